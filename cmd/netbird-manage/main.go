@@ -2,9 +2,14 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"netbird-manage/internal/client"
 	"netbird-manage/internal/commands"
@@ -15,6 +20,9 @@ import (
 var (
 	// debugMode is set to true when --debug flag is provided
 	debugMode = false
+
+	// timings collects per-endpoint API timings when --timings or --debug is provided
+	timings *client.Timings
 )
 
 func main() {
@@ -25,23 +33,153 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Check for global flags (--yes, --debug)
+	// Check for global flags (--yes, --confirm-phrase, --debug, --timings, --env-file, --template, --fields, --extra-header, --request-id, --max-in-flight, --api-version, --no-headers)
+	envFile := ""
+	apiVersion := ""
+	showTimings := false
 	filteredArgs := make([]string, 0, len(args))
-	for _, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		if arg == "--yes" || arg == "-y" {
 			helpers.SkipConfirmation = true
+		} else if arg == "--confirm-phrase" {
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --confirm-phrase requires the confirmation text")
+				os.Exit(1)
+			}
+			helpers.ConfirmPhrase = args[i+1]
+			i++
+		} else if strings.HasPrefix(arg, "--confirm-phrase=") {
+			helpers.ConfirmPhrase = strings.TrimPrefix(arg, "--confirm-phrase=")
 		} else if arg == "--debug" || arg == "-d" {
 			debugMode = true
+		} else if arg == "--timings" {
+			showTimings = true
+		} else if arg == "--no-headers" {
+			helpers.NoHeaders = true
+		} else if arg == "--env-file" {
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --env-file requires a path")
+				os.Exit(1)
+			}
+			envFile = args[i+1]
+			i++
+		} else if strings.HasPrefix(arg, "--env-file=") {
+			envFile = strings.TrimPrefix(arg, "--env-file=")
+		} else if arg == "--template" {
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --template requires a Go template")
+				os.Exit(1)
+			}
+			helpers.OutputTemplate = args[i+1]
+			i++
+		} else if strings.HasPrefix(arg, "--template=") {
+			helpers.OutputTemplate = strings.TrimPrefix(arg, "--template=")
+		} else if arg == "--fields" || strings.HasPrefix(arg, "--fields=") {
+			value := strings.TrimPrefix(arg, "--fields=")
+			if arg == "--fields" {
+				if i+1 >= len(args) {
+					fmt.Fprintln(os.Stderr, "Error: --fields requires a comma-separated list of field names")
+					os.Exit(1)
+				}
+				value = args[i+1]
+				i++
+			}
+			helpers.OutputFields = helpers.SplitCommaList(value)
+			if len(helpers.OutputFields) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --fields requires a comma-separated list of field names")
+				os.Exit(1)
+			}
+		} else if arg == "--extra-header" || strings.HasPrefix(arg, "--extra-header=") {
+			value := strings.TrimPrefix(arg, "--extra-header=")
+			if arg == "--extra-header" {
+				if i+1 >= len(args) {
+					fmt.Fprintln(os.Stderr, "Error: --extra-header requires 'Name: Value'")
+					os.Exit(1)
+				}
+				value = args[i+1]
+				i++
+			}
+			name, headerValue, err := config.ParseExtraHeader(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			config.ExtraHeaderFlags[name] = headerValue
+		} else if arg == "--request-id" {
+			if i+1 >= len(args) || strings.TrimSpace(args[i+1]) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --request-id requires an ID")
+				os.Exit(1)
+			}
+			client.FixedRequestID = strings.TrimSpace(args[i+1])
+			i++
+		} else if strings.HasPrefix(arg, "--request-id=") {
+			client.FixedRequestID = strings.TrimSpace(strings.TrimPrefix(arg, "--request-id="))
+		} else if arg == "--max-in-flight" || strings.HasPrefix(arg, "--max-in-flight=") {
+			value := strings.TrimPrefix(arg, "--max-in-flight=")
+			if arg == "--max-in-flight" {
+				if i+1 >= len(args) {
+					fmt.Fprintln(os.Stderr, "Error: --max-in-flight requires a number")
+					os.Exit(1)
+				}
+				value = args[i+1]
+				i++
+			}
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 1 {
+				fmt.Fprintf(os.Stderr, "Error: --max-in-flight must be a positive number, got '%s'\n", value)
+				os.Exit(1)
+			}
+			client.MaxInFlight = limit
+		} else if arg == "--api-version" || strings.HasPrefix(arg, "--api-version=") {
+			value := strings.TrimPrefix(arg, "--api-version=")
+			if arg == "--api-version" {
+				if i+1 >= len(args) {
+					fmt.Fprintln(os.Stderr, "Error: --api-version requires a server version, e.g. 0.35.0")
+					os.Exit(1)
+				}
+				value = args[i+1]
+				i++
+			}
+			apiVersion = strings.TrimSpace(value)
+			if _, ok := client.ParseVersion(apiVersion); !ok {
+				fmt.Fprintf(os.Stderr, "Error: --api-version must be a release version like 0.35.0, got '%s'\n", value)
+				os.Exit(1)
+			}
 		} else {
 			filteredArgs = append(filteredArgs, arg)
 		}
 	}
 	args = filteredArgs
 
+	// Record API timings for every client created during this run, including migrate's
+	if showTimings || debugMode {
+		timings = client.NewTimings()
+	}
+
+	// Load the env file before any config resolution
+	if envFile != "" {
+		if err := config.LoadEnvFile(envFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+	}
+
+	// Stored headers apply only to their own management URL; --extra-header applies to this
+	// run's URL (connect re-resolves them for the URL it connects to)
+	client.ExtraHeadersByURL = config.ResolveExtraHeaders(config.ManagementURL())
+
+	// Resolve the default --output format (NETBIRD_OUTPUT, then config file)
+	outputFormat, warning := config.DefaultOutputFormat()
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	helpers.DefaultOutputFormat = outputFormat
+
 	// Re-check after filtering
 	if len(args) == 0 {
 		commands.PrintUsage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	command := args[0]
@@ -50,18 +188,40 @@ func main() {
 	if command == "connect" {
 		if err := handleConnectCommand(args); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// The 'migrate' command is special: it uses its own tokens, not the saved config.
 	if command == "migrate" {
+		if len(config.ExtraHeaderFlags) > 0 {
+			fmt.Fprintln(os.Stderr, "Error: --extra-header does not apply to migrate; use --source-extra-header and --dest-extra-header")
+			exit(1)
+		}
 		if err := commands.HandleMigrateCommand(args, debugMode); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
+	}
+
+	// The 'doctor' command is special: it diagnoses a missing or broken config.
+	if command == "doctor" {
+		if err := commands.HandleDoctorCommand(args, debugMode); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	// The 'completion' command is special: it prints a static script and needs no config.
+	if command == "completion" {
+		if err := commands.HandleCompletionCommand(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		exit(0)
 	}
 
 	// Show help without requiring connection if just the command name is provided
@@ -69,193 +229,243 @@ func main() {
 		switch command {
 		case "peer":
 			commands.PrintPeerUsage()
-			os.Exit(0)
+			exit(0)
 		case "group", "groups":
 			commands.PrintGroupUsage()
-			os.Exit(0)
+			exit(0)
 		case "network":
 			commands.PrintNetworkUsage()
-			os.Exit(0)
+			exit(0)
 		case "policy":
 			commands.PrintPolicyUsage()
-			os.Exit(0)
+			exit(0)
 		case "setup-key":
 			commands.PrintSetupKeyUsage()
-			os.Exit(0)
+			exit(0)
 		case "user":
 			commands.PrintUserUsage()
-			os.Exit(0)
+			exit(0)
 		case "token":
 			commands.PrintTokenUsage()
-			os.Exit(0)
+			exit(0)
 		case "route":
 			commands.PrintRouteUsage()
-			os.Exit(0)
+			exit(0)
 		case "dns":
 			commands.PrintDNSUsage()
-			os.Exit(0)
+			exit(0)
 		case "posture-check", "posture":
 			commands.PrintPostureCheckUsage()
-			os.Exit(0)
+			exit(0)
 		case "event", "events":
 			commands.PrintEventUsage()
-			os.Exit(0)
+			exit(0)
 		case "geo", "geo-location", "location":
 			commands.PrintGeoLocationUsage()
-			os.Exit(0)
+			exit(0)
 		case "account", "accounts":
 			commands.PrintAccountUsage()
-			os.Exit(0)
+			exit(0)
 		case "ingress-port", "ingress":
 			commands.PrintIngressPortUsage()
-			os.Exit(0)
+			exit(0)
 		case "ingress-peer":
 			commands.PrintIngressPeerUsage()
-			os.Exit(0)
+			exit(0)
 		case "export":
 			commands.PrintExportUsage()
-			os.Exit(0)
+			exit(0)
 		case "import":
 			commands.PrintImportUsage()
-			os.Exit(0)
+			exit(0)
 		case "migrate":
 			commands.PrintMigrateUsage()
-			os.Exit(0)
+			exit(0)
 		case "help", "--help":
 			commands.PrintUsage()
-			os.Exit(0)
+			exit(0)
 		}
 	}
 
 	// For all other commands, load the config first
 	cfg, err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrNoToken) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error: Not connected.")
 		fmt.Fprintln(os.Stderr, "Please run 'netbird-manage connect --token <your_token>'")
 		fmt.Fprintln(os.Stderr, "or set the NETBIRD_API_TOKEN environment variable.")
-		os.Exit(1)
+		exit(1)
 	}
 
 	c := client.New(cfg.Token, cfg.ManagementURL)
 	c.Debug = debugMode
 
+	// Guarded commands need the server version to refuse features the server is too old for
+	if commands.CommandNeedsServerVersion(command) {
+		c.ServerVersion = resolveServerVersion(c, apiVersion)
+	}
+
 	svc := commands.NewService(c)
+	if err := svc.CheckServerFeature(command); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	if command == "help" || command == "--help" {
+		commands.PrintUsage()
+		exit(0)
+	}
 
 	// Route the command to the correct handler
+	handler := commandHandler(svc, command)
+	if handler == nil {
+		fmt.Fprintf(os.Stderr, "Error: Unknown command '%s'\n", command)
+		commands.PrintUsage()
+		exit(1)
+	}
+	if err := handler(args); err != nil {
+		fail(command, err)
+	}
+
+	exit(0)
+}
+
+// commandHandler returns the Service handler for a config-backed command, or nil if unknown
+func commandHandler(svc *commands.Service, command string) func(args []string) error {
 	switch command {
 	case "peer":
-		if err := svc.HandlePeersCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandlePeersCommand
 	case "network":
-		if err := svc.HandleNetworkCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleNetworkCommand
 	case "policy":
-		if err := svc.HandlePoliciesCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandlePoliciesCommand
 	case "group", "groups":
-		if err := svc.HandleGroupsCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleGroupsCommand
 	case "setup-key":
-		if err := svc.HandleSetupKeysCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleSetupKeysCommand
 	case "user":
-		if err := svc.HandleUsersCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleUsersCommand
 	case "token":
-		if err := svc.HandleTokensCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleTokensCommand
 	case "route":
-		if err := svc.HandleRoutesCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleRoutesCommand
 	case "dns":
-		if err := svc.HandleDNSCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleDNSCommand
 	case "posture-check", "posture":
-		if err := svc.HandlePostureChecksCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandlePostureChecksCommand
 	case "event", "events":
-		if err := svc.HandleEventsCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleEventsCommand
 	case "geo", "geo-location", "location":
-		if err := svc.HandleGeoLocationsCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleGeoLocationsCommand
 	case "account", "accounts":
-		if err := svc.HandleAccountsCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleAccountsCommand
 	case "ingress-port", "ingress":
-		if err := svc.HandleIngressPortsCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleIngressPortsCommand
 	case "ingress-peer":
-		if err := svc.HandleIngressPeersCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleIngressPeersCommand
 	case "export":
-		if err := svc.HandleExportCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		return svc.HandleExportCommand
 	case "import":
-		if err := svc.HandleImportCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+		return svc.HandleImportCommand
+	}
+	return nil
+}
+
+// exit prints the API timing summary (if enabled) and, with --debug, the last request ID,
+// then terminates with the given code
+func exit(code int) {
+	if timings != nil {
+		timings.Print(os.Stderr)
+	}
+	if debugMode {
+		if id := client.LastRequestID(); id != "" {
+			fmt.Fprintf(os.Stderr, "Last request ID: %s\n", id)
 		}
-	case "help", "--help":
-		commands.PrintUsage()
+	}
+	os.Exit(code)
+}
 
-	default:
-		fmt.Fprintf(os.Stderr, "Error: Unknown command '%s'\n", command)
-		commands.PrintUsage()
-		os.Exit(1)
+// fail reports a command error, explaining a 404 from a feature the server may lack, and exits
+func fail(command string, err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", commands.ExplainMissingFeature(command, err))
+	exit(1)
+}
+
+// resolveServerVersion returns the management server version: --api-version when given,
+// otherwise the version cached for this management URL, otherwise a fresh probe (which is
+// then cached). An empty result means the server does not report its version.
+func resolveServerVersion(c *client.Client, apiVersion string) string {
+	if apiVersion != "" {
+		return apiVersion
+	}
+	if version, ok := config.CachedServerVersion(c.ManagementURL); ok {
+		if debugMode && version != "" {
+			fmt.Fprintf(os.Stderr, "Management server version: %s (cached)\n", version)
+		}
+		return version
 	}
+
+	version, err := c.FetchServerVersion()
+	if err != nil {
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "Management server version unknown: %v\n", err)
+		}
+		// Only remember "not reported" when the server answered; retry after network errors
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			config.SaveServerVersion(c.ManagementURL, "")
+		}
+		return ""
+	}
+	if debugMode {
+		fmt.Fprintf(os.Stderr, "Management server version: %s\n", version)
+	}
+	config.SaveServerVersion(c.ManagementURL, version)
+	return version
 }
 
 // handleConnectCommand parses flags for the connect command
 func handleConnectCommand(args []string) error {
 	connectCmd := flag.NewFlagSet("connect", flag.ContinueOnError)
 	tokenFlag := connectCmd.String("token", "", "Your NetBird API token (Personal Access Token or Service User token)")
+	tokenFromStdinFlag := connectCmd.Bool("token-from-stdin", false, "Read the API token from stdin instead of --token")
+	tokenFromFileFlag := connectCmd.String("token-from-file", "", "Read the API token from a file instead of --token")
 	urlFlag := connectCmd.String("management-url", "", "Your self-hosted management URL (optional, defaults to NetBird cloud)")
+	defaultOutputFlag := connectCmd.String("default-output", "", "Default output format for list/inspect commands (table, wide, or json)")
+	skipValidationFlag := connectCmd.Bool("skip-validation", false, "Save the token without testing the connection (for offline setups)")
+	encryptFlag := connectCmd.Bool("encrypt", false, "Encrypt the stored token with a passphrase (prompted, or from "+config.EnvConfigPassphrase+")")
 
 	if err := connectCmd.Parse(args[1:]); err != nil {
 		return nil // flag package will print error
 	}
 
+	// Read the token from stdin or a file so it stays out of shell history and process listings
+	token, err := readConnectToken(*tokenFlag, *tokenFromStdinFlag, *tokenFromFileFlag)
+	if err != nil {
+		return err
+	}
+
+	// Store the default output format on its own or alongside new credentials
+	if *defaultOutputFlag != "" {
+		if err := config.SaveDefaultOutput(*defaultOutputFlag); err != nil {
+			return err
+		}
+		if token == "" && *urlFlag == "" {
+			return nil
+		}
+	}
+
 	// If no flags are provided, show status
-	if *tokenFlag == "" && *urlFlag == "" {
+	if token == "" && *urlFlag == "" {
 		return handleConnectStatus()
 	}
 
 	// If token is missing
-	if *tokenFlag == "" {
-		return fmt.Errorf("missing required flag: --token")
+	if token == "" {
+		return fmt.Errorf("missing required flag: --token (or --token-from-stdin / --token-from-file)")
 	}
 
 	// If URL is missing, use default
@@ -264,14 +474,69 @@ func handleConnectCommand(args []string) error {
 		mgmtURL = config.DefaultCloudURL
 	}
 
+	// Test the new URL with its own stored headers and --extra-header, never another URL's
+	client.ExtraHeadersByURL = config.ResolveExtraHeaders(mgmtURL)
+
+	if *skipValidationFlag {
+		return config.SaveWithoutValidation(token, mgmtURL, *encryptFlag)
+	}
+
 	// Test and save the new configuration
-	return config.TestAndSave(*tokenFlag, mgmtURL)
+	return config.TestAndSave(token, mgmtURL, *encryptFlag)
+}
+
+// readConnectToken returns the token from --token, --token-from-stdin, or --token-from-file.
+// At most one source may be given; surrounding whitespace and a trailing newline are trimmed.
+func readConnectToken(tokenFlag string, fromStdin bool, fromFile string) (string, error) {
+	sources := 0
+	for _, set := range []bool{tokenFlag != "", fromStdin, fromFile != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", fmt.Errorf("use only one of --token, --token-from-stdin, or --token-from-file")
+	}
+
+	var token string
+	switch {
+	case fromStdin:
+		if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprint(os.Stderr, "API token: ")
+		}
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read token from stdin: %v", err)
+		}
+		token = strings.TrimSpace(line)
+		if token == "" {
+			return "", fmt.Errorf("no token received on stdin")
+		}
+	case fromFile != "":
+		data, err := os.ReadFile(fromFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %v", err)
+		}
+		token = strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("token file '%s' is empty", fromFile)
+		}
+		if strings.ContainsAny(token, "\r\n") {
+			return "", fmt.Errorf("token file '%s' must contain only the token", fromFile)
+		}
+	default:
+		token = tokenFlag
+	}
+	return token, nil
 }
 
 // handleConnectStatus shows the current connection status
 func handleConnectStatus() error {
 	fmt.Println("Checking connection status...")
 	cfg, err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrNoToken) {
+		return err
+	}
 	if err != nil {
 		fmt.Println("Status: Not connected.")
 		fmt.Println("Run 'netbird-manage connect --token <token>' to connect.")
@@ -280,6 +545,7 @@ func handleConnectStatus() error {
 
 	fmt.Printf("Status:         Connected\n")
 	fmt.Printf("Management URL: %s\n", cfg.ManagementURL)
+	fmt.Printf("Default Output: %s\n", helpers.DefaultOutputFormat)
 
 	// Try to validate the token
 	c := client.New(cfg.Token, cfg.ManagementURL)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/commands"
+)
+
+// pathRecorder is a fake management API that answers every request with an empty list
+// and records the paths it was asked for
+type pathRecorder struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+func (r *pathRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	r.paths[req.URL.Path] = true
+	r.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, `[]`)
+}
+
+// TestCommandHandlerRoutesThroughService checks that the network, policy, group, and
+// posture-check commands (and their aliases) dispatch to the Service handlers
+func TestCommandHandlerRoutesThroughService(t *testing.T) {
+	tests := []struct {
+		command  string
+		wantPath string
+	}{
+		{command: "network", wantPath: "/api/networks"},
+		{command: "policy", wantPath: "/api/policies"},
+		{command: "group", wantPath: "/api/groups"},
+		{command: "groups", wantPath: "/api/groups"},
+		{command: "posture-check", wantPath: "/api/posture-checks"},
+		{command: "posture", wantPath: "/api/posture-checks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			recorder := &pathRecorder{paths: make(map[string]bool)}
+			server := httptest.NewServer(recorder)
+			defer server.Close()
+
+			svc := commands.NewService(client.New("test-token", server.URL+"/api"))
+			handler := commandHandler(svc, tt.command)
+			if handler == nil {
+				t.Fatalf("commandHandler(%q) = nil, want a Service handler", tt.command)
+			}
+			if err := handler([]string{tt.command, "--list"}); err != nil {
+				t.Fatalf("%s --list: %v", tt.command, err)
+			}
+			if !recorder.paths[tt.wantPath] {
+				t.Errorf("%s --list did not request %s (requested: %v)", tt.command, tt.wantPath, recorder.paths)
+			}
+		})
+	}
+}
+
+func TestCommandHandlerUnknownCommand(t *testing.T) {
+	svc := commands.NewService(client.New("test-token", "http://127.0.0.1/api"))
+	for _, command := range []string{"networks", "policies", "bogus"} {
+		if commandHandler(svc, command) != nil {
+			t.Errorf("commandHandler(%q) returned a handler, want nil", command)
+		}
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client holds the API token and HTTP client
@@ -17,6 +20,97 @@ type Client struct {
 	ManagementURL string // URL to the NetBird Management API
 	HTTPClient    *http.Client
 	Debug         bool // Enable verbose debug output
+
+	// ServerVersion is the management server version (from --api-version or a probe of
+	// /instance/version); empty when unknown
+	ServerVersion string
+
+	// ExtraHeaders are sent with every request, e.g. for an authenticating proxy in front of the API
+	ExtraHeaders map[string]string
+
+	cacheMu sync.Mutex
+	cache   map[string][]json.RawMessage // Complete listings from GetCachedList, keyed by endpoint
+
+	inFlight chan struct{} // Semaphore limiting concurrent requests (see MaxInFlight)
+}
+
+// APIError is returned by MakeRequest for non-2xx responses.
+// It carries the decoded NetBird error message so callers can report actionable
+// errors and special-case status codes with errors.As.
+type APIError struct {
+	StatusCode int    // HTTP status code (e.g. 404)
+	Status     string // HTTP status text (e.g. "404 Not Found")
+	Code       int    // NetBird error code from the response body, if any
+	Message    string // NetBird error message from the response body, if any
+	Body       []byte // Raw response body (shown in --debug output)
+
+	RequestID       string // Client request ID sent in the X-Request-Id header
+	ServerRequestID string // Request ID reported by the server or a proxy, if any
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("api request failed: %s", e.Status)
+	if e.Message != "" {
+		msg = fmt.Sprintf("api request failed (%s): %s", e.Status, e.Message)
+	}
+	return msg + e.requestIDSuffix()
+}
+
+// requestIDSuffix names the request ID to quote in support cases, preferring the server's
+func (e *APIError) requestIDSuffix() string {
+	switch {
+	case e.ServerRequestID != "" && e.ServerRequestID != e.RequestID:
+		return fmt.Sprintf(" [server request ID: %s, client request ID: %s]", e.ServerRequestID, e.RequestID)
+	case e.ServerRequestID != "":
+		return fmt.Sprintf(" [request ID: %s]", e.ServerRequestID)
+	case e.RequestID != "":
+		return fmt.Sprintf(" [client request ID: %s]", e.RequestID)
+	}
+	return ""
+}
+
+// IsNotFound reports whether the API responded with 404 Not Found
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsForbidden reports whether the API responded with 403 Forbidden
+func (e *APIError) IsForbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
+// RequestHook is called after every API request completes with the normalized method,
+// endpoint, response status (0 if the request failed before a response), and duration
+type RequestHook func(method, endpoint string, statusCode int, duration time.Duration)
+
+var (
+	hooksMu      sync.Mutex
+	requestHooks []RequestHook
+)
+
+// AddRequestHook registers a hook that runs after each request made by any client
+func AddRequestHook(hook RequestHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	requestHooks = append(requestHooks, hook)
+}
+
+// runRequestHooks notifies all registered hooks about a completed request
+func runRequestHooks(method, endpoint string, statusCode int, duration time.Duration) {
+	hooksMu.Lock()
+	hooks := requestHooks
+	hooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook(method, endpoint, statusCode, duration)
+	}
+}
+
+// cacheDependents lists resource types whose cached responses embed another resource type.
+// A mutation to the key invalidates the listed types as well.
+var cacheDependents = map[string][]string{
+	"peers": {"groups"}, // Group responses embed their peers
 }
 
 // New creates a new NetBird API client
@@ -25,13 +119,91 @@ func New(token, managementURL string) *Client {
 		Token:         token,
 		ManagementURL: managementURL,
 		HTTPClient:    &http.Client{},
+		ExtraHeaders:  copyHeaders(ExtraHeadersByURL[NormalizeBaseURL(managementURL)]),
+		inFlight:      newInFlightLimiter(),
+	}
+}
+
+// ExtraHeadersByURL holds extra headers per management URL, keyed by NormalizeBaseURL. New
+// gives a client only the entry for its own URL, so headers meant for one server (often proxy
+// credentials) are never sent to another. Resolved once at startup from the config file and
+// --extra-header flags.
+var ExtraHeadersByURL map[string]map[string]string
+
+// NormalizeBaseURL returns the key used for per-URL settings: lower-case scheme and host,
+// no trailing slash
+func NormalizeBaseURL(managementURL string) string {
+	trimmed := strings.TrimRight(strings.TrimSpace(managementURL), "/")
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return trimmed
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	return parsed.String()
+}
+
+// copyHeaders returns a copy of headers so a client's changes do not leak into the shared map
+func copyHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(headers))
+	for name, value := range headers {
+		copied[name] = value
+	}
+	return copied
+}
+
+// invalidateCache drops cached responses for the resource type an endpoint belongs to
+func (c *Client) invalidateCache(endpoint string) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if len(c.cache) == 0 {
+		return
+	}
+
+	resourceType := resourceTypeOf(endpoint)
+	types := append([]string{resourceType}, cacheDependents[resourceType]...)
+	for key := range c.cache {
+		for _, t := range types {
+			if resourceTypeOf(key) == t {
+				delete(c.cache, key)
+				break
+			}
+		}
 	}
 }
 
+// hasExtraHeader reports whether a canonical header name was set from ExtraHeaders
+func (c *Client) hasExtraHeader(key string) bool {
+	for name := range c.ExtraHeaders {
+		if http.CanonicalHeaderKey(name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceTypeOf returns the first path segment of an endpoint (e.g. "/groups/abc" -> "groups")
+func resourceTypeOf(endpoint string) string {
+	trimmed := strings.TrimPrefix(endpoint, "/")
+	if i := strings.IndexAny(trimmed, "/?"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	return trimmed
+}
+
 // MakeRequest is a helper function to create and send authenticated API requests
 func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Response, error) {
 	url := c.ManagementURL + endpoint
 
+	// Any mutation may change cached listings of the same resource type
+	if method != "GET" {
+		c.invalidateCache(endpoint)
+	}
+
 	// Debug: Log request details
 	if c.Debug {
 		fmt.Fprintf(os.Stderr, "\n=== DEBUG: HTTP REQUEST ===\n")
@@ -54,6 +226,11 @@ func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Res
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
+	// Set extra headers first so they cannot replace authentication or content type
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+
 	// Set authentication and content type headers
 	req.Header.Set("Authorization", "Token "+c.Token)
 	req.Header.Set("Accept", "application/json")
@@ -61,6 +238,15 @@ func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Res
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	// Tag the request for correlation with server logs, unless an extra header already does
+	if req.Header.Get(RequestIDHeader) == "" {
+		if id := newRequestID(); id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+	}
+	requestID := req.Header.Get(RequestIDHeader)
+	recordRequestID(requestID)
+
 	// Debug: Log request headers (redact token)
 	if c.Debug {
 		fmt.Fprintf(os.Stderr, "\nHeaders:\n")
@@ -69,6 +255,9 @@ func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Res
 			if key == "Authorization" {
 				// Redact token for security
 				value = "Token [REDACTED]"
+			} else if c.hasExtraHeader(key) {
+				// Extra headers usually carry proxy credentials
+				value = "[REDACTED]"
 			}
 			fmt.Fprintf(os.Stderr, "  %s: %s\n", key, value)
 		}
@@ -85,12 +274,24 @@ func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Res
 		}
 	}
 
+	// Wait for a free slot before timing so queueing is not reported as API latency
+	c.acquireSlot()
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
+	c.releaseSlot()
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	runRequestHooks(method, endpoint, statusCode, time.Since(start))
 	if err != nil {
 		if c.Debug {
 			fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
 		}
-		return nil, fmt.Errorf("api request failed: %v", err)
+		if requestID != "" {
+			return nil, fmt.Errorf("api request failed [client request ID: %s]: %w", requestID, err)
+		}
+		return nil, fmt.Errorf("api request failed: %w", err)
 	}
 
 	// Debug: Log response details
@@ -121,16 +322,24 @@ func (c *Client) MakeRequest(method, endpoint string, body io.Reader) (*http.Res
 			}
 		}
 
-		var apiError struct {
+		apiErr := &APIError{
+			StatusCode:      resp.StatusCode,
+			Status:          resp.Status,
+			Body:            respBody,
+			RequestID:       requestID,
+			ServerRequestID: serverRequestID(resp.Header),
+		}
+
+		// Try to decode the error response from NetBird; fall back to the status for non-JSON errors
+		var errorBody struct {
 			Message string `json:"message"`
 			Code    int    `json:"code"`
 		}
-		// Try to decode the error response from NetBird
-		if err := json.Unmarshal(respBody, &apiError); err == nil {
-			return resp, fmt.Errorf("api request failed: %d %s (status code: %d) %s", apiError.Code, apiError.Message, resp.StatusCode, resp.Status)
+		if err := json.Unmarshal(respBody, &errorBody); err == nil {
+			apiErr.Message = errorBody.Message
+			apiErr.Code = errorBody.Code
 		}
-		// Fallback for non-JSON errors
-		return resp, fmt.Errorf("api request failed: %s", resp.Status)
+		return resp, apiErr
 	}
 
 	// Debug: Log successful response body
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// maxIncompleteRetries is how many times an empty or truncated GET response is retried
+const maxIncompleteRetries = 1
+
+// GetJSON performs a GET request and decodes the JSON response into v.
+// An empty or truncated response body (typical of a dropped connection) is retried
+// once, and reported as an incomplete response rather than a bare "EOF".
+func (c *Client) GetJSON(endpoint string, v interface{}) error {
+	_, body, err := c.getJSONBody(endpoint)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode %s response: %v", resourceTypeOf(endpoint), err)
+	}
+	return nil
+}
+
+// getJSONBody performs a GET request and returns the response with its body fully read,
+// retrying when the body is empty or cut off mid-document
+func (c *Client) getJSONBody(endpoint string) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.MakeRequest("GET", endpoint, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if readErr == nil && !isIncompleteJSON(body) {
+			return resp, body, nil
+		}
+
+		if c.Debug {
+			fmt.Fprintf(os.Stderr, "\n=== DEBUG: INCOMPLETE RESPONSE ===\n")
+			fmt.Fprintf(os.Stderr, "GET %s%s returned %d body bytes", c.ManagementURL, endpoint, len(body))
+			if readErr != nil {
+				fmt.Fprintf(os.Stderr, " (read error: %v)", readErr)
+			}
+			fmt.Fprintf(os.Stderr, "\n===========================\n\n")
+		}
+
+		if attempt >= maxIncompleteRetries {
+			return nil, nil, fmt.Errorf("incomplete response from %s (%d bytes received after %d attempts); the connection may be unstable, please retry",
+				endpoint, len(body), attempt+1)
+		}
+	}
+}
+
+// isIncompleteJSON reports whether a body is empty or ends before the JSON document does.
+// Bodies that are complete but malformed are left to the decoder to report.
+func isIncompleteJSON(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return true
+	}
+	if json.Valid(trimmed) {
+		return false
+	}
+
+	var raw json.RawMessage
+	err := json.Unmarshal(trimmed, &raw)
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(trimmed))
+}
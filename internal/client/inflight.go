@@ -0,0 +1,31 @@
+package client
+
+// DefaultMaxInFlight is the default cap on concurrent requests per client
+const DefaultMaxInFlight = 8
+
+// MaxInFlight, set from --max-in-flight, caps the requests each client created by New has
+// in flight at once. Per-command --concurrency flags bound their own workers, but all of
+// them share this ceiling, so nested parallel loops cannot exceed it.
+var MaxInFlight = DefaultMaxInFlight
+
+// newInFlightLimiter returns a semaphore of MaxInFlight slots, or nil for no limit
+func newInFlightLimiter() chan struct{} {
+	if MaxInFlight < 1 {
+		return nil
+	}
+	return make(chan struct{}, MaxInFlight)
+}
+
+// acquireSlot blocks until the client may send another request
+func (c *Client) acquireSlot() {
+	if c.inFlight != nil {
+		c.inFlight <- struct{}{}
+	}
+}
+
+// releaseSlot frees the slot taken by acquireSlot
+func (c *Client) releaseSlot() {
+	if c.inFlight != nil {
+		<-c.inFlight
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxListPages caps how many pages GetList follows for one endpoint, so a server that
+// keeps returning "next" links can't make a command loop forever
+const MaxListPages = 100
+
+// linkNextPattern extracts the rel="next" target from an RFC 8288 Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// pageEnvelope is the paginated response shape: items under "data" plus either a
+// "next" link or page counters
+type pageEnvelope struct {
+	Data       []json.RawMessage `json:"data"`
+	Next       string            `json:"next"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// GetList fetches every item of a list endpoint and decodes them into T.
+// Today the NetBird API returns plain JSON arrays, which are handled in a single request.
+// If an endpoint starts paginating, GetList follows Link headers, "next" links, or
+// page/total_pages counters until exhausted, stopping at MaxListPages with a warning.
+func GetList[T any](c *Client, endpoint string) ([]T, error) {
+	raw, err := c.fetchAllPages(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return decodeListItems[T](raw, endpoint)
+}
+
+// decodeListItems decodes the raw items of a listing into T
+func decodeListItems[T any](raw []json.RawMessage, endpoint string) ([]T, error) {
+	items := make([]T, 0, len(raw))
+	for _, item := range raw {
+		var decoded T
+		if err := json.Unmarshal(item, &decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode %s response: %v", resourceTypeOf(endpoint), err)
+		}
+		items = append(items, decoded)
+	}
+	return items, nil
+}
+
+// GetCachedList is GetList with a per-client cache: the complete (all pages) listing is kept
+// for the rest of the command run. Use it only for listings that are stable within one
+// invocation, such as /groups during name resolution; any mutating MakeRequest to the same
+// resource type invalidates the entry.
+func GetCachedList[T any](c *Client, endpoint string) ([]T, error) {
+	c.cacheMu.Lock()
+	raw, ok := c.cache[endpoint]
+	c.cacheMu.Unlock()
+
+	if ok {
+		if c.Debug {
+			fmt.Fprintf(os.Stderr, "\n=== DEBUG: CACHE HIT ===\nGET %s%s\n===========================\n\n", c.ManagementURL, endpoint)
+		}
+	} else {
+		var err error
+		if raw, err = c.fetchAllPages(endpoint); err != nil {
+			return nil, err
+		}
+		c.cacheMu.Lock()
+		if c.cache == nil {
+			c.cache = make(map[string][]json.RawMessage)
+		}
+		c.cache[endpoint] = raw
+		c.cacheMu.Unlock()
+	}
+
+	return decodeListItems[T](raw, endpoint)
+}
+
+// fetchAllPages collects the raw items from every page of a list endpoint
+func (c *Client) fetchAllPages(endpoint string) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	next := endpoint
+
+	for page := 1; next != ""; page++ {
+		if page > MaxListPages {
+			fmt.Fprintf(os.Stderr, "Warning: stopped fetching %s after %d pages; results may be incomplete\n", endpoint, MaxListPages)
+			break
+		}
+
+		resp, body, err := c.getJSONBody(next)
+		if err != nil {
+			return nil, err
+		}
+
+		current := next
+		next = ""
+
+		// Plain array: complete unless the server sent a Link header
+		var pageItems []json.RawMessage
+		if err := json.Unmarshal(body, &pageItems); err == nil {
+			items = append(items, pageItems...)
+			if match := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+				if next, err = c.relativeEndpoint(match[1]); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		// Envelope: follow "next", or request the following page number
+		var envelope pageEnvelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to decode %s response: %v", resourceTypeOf(endpoint), err)
+		}
+		items = append(items, envelope.Data...)
+
+		switch {
+		case envelope.Next != "":
+			if next, err = c.relativeEndpoint(envelope.Next); err != nil {
+				return nil, err
+			}
+		case envelope.TotalPages > envelope.Page && envelope.Page > 0 && len(envelope.Data) > 0:
+			next = withPageParams(current, envelope.Page+1, envelope.PageSize)
+		}
+	}
+
+	return items, nil
+}
+
+// relativeEndpoint converts a next-page link into an endpoint for MakeRequest.
+// Absolute links must point at the client's management URL so the token is never
+// sent to another host.
+func (c *Client) relativeEndpoint(link string) (string, error) {
+	if strings.HasPrefix(link, "/") {
+		// Server-relative links may include the API base path (e.g. /api/peers?page=2)
+		base, err := url.Parse(c.ManagementURL)
+		if err == nil && base.Path != "" && strings.HasPrefix(link, base.Path+"/") {
+			return strings.TrimPrefix(link, base.Path), nil
+		}
+		return link, nil
+	}
+	if strings.HasPrefix(link, c.ManagementURL+"/") {
+		return strings.TrimPrefix(link, c.ManagementURL), nil
+	}
+	return "", fmt.Errorf("refusing to follow pagination link outside the management URL: %s", link)
+}
+
+// withPageParams sets page (and page_size, when known) on an endpoint's query string
+func withPageParams(endpoint string, page, pageSize int) string {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		query = url.Values{}
+	}
+	query.Set("page", strconv.Itoa(page))
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return path + "?" + query.Encode()
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+)
+
+// RequestIDHeader carries the client-generated correlation ID on every request
+const RequestIDHeader = "X-Request-Id"
+
+// serverRequestIDHeaders are response headers servers and proxies commonly use to
+// report their own request ID, checked in order
+var serverRequestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "X-Amzn-Trace-Id", "Cf-Ray"}
+
+// FixedRequestID, when set (from --request-id), is sent on every request instead of a
+// generated ID so a whole run can be found in server logs with one value
+var FixedRequestID string
+
+var (
+	requestIDMu   sync.Mutex
+	lastRequestID string
+)
+
+// newRequestID returns FixedRequestID or a random 16-byte hex ID
+func newRequestID() string {
+	if FixedRequestID != "" {
+		return FixedRequestID
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// recordRequestID remembers the ID of the most recent request made by any client
+func recordRequestID(id string) {
+	requestIDMu.Lock()
+	defer requestIDMu.Unlock()
+	lastRequestID = id
+}
+
+// LastRequestID returns the client request ID of the most recent API request, if any
+func LastRequestID() string {
+	requestIDMu.Lock()
+	defer requestIDMu.Unlock()
+	return lastRequestID
+}
+
+// serverRequestID returns the request ID reported in response headers, if any
+func serverRequestID(header http.Header) string {
+	for _, name := range serverRequestIDHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Timings accumulates per-endpoint API call counts and durations for a command run
+type Timings struct {
+	mu    sync.Mutex
+	stats map[string]*endpointTiming
+}
+
+// endpointTiming holds the totals for one normalized endpoint
+type endpointTiming struct {
+	key   string
+	calls int
+	total time.Duration
+}
+
+// NewTimings creates a Timings collector and registers it as a request hook
+func NewTimings() *Timings {
+	t := &Timings{stats: make(map[string]*endpointTiming)}
+	AddRequestHook(t.record)
+	return t
+}
+
+// record is the RequestHook that accumulates a single call
+func (t *Timings) record(method, endpoint string, statusCode int, duration time.Duration) {
+	key := method + " " + normalizeEndpoint(endpoint)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, ok := t.stats[key]
+	if !ok {
+		stat = &endpointTiming{key: key}
+		t.stats[key] = stat
+	}
+	stat.calls++
+	stat.total += duration
+}
+
+// Print writes a summary table sorted by total time (slowest first)
+func (t *Timings) Print(w io.Writer) {
+	t.mu.Lock()
+	stats := make([]endpointTiming, 0, len(t.stats))
+	for _, stat := range t.stats {
+		stats = append(stats, *stat)
+	}
+	t.mu.Unlock()
+
+	if len(stats) == 0 {
+		return
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].total != stats[j].total {
+			return stats[i].total > stats[j].total
+		}
+		return stats[i].key < stats[j].key
+	})
+
+	var calls int
+	var total time.Duration
+	for _, stat := range stats {
+		calls += stat.calls
+		total += stat.total
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "=== API TIMINGS ===")
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ENDPOINT\tCALLS\tTOTAL MS\tAVG MS")
+	fmt.Fprintln(tw, "--------\t-----\t--------\t------")
+	for _, stat := range stats {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", stat.key, stat.calls, stat.total.Milliseconds(), (stat.total / time.Duration(stat.calls)).Milliseconds())
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%d\n", calls, total.Milliseconds(), (total / time.Duration(calls)).Milliseconds())
+	tw.Flush()
+}
+
+// normalizeEndpoint strips query strings and replaces resource IDs with {id}
+// so calls to the same endpoint for different resources are grouped together
+func normalizeEndpoint(endpoint string) string {
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+
+	segments := strings.Split(endpoint, "/")
+	for i, segment := range segments {
+		if isIDSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment looks like an ID rather than a fixed path name.
+// Fixed names are lowercase words with hyphens (e.g. "network-traffic"); anything else is an ID.
+func isIDSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if (r < 'a' || r > 'z') && r != '-' {
+			return true
+		}
+	}
+	// NetBird IDs are 20-character lowercase strings; treat long unhyphenated segments as IDs
+	return len(segment) >= 20 && !strings.Contains(segment, "-")
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FetchServerVersion asks the management server for its version via /instance/version.
+// Older servers do not expose the endpoint; that is reported as an error.
+func (c *Client) FetchServerVersion() (string, error) {
+	resp, err := c.MakeRequest("GET", "/instance/version", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var version struct {
+		ManagementCurrentVersion string `json:"management_current_version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("failed to decode version response: %v", err)
+	}
+	if version.ManagementCurrentVersion == "" {
+		return "", fmt.Errorf("server did not report a management version")
+	}
+	return version.ManagementCurrentVersion, nil
+}
+
+// CompareVersions compares dotted versions such as "v0.35.2" and "0.36".
+// It returns -1, 0, or 1, and ok=false when either side is not a release version
+// (e.g. "development"), in which case callers should not draw conclusions.
+func CompareVersions(a, b string) (cmp int, ok bool) {
+	left, ok := ParseVersion(a)
+	if !ok {
+		return 0, false
+	}
+	right, ok := ParseVersion(b)
+	if !ok {
+		return 0, false
+	}
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r int
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		if l != r {
+			if l < r {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// ParseVersion splits "v1.2.3" (ignoring any "-rc1", "+build", or " (details)" suffix) into
+// its numbers. It reports false for non-release versions such as "development".
+func ParseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
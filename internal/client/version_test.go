@@ -0,0 +1,29 @@
+package client
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{a: "0.35.0", b: "0.35.0", want: 0, wantOK: true},
+		{a: "v0.35.2", b: "0.36", want: -1, wantOK: true},
+		{a: "0.36", b: "0.35.9", want: 1, wantOK: true},
+		{a: "0.35", b: "0.35.0", want: 0, wantOK: true},
+		{a: "0.28.4-dev", b: "0.28.4", want: 0, wantOK: true},
+		{a: "0.28.4+abc123", b: "0.28.3", want: 1, wantOK: true},
+		{a: "0.28.4 (linux/amd64)", b: "0.28.4", want: 0, wantOK: true},
+		{a: "development", b: "0.28.4", wantOK: false},
+		{a: "0.28.4", b: "", wantOK: false},
+		{a: "0.x.1", b: "0.28.4", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		cmp, ok := CompareVersions(tt.a, tt.b)
+		if ok != tt.wantOK || (ok && cmp != tt.want) {
+			t.Errorf("CompareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, cmp, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// account_settings.go - Account settings baseline export and drift check
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/models"
+)
+
+// getCurrentAccount returns the account the API token belongs to
+func (s *Service) getCurrentAccount() (*models.Account, error) {
+	accounts, err := client.GetList[models.Account](s.Client, "/accounts")
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no account found for this token")
+	}
+	return &accounts[0], nil
+}
+
+// accountSettingsMap serializes settings keyed by their API field names, so baselines
+// use the same names as the API and JSON output
+func accountSettingsMap(settings models.AccountSettings) (map[string]interface{}, error) {
+	return normalizeSettingsValue(settings)
+}
+
+// normalizeSettingsValue round-trips a value through JSON so YAML- and API-sourced
+// settings compare with the same types (numbers as float64, lists as []interface{})
+func normalizeSettingsValue(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// exportAccountSettings implements "account --export-settings <file>"
+func (s *Service) exportAccountSettings(path string) error {
+	account, err := s.getCurrentAccount()
+	if err != nil {
+		return err
+	}
+
+	settings, err := accountSettingsMap(account.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to serialize settings: %v", err)
+	}
+
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	if err := writeDataFile(path, settings, format); err != nil {
+		return err
+	}
+
+	fmt.Printf("Account settings saved to %s\n", path)
+	return nil
+}
+
+// diffAccountSettings implements "account --settings-diff <baseline>". Only fields in the
+// baseline are compared; it returns an error when any differ so scheduled checks can fail.
+func (s *Service) diffAccountSettings(baselinePath string) error {
+	rawBaseline, err := loadYAMLFromFile(baselinePath)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline: %v", err)
+	}
+	baseline, err := normalizeSettingsValue(rawBaseline)
+	if err != nil {
+		return fmt.Errorf("failed to read baseline: %v", err)
+	}
+
+	account, err := s.getCurrentAccount()
+	if err != nil {
+		return err
+	}
+	current, err := accountSettingsMap(account.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to serialize settings: %v", err)
+	}
+
+	keys := make([]string, 0, len(baseline))
+	for key := range baseline {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var differing []string
+	for _, key := range keys {
+		expected := baseline[key]
+		actual, ok := current[key]
+		if !ok && expected != nil {
+			// Omitted fields (e.g. Cloud-only settings left at their default) are zero values
+			actual = reflect.Zero(reflect.TypeOf(expected)).Interface()
+		}
+		if settingsValuesEqual(expected, actual) {
+			continue
+		}
+		differing = append(differing, key)
+		fmt.Printf("  ~ %s: %s -> %s\n", key, formatDiffValue(expected), formatDiffValue(actual))
+	}
+
+	if len(differing) == 0 {
+		fmt.Printf("Account settings match baseline %s (%d fields checked)\n", baselinePath, len(keys))
+		return nil
+	}
+
+	fmt.Println()
+	return fmt.Errorf("%d account setting(s) differ from baseline %s", len(differing), baselinePath)
+}
+
+// settingsValuesEqual compares normalized values, treating nil and empty lists as equal
+func settingsValuesEqual(a, b interface{}) bool {
+	if isEmptySettingsValue(a) && isEmptySettingsValue(b) {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// isEmptySettingsValue reports whether a value is nil or an empty list
+func isEmptySettingsValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	list, ok := v.([]interface{})
+	return ok && len(list) == 0
+}
@@ -4,6 +4,7 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -26,13 +28,17 @@ func (s *Service) HandleAccountsCommand(args []string) error {
 	// Query flags
 	listFlag := accountCmd.Bool("list", false, "List all accounts")
 	inspectFlag := accountCmd.String("inspect", "", "Inspect an account by its ID")
+	exportSettingsFlag := accountCmd.String("export-settings", "", "Save current account settings to a YAML/JSON baseline file")
+	settingsDiffFlag := accountCmd.String("settings-diff", "", "Compare current account settings against a baseline file")
 
 	// Modification flags
 	updateFlag := accountCmd.String("update", "", "Update an account by its ID (use with update flags)")
 	deleteFlag := accountCmd.String("delete", "", "Delete an account by its ID")
+	enableTrafficLoggingFlag := accountCmd.Bool("enable-traffic-logging", false, "Enable traffic event logging for the account (Cloud-only)")
+	disableTrafficLoggingFlag := accountCmd.Bool("disable-traffic-logging", false, "Disable traffic event logging for the account (Cloud-only)")
 
 	// Output flags
-	outputFlag := accountCmd.String("output", "table", "Output format: table or json")
+	outputFlag := accountCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// Update flags (use with --update)
 	peerLoginExpFlag := accountCmd.String("peer-login-expiration", "", "Peer login expiration (e.g., 24h, 7d)")
@@ -68,6 +74,21 @@ func (s *Service) HandleAccountsCommand(args []string) error {
 		return s.inspectAccount(*inspectFlag, *outputFlag)
 	}
 
+	if *exportSettingsFlag != "" {
+		return s.exportAccountSettings(*exportSettingsFlag)
+	}
+
+	if *settingsDiffFlag != "" {
+		return s.diffAccountSettings(*settingsDiffFlag)
+	}
+
+	if *enableTrafficLoggingFlag && *disableTrafficLoggingFlag {
+		return fmt.Errorf("--enable-traffic-logging and --disable-traffic-logging cannot be used together")
+	}
+	if *enableTrafficLoggingFlag || *disableTrafficLoggingFlag {
+		return s.setTrafficLogging(*enableTrafficLoggingFlag)
+	}
+
 	if *updateFlag != "" {
 		// Build update request from flags
 		return s.updateAccountFromFlags(*updateFlag,
@@ -96,16 +117,10 @@ func (s *Service) HandleAccountsCommand(args []string) error {
 
 // listAccounts lists all accounts (returns single account)
 func (s *Service) listAccounts(outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/accounts", nil)
+	accounts, err := client.GetList[models.Account](s.Client, "/accounts")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var accounts []models.Account
-	if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
-	}
 
 	if len(accounts) == 0 {
 		fmt.Println("No accounts found")
@@ -114,7 +129,7 @@ func (s *Service) listAccounts(outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(accounts, "", "  ")
+		output, err := helpers.MarshalJSONOutput(accounts)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -124,8 +139,7 @@ func (s *Service) listAccounts(outputFormat string) error {
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ACCOUNT ID\tDOMAIN\tNETWORK RANGE\tPEER LOGIN EXP\tDNS DOMAIN")
-	fmt.Fprintln(w, "----------\t------\t-------------\t--------------\t----------")
+	helpers.TableHeader(w, "ACCOUNT ID", "DOMAIN", "NETWORK RANGE", "PEER LOGIN EXP", "DNS DOMAIN")
 
 	for _, account := range accounts {
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
@@ -168,20 +182,14 @@ func (s *Service) listAccounts(outputFormat string) error {
 
 // inspectAccount shows detailed information about an account
 func (s *Service) inspectAccount(accountID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/accounts/"+accountID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var account models.Account
-	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	if err := s.Client.GetJSON("/accounts/"+accountID, &account); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(account, "", "  ")
+		output, err := helpers.MarshalJSONOutput(account)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -227,15 +235,9 @@ func (s *Service) updateAccountFromFlags(accountID string,
 	groupsPropagation, regularUsersView, peerApproval, trafficLogging string) error {
 
 	// First, fetch the current account state
-	resp, err := s.Client.MakeRequest("GET", "/accounts/"+accountID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var account models.Account
-	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
-		return fmt.Errorf("failed to decode current account: %v", err)
+	if err := s.Client.GetJSON("/accounts/"+accountID, &account); err != nil {
+		return err
 	}
 
 	// Update only the fields that were provided
@@ -323,6 +325,65 @@ func (s *Service) updateAccountFromFlags(accountID string,
 	return nil
 }
 
+// setTrafficLogging implements "account --enable-traffic-logging" and "--disable-traffic-logging".
+// Only the traffic_logging setting of the token's account is changed.
+func (s *Service) setTrafficLogging(enable bool) error {
+	account, err := s.getCurrentAccount()
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %v", err)
+	}
+
+	state := "disabled"
+	if enable {
+		state = "enabled"
+	}
+	if account.Settings.TrafficLogging == enable {
+		fmt.Printf("Traffic logging is already %s for account %s\n", state, account.ID)
+		return nil
+	}
+
+	if enable {
+		fmt.Println("Enabling traffic logging records connection events (source, destination, ports, and")
+		fmt.Println("volume) for every peer. Check your privacy and data retention policies first.")
+		if !helpers.ConfirmAction(fmt.Sprintf("Enable traffic logging for account %s?", account.ID)) {
+			return nil
+		}
+	}
+
+	account.Settings.TrafficLogging = enable
+	updateReq := models.AccountUpdateRequest{
+		Settings:   account.Settings,
+		Onboarding: account.Onboarding,
+	}
+	bodyBytes, err := json.Marshal(updateReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := s.Client.MakeRequest("PUT", "/accounts/"+account.ID, bytes.NewReader(bodyBytes))
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return fmt.Errorf("failed to update traffic logging (it is only available on NetBird Cloud): %v", err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	var updated models.Account
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	if updated.Settings.TrafficLogging != enable {
+		return fmt.Errorf("the server accepted the update but traffic logging is still %t; this account does not support it (Cloud-only)",
+			updated.Settings.TrafficLogging)
+	}
+
+	fmt.Printf("Traffic logging %s for account %s\n", state, account.ID)
+	fmt.Printf("  Traffic Logging: %t\n", updated.Settings.TrafficLogging)
+	return nil
+}
+
 // deleteAccount deletes an account and all its resources
 func (s *Service) deleteAccount(accountID string) error {
 	// Fetch account details first
@@ -0,0 +1,257 @@
+// completion.go - Shell completion scripts generated from a static command/flag registry
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// completionCommand is a top-level command and the flags offered for it
+type completionCommand struct {
+	Name  string
+	Flags []string
+}
+
+// completionGlobalFlags are accepted before any command
+var completionGlobalFlags = []string{
+	"yes", "confirm-phrase", "debug", "timings", "env-file", "template", "fields", "extra-header",
+	"request-id", "max-in-flight", "api-version", "no-headers",
+}
+
+// completionShells lists the shells "completion" can generate scripts for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionRegistry lists each command's primary flags. Keep it in sync with the
+// flag.NewFlagSet definitions in the handlers when adding or renaming flags.
+var completionRegistry = []completionCommand{
+	{"connect", []string{"token", "token-from-stdin", "token-from-file", "management-url", "default-output", "skip-validation", "encrypt"}},
+	{"doctor", []string{"timeout"}},
+	{"peer", []string{
+		"list", "inspect", "with-routing", "show-policies", "remove", "remove-batch", "edit", "add-group", "remove-group",
+		"update", "rename", "new-name", "ssh-enabled", "login-expiration", "inactivity-expiration",
+		"approval-required", "ip", "accessible-peers", "filter-name", "filter-ip", "filter-version", "filter-connected",
+		"last-seen-before", "last-seen-after", "sort",
+		"export-inventory", "group", "group-match", "set-ssh", "peer", "all", "output",
+		"bulk-update-from-csv", "dry-run",
+	}},
+	{"group", []string{
+		"list", "inspect", "filter-name", "show-usage", "unused-only", "empty-only", "create", "peers",
+		"from-peers-filter", "dry-run", "delete", "delete-batch", "delete-unused", "rename",
+		"new-name", "rename-batch", "add-peers", "remove-peers", "verbose", "output",
+	}},
+	{"network", []string{
+		"list", "filter-name", "sort", "inspect", "create", "delete", "rename", "update", "new-name",
+		"description", "list-resources", "inspect-resource", "add-resource", "update-resource",
+		"remove-resource", "move-resource", "from", "to", "dry-run", "list-routers", "list-all-routers", "inspect-router", "add-router",
+		"update-router", "remove-router", "network-id", "resource-id", "router-id", "name",
+		"address", "groups", "peer", "peer-groups", "metric", "masquerade", "no-masquerade",
+		"enabled", "disabled", "output",
+	}},
+	{"policy", []string{
+		"list", "inspect", "matrix", "validate", "include-disabled", "create", "create-from-file",
+		"export", "import", "delete", "enable", "disable", "name", "description", "active", "add-rule", "edit-rule",
+		"remove-rule", "enable-rule", "disable-rule", "toggle-rule", "move-rule", "policy-id",
+		"rule-name", "rule-description", "action", "protocol", "sources", "destinations", "ports",
+		"port-range", "bidirectional", "allow-duplicate-names", "output",
+	}},
+	{"setup-key", []string{
+		"list", "inspect", "filter-name", "filter-type", "valid-only", "create", "type",
+		"expires-in", "expires-at", "auto-groups", "usage-limit", "ephemeral",
+		"allow-extra-dns-labels", "quick", "revoke", "enable", "update-groups", "groups",
+		"delete", "delete-batch", "delete-all", "delete-expired", "dry-run", "concurrency", "output",
+	}},
+	{"user", []string{
+		"list", "inspect", "me", "service-users", "regular-users", "invite", "create", "email", "name",
+		"role", "auto-groups", "service-user", "update", "block", "unblock", "remove",
+		"resend-invite", "output",
+	}},
+	{"token", []string{
+		"list", "inspect", "create", "name", "expires-in", "print-env", "shell", "revoke", "revoke-all-expired", "dry-run",
+		"user-id", "user", "output",
+	}},
+	{"route", []string{
+		"list", "inspect", "filter-network", "filter-peer", "enabled-only", "disabled-only",
+		"resolve-names", "create", "create-batch", "network-id", "description", "peer",
+		"peer-groups", "metric", "masquerade", "no-masquerade", "groups", "enabled", "disabled",
+		"keep-route", "no-keep-route",
+		"update", "delete", "delete-batch", "delete-disabled", "dry-run", "enable", "disable", "output",
+	}},
+	{"dns", []string{
+		"list", "inspect", "filter-name", "primary-only", "enabled-only", "get-settings", "create",
+		"nameservers", "groups", "domains", "description", "search-domains", "primary", "enabled",
+		"disabled", "update", "delete", "enable", "disable", "toggle", "update-settings",
+		"disabled-groups", "output",
+	}},
+	{"posture-check", []string{
+		"list", "inspect", "filter-name", "filter-type", "show-usage", "unused-only", "create",
+		"description", "type", "min-version", "os", "min-os-version", "min-kernel", "locations",
+		"action", "ranges", "linux-path", "mac-path", "windows-path", "clone", "update", "delete",
+		"output",
+	}},
+	{"event", []string{
+		"audit", "traffic", "user-id", "target-id", "activity-code", "activity-codes", "start-date",
+		"end-date", "since", "search", "reporter-id", "protocol", "type", "connection-type",
+		"direction", "page", "page-size", "max-pages", "summary", "group-by", "json-lines", "export", "until", "format", "max-events", "output",
+	}},
+	{"geo", []string{"countries", "cities", "country", "validate", "output"}},
+	{"account", []string{
+		"list", "inspect", "export-settings", "settings-diff", "update", "delete",
+		"peer-login-expiration", "peer-inactivity-expiration", "dns-domain", "network-range",
+		"jwt-groups-enabled", "jwt-groups-claim", "jwt-allow-groups", "groups-propagation-enabled",
+		"regular-users-view-blocked", "peer-approval-enabled", "traffic-logging", "output",
+		"enable-traffic-logging", "disable-traffic-logging",
+	}},
+	{"ingress-port", []string{
+		"list", "inspect", "create", "update", "delete", "peer", "target-port", "protocol",
+		"description", "output",
+	}},
+	{"ingress-peer", []string{
+		"list", "filter-enabled", "inspect", "create", "update", "delete", "name", "location",
+		"enabled", "output",
+	}},
+	{"export", []string{
+		"full", "split", "format", "groups-only", "policies-only", "networks-only", "routes-only",
+		"dns-only", "posture-only", "setup-keys-only", "progress", "gzip",
+	}},
+	{"import", []string{
+		"apply", "on-conflict", "update", "skip-existing", "force", "verbose", "diff", "prune", "progress",
+		"summary-only", "groups-only", "policies-only", "networks-only", "routes-only", "dns-only",
+		"posture-only", "setup-keys-only", "peers-only",
+	}},
+	{"migrate", []string{
+		"source-token", "source-url", "source-extra-header", "dest-token", "dest-url", "dest-extra-header",
+		"peer", "group", "create-groups",
+		"key-expiry", "cleanup", "state-file", "resume", "include-peers", "exclude-peers", "peer-filter", "config", "all", "groups", "policies",
+		"networks", "routes", "dns", "posture-checks", "setup-keys", "on-conflict", "skip-existing", "update",
+		"dry-run", "verbose", "progress", "summary-only", "summary-json",
+	}},
+	{"completion", completionShells},
+	{"help", nil},
+}
+
+// HandleCompletionCommand prints a shell completion script. It needs no config or API access.
+func HandleCompletionCommand(args []string) error {
+	completionCmd := flag.NewFlagSet("completion", flag.ContinueOnError)
+	completionCmd.SetOutput(os.Stderr)
+	completionCmd.Usage = PrintCompletionUsage
+
+	if err := completionCmd.Parse(args[1:]); err != nil {
+		return nil
+	}
+
+	if completionCmd.NArg() != 1 {
+		PrintCompletionUsage()
+		return nil
+	}
+
+	switch shell := completionCmd.Arg(0); shell {
+	case "bash":
+		fmt.Print(bashCompletionScript())
+	case "zsh":
+		fmt.Print(zshCompletionScript())
+	case "fish":
+		fmt.Print(fishCompletionScript())
+	default:
+		return fmt.Errorf("unsupported shell '%s' (supported: %s)", shell, strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// completionCommandNames returns the registry's command names in registry order
+func completionCommandNames() []string {
+	names := make([]string, len(completionRegistry))
+	for i, cmd := range completionRegistry {
+		names[i] = cmd.Name
+	}
+	return names
+}
+
+// prefixFlags turns flag names into "--name" words, sorted for stable output
+func prefixFlags(flags []string) []string {
+	words := make([]string, len(flags))
+	for i, name := range flags {
+		words[i] = "--" + name
+	}
+	sort.Strings(words)
+	return words
+}
+
+// bashCompletionScript renders the bash completion function
+func bashCompletionScript() string {
+	var b strings.Builder
+	b.WriteString("# bash completion for netbird-manage\n")
+	b.WriteString("_netbird_manage() {\n")
+	b.WriteString("    local cur cmd i\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    cmd=\"\"\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        case \"${COMP_WORDS[i]}\" in\n")
+	b.WriteString("            -*) ;;\n")
+	b.WriteString("            *) cmd=\"${COMP_WORDS[i]}\"; break ;;\n")
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n\n")
+	b.WriteString("    case \"$cmd\" in\n")
+	for _, cmd := range completionRegistry {
+		words := prefixFlags(cmd.Flags)
+		if cmd.Name == "completion" {
+			words = cmd.Flags
+		}
+		fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", cmd.Name, strings.Join(words, " "))
+	}
+	fmt.Fprintf(&b, "        \"\") COMPREPLY=($(compgen -W \"%s %s\" -- \"$cur\")) ;;\n",
+		strings.Join(completionCommandNames(), " "), strings.Join(prefixFlags(completionGlobalFlags), " "))
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -F _netbird_manage netbird-manage\n")
+	return b.String()
+}
+
+// zshCompletionScript renders the zsh completion function
+func zshCompletionScript() string {
+	var b strings.Builder
+	b.WriteString("#compdef netbird-manage\n")
+	b.WriteString("# zsh completion for netbird-manage\n")
+	b.WriteString("_netbird_manage() {\n")
+	b.WriteString("    local cmd word\n")
+	b.WriteString("    for word in ${words[2,CURRENT-1]}; do\n")
+	b.WriteString("        if [[ $word != -* ]]; then cmd=$word; break; fi\n")
+	b.WriteString("    done\n\n")
+	b.WriteString("    case $cmd in\n")
+	for _, cmd := range completionRegistry {
+		words := prefixFlags(cmd.Flags)
+		if cmd.Name == "completion" {
+			words = cmd.Flags
+		}
+		fmt.Fprintf(&b, "        %s) compadd -- %s ;;\n", cmd.Name, strings.Join(words, " "))
+	}
+	fmt.Fprintf(&b, "        *) compadd -- %s %s ;;\n",
+		strings.Join(completionCommandNames(), " "), strings.Join(prefixFlags(completionGlobalFlags), " "))
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+	b.WriteString("compdef _netbird_manage netbird-manage\n")
+	return b.String()
+}
+
+// fishCompletionScript renders fish "complete" directives
+func fishCompletionScript() string {
+	var b strings.Builder
+	b.WriteString("# fish completion for netbird-manage\n")
+	b.WriteString("complete -c netbird-manage -f\n")
+	for _, name := range completionGlobalFlags {
+		fmt.Fprintf(&b, "complete -c netbird-manage -n '__fish_use_subcommand' -l %s\n", name)
+	}
+	fmt.Fprintf(&b, "complete -c netbird-manage -n '__fish_use_subcommand' -a '%s'\n", strings.Join(completionCommandNames(), " "))
+	for _, cmd := range completionRegistry {
+		if cmd.Name == "completion" {
+			fmt.Fprintf(&b, "complete -c netbird-manage -n '__fish_seen_subcommand_from completion' -a '%s'\n", strings.Join(cmd.Flags, " "))
+			continue
+		}
+		for _, name := range cmd.Flags {
+			fmt.Fprintf(&b, "complete -c netbird-manage -n '__fish_seen_subcommand_from %s' -l %s\n", cmd.Name, name)
+		}
+	}
+	return b.String()
+}
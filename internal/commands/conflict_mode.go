@@ -0,0 +1,74 @@
+// conflict_mode.go - --on-conflict handling shared by import and migrate
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Conflict modes accepted by --on-conflict
+const (
+	conflictFail   = "fail"   // Report existing resources as conflicts (default)
+	conflictUpdate = "update" // Update existing resources
+	conflictSkip   = "skip"   // Leave existing resources untouched
+	conflictUpsert = "upsert" // Create missing and update existing resources
+)
+
+var validConflictModes = []string{conflictFail, conflictUpdate, conflictSkip, conflictUpsert}
+
+// resolveConflictMode combines --on-conflict with the deprecated --update, --skip-existing,
+// and --force aliases, warning about each alias used. Selecting two different modes is an error.
+func resolveConflictMode(onConflict string, update, skipExisting, force bool) (string, error) {
+	selected := map[string]string{} // mode -> flag that selected it
+
+	if onConflict != "" {
+		mode := strings.ToLower(strings.TrimSpace(onConflict))
+		valid := false
+		for _, m := range validConflictModes {
+			if mode == m {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "", fmt.Errorf("invalid --on-conflict '%s' (valid: %s)", onConflict, strings.Join(validConflictModes, ", "))
+		}
+		selected[mode] = "--on-conflict " + mode
+	}
+
+	for _, alias := range []struct {
+		set  bool
+		flag string
+		mode string
+	}{
+		{update, "--update", conflictUpdate},
+		{skipExisting, "--skip-existing", conflictSkip},
+		{force, "--force", conflictUpsert},
+	} {
+		if !alias.set {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s is deprecated; use --on-conflict %s\n", alias.flag, alias.mode)
+		if _, ok := selected[alias.mode]; !ok {
+			selected[alias.mode] = alias.flag
+		}
+	}
+
+	switch len(selected) {
+	case 0:
+		return conflictFail, nil
+	case 1:
+		for mode := range selected {
+			return mode, nil
+		}
+	}
+
+	flags := make([]string, 0, len(selected))
+	for _, m := range validConflictModes {
+		if flag, ok := selected[m]; ok {
+			flags = append(flags, flag)
+		}
+	}
+	return "", fmt.Errorf("conflicting conflict modes: %s (choose one --on-conflict mode)", strings.Join(flags, ", "))
+}
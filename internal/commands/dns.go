@@ -12,6 +12,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -29,7 +30,7 @@ func (s *Service) HandleDNSCommand(args []string) error {
 	primaryOnlyFlag := dnsCmd.Bool("primary-only", false, "Show only primary groups")
 	enabledOnlyFlag := dnsCmd.Bool("enabled-only", false, "Show only enabled groups")
 	getSettingsFlag := dnsCmd.Bool("get-settings", false, "Get DNS settings for the account")
-	outputFlag := dnsCmd.String("output", "table", "Output format: table or json")
+	outputFlag := dnsCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// Create flags
 	createFlag := dnsCmd.String("create", "", "Create a new DNS nameserver group with the given name")
@@ -51,6 +52,7 @@ func (s *Service) HandleDNSCommand(args []string) error {
 	// Toggle flags
 	enableFlag := dnsCmd.String("enable", "", "Enable a DNS group by ID")
 	disableFlag := dnsCmd.String("disable", "", "Disable a DNS group by ID")
+	toggleFlag := dnsCmd.String("toggle", "", "Flip a DNS group between enabled and disabled by ID")
 
 	// Settings flags
 	updateSettingsFlag := dnsCmd.Bool("update-settings", false, "Update DNS settings")
@@ -104,14 +106,17 @@ func (s *Service) HandleDNSCommand(args []string) error {
 		return s.deleteDNSGroup(*deleteFlag)
 	}
 
-	// Enable DNS group
-	if *enableFlag != "" {
-		return s.toggleDNSGroup(*enableFlag, true)
-	}
-
-	// Disable DNS group
-	if *disableFlag != "" {
-		return s.toggleDNSGroup(*disableFlag, false)
+	// Enable, disable, or flip a DNS group
+	if *enableFlag != "" || *disableFlag != "" || *toggleFlag != "" {
+		enabled, disabled := true, false
+		switch {
+		case *enableFlag != "":
+			return s.setDNSGroupEnabled(*enableFlag, &enabled)
+		case *disableFlag != "":
+			return s.setDNSGroupEnabled(*disableFlag, &disabled)
+		default:
+			return s.setDNSGroupEnabled(*toggleFlag, nil)
+		}
 	}
 
 	// Update DNS group
@@ -154,16 +159,10 @@ type DNSFilters struct {
 
 // listDNSGroups implements the "dns --list" command
 func (s *Service) listDNSGroups(filters *DNSFilters, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/dns/nameservers", nil)
+	groups, err := client.GetList[models.DNSNameserverGroup](s.Client, "/dns/nameservers")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var groups []models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return fmt.Errorf("failed to decode DNS groups response: %v", err)
-	}
 
 	// Apply filters
 	var filtered []models.DNSNameserverGroup
@@ -191,9 +190,22 @@ func (s *Service) listDNSGroups(filters *DNSFilters, outputFormat string) error
 		return nil
 	}
 
+	// Resolve distribution group IDs to names in a single groups lookup
+	groupNames, err := s.getGroupNamesByID()
+	if err != nil {
+		return fmt.Errorf("failed to resolve groups: %v", err)
+	}
+
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filtered, "", "  ")
+		results := make([]dnsGroupListOutput, 0, len(filtered))
+		for _, group := range filtered {
+			results = append(results, dnsGroupListOutput{
+				DNSNameserverGroup: group,
+				GroupsResolved:     resolveGroupRefsFromMap(group.Groups, groupNames),
+			})
+		}
+		output, err := helpers.MarshalJSONOutput(results)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -203,12 +215,21 @@ func (s *Service) listDNSGroups(filters *DNSFilters, outputFormat string) error
 
 	// Print a formatted table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tNAMESERVERS\tGROUPS\tDOMAINS\tPRIMARY\tENABLED")
-	fmt.Fprintln(w, "--\t----\t-----------\t------\t-------\t-------\t-------")
+	helpers.TableHeader(w, "ID", "NAME", "NAMESERVERS", "GROUPS", "DOMAINS", "PRIMARY", "ENABLED")
 
 	for _, group := range filtered {
-		nsCount := fmt.Sprintf("%d servers", len(group.Nameservers))
-		groupCount := fmt.Sprintf("%d groups", len(group.Groups))
+		groupList := "-"
+		if len(group.Groups) > 0 {
+			var names []string
+			for _, ref := range resolveGroupRefsFromMap(group.Groups, groupNames) {
+				if ref.Missing {
+					names = append(names, ref.ID+" (missing)")
+				} else {
+					names = append(names, ref.Name)
+				}
+			}
+			groupList = strings.Join(names, ", ")
+		}
 		domainCount := "-"
 		if len(group.Domains) > 0 {
 			domainCount = fmt.Sprintf("%d domains", len(group.Domains))
@@ -222,8 +243,8 @@ func (s *Service) listDNSGroups(filters *DNSFilters, outputFormat string) error
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n",
 			group.ID,
 			group.Name,
-			nsCount,
-			groupCount,
+			formatNameservers(group.Nameservers),
+			groupList,
 			domainCount,
 			primaryStr,
 			group.Enabled,
@@ -231,26 +252,38 @@ func (s *Service) listDNSGroups(filters *DNSFilters, outputFormat string) error
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d DNS nameserver groups\n", len(filtered))
+	helpers.TableFooter("\nTotal: %d DNS nameserver groups\n", len(filtered))
 	return nil
 }
 
-// inspectDNSGroup implements the "dns --inspect" command
-func (s *Service) inspectDNSGroup(groupID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/dns/nameservers/"+groupID, nil)
-	if err != nil {
-		return err
+// dnsGroupListOutput is the JSON shape of 'dns --list', adding resolved group names
+type dnsGroupListOutput struct {
+	models.DNSNameserverGroup
+	GroupsResolved []models.ResolvedGroupRef `json:"groups_resolved"`
+}
+
+// formatNameservers renders nameservers inline as ip:port:type
+func formatNameservers(nameservers []models.Nameserver) string {
+	if len(nameservers) == 0 {
+		return "-"
 	}
-	defer resp.Body.Close()
+	parts := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		parts = append(parts, fmt.Sprintf("%s:%d:%s", ns.IP, ns.Port, ns.NSType))
+	}
+	return strings.Join(parts, ", ")
+}
 
+// inspectDNSGroup implements the "dns --inspect" command
+func (s *Service) inspectDNSGroup(groupID string, outputFormat string) error {
 	var group models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
-		return fmt.Errorf("failed to decode DNS group response: %v", err)
+	if err := s.Client.GetJSON("/dns/nameservers/"+groupID, &group); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(group, "", "  ")
+		output, err := helpers.MarshalJSONOutput(group)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -286,8 +319,12 @@ func (s *Service) inspectDNSGroup(groupID string, outputFormat string) error {
 	fmt.Println("Target Groups:")
 	fmt.Println("--------------")
 	if len(group.Groups) > 0 {
-		for _, groupID := range group.Groups {
-			fmt.Printf("  - %s\n", groupID)
+		refs, err := s.resolveGroupRefs(group.Groups)
+		if err != nil {
+			return fmt.Errorf("failed to resolve groups: %v", err)
+		}
+		for _, ref := range refs {
+			fmt.Printf("  - %s\n", formatGroupRef(ref))
 		}
 	} else {
 		fmt.Println("  None")
@@ -366,15 +403,9 @@ func (s *Service) createDNSGroup(name, nameservers, groups, domains, description
 // updateDNSGroup implements the "dns --update" command
 func (s *Service) updateDNSGroup(groupID, nameservers, groups, domains, description string, searchDomains, primary, enabled bool) error {
 	// First, get the current group
-	resp, err := s.Client.MakeRequest("GET", "/dns/nameservers/"+groupID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var currentGroup models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&currentGroup); err != nil {
-		return fmt.Errorf("failed to decode current DNS group: %v", err)
+	if err := s.Client.GetJSON("/dns/nameservers/"+groupID, &currentGroup); err != nil {
+		return err
 	}
 
 	// Build update request (update only provided fields)
@@ -412,7 +443,7 @@ func (s *Service) updateDNSGroup(groupID, nameservers, groups, domains, descript
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/dns/nameservers/"+groupID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/dns/nameservers/"+groupID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -463,18 +494,26 @@ func (s *Service) deleteDNSGroup(groupID string) error {
 	return nil
 }
 
-// toggleDNSGroup enables or disables a DNS group
-func (s *Service) toggleDNSGroup(groupID string, enable bool) error {
+// setDNSGroupEnabled enables or disables a DNS group, preserving every other field.
+// A nil enabled flips the group's current state.
+func (s *Service) setDNSGroupEnabled(groupID string, enabled *bool) error {
 	// First, get the current group
-	resp, err := s.Client.MakeRequest("GET", "/dns/nameservers/"+groupID, nil)
-	if err != nil {
+	var group models.DNSNameserverGroup
+	if err := s.Client.GetJSON("/dns/nameservers/"+groupID, &group); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var group models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
-		return fmt.Errorf("failed to decode DNS group: %v", err)
+	enable := !group.Enabled
+	if enabled != nil {
+		enable = *enabled
+	}
+	status := "enabled"
+	if !enable {
+		status = "disabled"
+	}
+	if enable == group.Enabled {
+		fmt.Printf("DNS nameserver group '%s' is already %s\n", group.Name, status)
+		return nil
 	}
 
 	// Update the enabled status
@@ -494,16 +533,12 @@ func (s *Service) toggleDNSGroup(groupID string, enable bool) error {
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/dns/nameservers/"+groupID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/dns/nameservers/"+groupID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	status := "enabled"
-	if !enable {
-		status = "disabled"
-	}
 	fmt.Printf("DNS nameserver group '%s' %s successfully\n", group.Name, status)
 	return nil
 }
@@ -523,7 +558,7 @@ func (s *Service) getDNSSettings(outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(settings, "", "  ")
+		output, err := helpers.MarshalJSONOutput(settings)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -0,0 +1,341 @@
+// doctor.go - Connection health self-test
+package commands
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/config"
+	"netbird-manage/internal/models"
+)
+
+// Doctor check outcomes
+const (
+	doctorPass = "PASS"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+	doctorSkip = "SKIP"
+)
+
+// doctorResult is the outcome of a single diagnostic check
+type doctorResult struct {
+	Name   string
+	Status string
+	Detail string
+	Hint   string // Remediation shown for warnings and failures
+}
+
+// HandleDoctorCommand runs connection diagnostics. It works without a valid config
+// so it can explain why other commands fail.
+func HandleDoctorCommand(args []string, debug bool) error {
+	doctorCmd := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	doctorCmd.SetOutput(os.Stderr)
+	doctorCmd.Usage = PrintDoctorUsage
+	timeout := doctorCmd.Duration("timeout", 10*time.Second, "Timeout for each network check")
+
+	if err := doctorCmd.Parse(args[1:]); err != nil {
+		return nil
+	}
+
+	fmt.Println("Running NetBird connection diagnostics...")
+	fmt.Println()
+
+	var results []doctorResult
+	report := func(result doctorResult) {
+		results = append(results, result)
+		printDoctorResult(result)
+	}
+
+	// 1. Configuration
+	cfg, cfgResult := checkDoctorConfig()
+	report(cfgResult)
+
+	managementURL := config.DefaultCloudURL
+	if cfg != nil {
+		managementURL = cfg.ManagementURL
+	}
+
+	// 2. URL format, then 3. TCP and TLS reachability
+	parsedURL, urlResult := checkDoctorURL(managementURL)
+	report(urlResult)
+
+	reachable := false
+	if parsedURL != nil {
+		var tcpResult doctorResult
+		reachable, tcpResult = checkDoctorTCP(parsedURL, *timeout)
+		report(tcpResult)
+		if reachable {
+			var tlsResult doctorResult
+			reachable, tlsResult = checkDoctorTLS(parsedURL, *timeout)
+			report(tlsResult)
+		}
+	}
+
+	// 4. Token validity, 5. token scope, 6. API version
+	if cfg == nil || !reachable {
+		reason := "no token configured"
+		if cfg != nil {
+			reason = "management URL is not reachable"
+		}
+		for _, name := range []string{"API token", "Token scope", "API version"} {
+			report(doctorResult{Name: name, Status: doctorSkip, Detail: reason})
+		}
+	} else {
+		c := client.New(cfg.Token, cfg.ManagementURL)
+		c.Debug = debug
+		c.HTTPClient.Timeout = *timeout
+
+		tokenResult := checkDoctorToken(c)
+		report(tokenResult)
+		if tokenResult.Status == doctorPass {
+			report(checkDoctorScope(c))
+			report(checkDoctorVersion(c))
+		} else {
+			report(doctorResult{Name: "Token scope", Status: doctorSkip, Detail: "token is not valid"})
+			report(doctorResult{Name: "API version", Status: doctorSkip, Detail: "token is not valid"})
+		}
+	}
+
+	// Summary
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	fmt.Println()
+	fmt.Printf("Summary: %d passed, %d warnings, %d failed, %d skipped\n",
+		counts[doctorPass], counts[doctorWarn], counts[doctorFail], counts[doctorSkip])
+
+	if counts[doctorFail] > 0 {
+		return fmt.Errorf("%d critical check(s) failed", counts[doctorFail])
+	}
+	return nil
+}
+
+// printDoctorResult prints one check as a pass/fail line with an optional hint
+func printDoctorResult(result doctorResult) {
+	fmt.Printf("  [%s] %-20s %s\n", result.Status, result.Name, result.Detail)
+	if result.Hint != "" && (result.Status == doctorFail || result.Status == doctorWarn) {
+		fmt.Printf("         %-20s -> %s\n", "", result.Hint)
+	}
+}
+
+// checkDoctorConfig verifies that a token is configured and reports where it comes from
+func checkDoctorConfig() (*models.Config, doctorResult) {
+	result := doctorResult{Name: "Configuration"}
+
+	source, err := config.TokenSource()
+	if err != nil {
+		result.Status = doctorFail
+		result.Detail = fmt.Sprintf("config file is unreadable (%v)", err)
+		result.Hint = "Re-run 'netbird-manage connect --token <token>' to rewrite the config file"
+		return nil, result
+	}
+
+	cfg, err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrNoToken) {
+		result.Status = doctorFail
+		result.Detail = err.Error()
+		result.Hint = "Set " + config.EnvConfigPassphrase + " to the passphrase used with 'connect --encrypt', or reconnect"
+		return nil, result
+	}
+	if err != nil || source == "" {
+		result.Status = doctorFail
+		result.Detail = "no API token configured"
+		result.Hint = "Run 'netbird-manage connect --token <token>' or set " + config.EnvToken
+		return nil, result
+	}
+
+	result.Status = doctorPass
+	result.Detail = "token from " + source
+	return cfg, result
+}
+
+// checkDoctorURL validates the management URL format
+func checkDoctorURL(managementURL string) (*url.URL, doctorResult) {
+	result := doctorResult{Name: "Management URL"}
+	hint := "Use the full API URL, e.g. " + config.DefaultCloudURL + " or https://netbird.example.com/api"
+
+	parsed, err := url.Parse(managementURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		result.Status = doctorFail
+		result.Detail = fmt.Sprintf("%q is not a valid http(s) URL", managementURL)
+		result.Hint = hint
+		return nil, result
+	}
+
+	result.Status = doctorPass
+	result.Detail = managementURL
+	if !strings.HasSuffix(strings.TrimSuffix(parsed.Path, "/"), "/api") {
+		result.Status = doctorWarn
+		result.Hint = "NetBird management URLs usually end in /api. " + hint
+	}
+	return parsed, result
+}
+
+// checkDoctorTCP verifies the management host accepts TCP connections
+func checkDoctorTCP(parsed *url.URL, timeout time.Duration) (bool, doctorResult) {
+	result := doctorResult{Name: "Network"}
+	address := doctorHostPort(parsed)
+
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		result.Status = doctorFail
+		result.Detail = fmt.Sprintf("cannot connect to %s (%v)", address, err)
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			result.Hint = "The hostname does not resolve; check the URL spelling and your DNS settings"
+		} else {
+			result.Hint = "Check your network connection, firewall, or proxy settings"
+		}
+		return false, result
+	}
+	conn.Close()
+
+	result.Status = doctorPass
+	result.Detail = "TCP connection to " + address + " succeeded"
+	return true, result
+}
+
+// checkDoctorTLS verifies the TLS handshake and certificate for https URLs
+func checkDoctorTLS(parsed *url.URL, timeout time.Duration) (bool, doctorResult) {
+	result := doctorResult{Name: "TLS"}
+
+	if parsed.Scheme != "https" {
+		result.Status = doctorWarn
+		result.Detail = "plain HTTP; the API token is sent unencrypted"
+		result.Hint = "Use an https:// management URL outside of local testing"
+		return true, result
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", doctorHostPort(parsed), &tls.Config{ServerName: parsed.Hostname()})
+	if err != nil {
+		result.Status = doctorFail
+		result.Detail = fmt.Sprintf("TLS handshake failed (%v)", err)
+		result.Hint = "The server certificate is invalid or untrusted; check the certificate or your system CA store"
+		return false, result
+	}
+	defer conn.Close()
+
+	result.Status = doctorPass
+	result.Detail = "certificate valid"
+	if certs := conn.ConnectionState().PeerCertificates; len(certs) > 0 {
+		result.Detail = fmt.Sprintf("certificate valid until %s", certs[0].NotAfter.Format("2006-01-02"))
+	}
+	return true, result
+}
+
+// checkDoctorToken verifies the token by listing peers
+func checkDoctorToken(c *client.Client) doctorResult {
+	result := doctorResult{Name: "API token"}
+
+	resp, err := c.MakeRequest("GET", "/peers", nil)
+	if err == nil {
+		resp.Body.Close()
+		result.Status = doctorPass
+		result.Detail = "token valid (GET /peers returned " + resp.Status + ")"
+		return result
+	}
+
+	result.Status = doctorFail
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		result.Detail = fmt.Sprintf("request failed (%v)", err)
+		result.Hint = "The server did not respond to the API request; re-run with --debug for details"
+		return result
+	}
+
+	switch apiErr.StatusCode {
+	case 401:
+		result.Detail = "token invalid or expired (401 Unauthorized)"
+		result.Hint = "Create a new Personal Access Token in the dashboard and run 'netbird-manage connect --token <token>'"
+	case 403:
+		result.Detail = "token cannot list peers (403 Forbidden)"
+		result.Hint = "The token's user lacks permission; use an admin or network admin token"
+	case 404:
+		result.Detail = "API endpoint not found (404 Not Found)"
+		result.Hint = "The management URL path is wrong; it usually ends in /api"
+	default:
+		result.Detail = apiErr.Error()
+		result.Hint = "Re-run with --debug to see the full response"
+	}
+	return result
+}
+
+// checkDoctorScope reports which common resources the token can read
+func checkDoctorScope(c *client.Client) doctorResult {
+	result := doctorResult{Name: "Token scope"}
+
+	var allowed, denied []string
+	for _, endpoint := range []string{"/users", "/policies", "/groups", "/setup-keys"} {
+		resp, err := c.MakeRequest("GET", endpoint, nil)
+		if err != nil {
+			denied = append(denied, endpoint)
+			continue
+		}
+		resp.Body.Close()
+		allowed = append(allowed, endpoint)
+	}
+
+	if len(denied) > 0 {
+		result.Status = doctorWarn
+		result.Detail = "cannot read " + strings.Join(denied, ", ")
+		result.Hint = "Commands using these resources will fail; use a token from an admin user for full access"
+		return result
+	}
+
+	result.Status = doctorPass
+	result.Detail = "can read " + strings.Join(allowed, ", ")
+	return result
+}
+
+// checkDoctorVersion reports the management server version when the API exposes it
+func checkDoctorVersion(c *client.Client) doctorResult {
+	result := doctorResult{Name: "API version"}
+
+	resp, err := c.MakeRequest("GET", "/instance/version", nil)
+	if err != nil {
+		result.Status = doctorSkip
+		result.Detail = "not exposed by this server"
+		return result
+	}
+	defer resp.Body.Close()
+
+	var version map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		result.Status = doctorSkip
+		result.Detail = "not exposed by this server"
+		return result
+	}
+
+	result.Status = doctorPass
+	result.Detail = "reported by server"
+	if current, ok := version["management_current_version"].(string); ok && current != "" {
+		result.Detail = "management " + current
+		if available, ok := version["management_available_version"].(string); ok && available != "" && available != current {
+			result.Detail += " (" + available + " available)"
+		}
+	}
+	return result
+}
+
+// doctorHostPort returns host:port for a URL, defaulting the port from the scheme
+func doctorHostPort(parsed *url.URL) string {
+	port := parsed.Port()
+	if port == "" {
+		port = "443"
+		if parsed.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(parsed.Hostname(), port)
+}
@@ -0,0 +1,397 @@
+// event_export.go - event --export: audit and traffic events as a portable evidence bundle
+package commands
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// eventExportPageSize is the traffic page size used while exporting
+const eventExportPageSize = 500
+
+// EventExportManifest is written alongside the exported events as manifest.json
+type EventExportManifest struct {
+	CreatedAt string                    `json:"created_at"`
+	Since     string                    `json:"since"`
+	Until     string                    `json:"until"`
+	Format    string                    `json:"format"`
+	MaxEvents int                       `json:"max_events"`
+	Audit     EventExportManifestStream `json:"audit"`
+	Traffic   EventExportManifestStream `json:"traffic"`
+}
+
+// EventExportManifestStream records what was written for one event type
+type EventExportManifestStream struct {
+	File      string `json:"file,omitempty"`
+	Count     int    `json:"count"`
+	Available int    `json:"available"`         // Events the API reported for the window
+	Truncated bool   `json:"truncated"`         // Stopped at --max-events
+	Error     string `json:"error,omitempty"`   // Why the stream is missing or incomplete
+	Skipped   string `json:"skipped,omitempty"` // Why the stream was not exported
+}
+
+// eventExportSink creates the files of an export, either in a directory or a zip archive.
+// Only one file is open at a time, so entries are streamed straight to disk.
+type eventExportSink interface {
+	Create(name string) (io.Writer, error)
+	Close() error
+}
+
+// dirExportSink writes each file into a directory
+type dirExportSink struct {
+	dir     string
+	current *os.File
+}
+
+func (d *dirExportSink) Create(name string) (io.Writer, error) {
+	if err := d.closeCurrent(); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(filepath.Join(d.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", name, err)
+	}
+	d.current = file
+	return file, nil
+}
+
+func (d *dirExportSink) closeCurrent() error {
+	if d.current == nil {
+		return nil
+	}
+	err := d.current.Close()
+	d.current = nil
+	return err
+}
+
+func (d *dirExportSink) Close() error {
+	return d.closeCurrent()
+}
+
+// zipExportSink writes each file as an entry of a zip archive
+type zipExportSink struct {
+	file   *os.File
+	writer *zip.Writer
+}
+
+func (z *zipExportSink) Create(name string) (io.Writer, error) {
+	return z.writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+}
+
+func (z *zipExportSink) Close() error {
+	if err := z.writer.Close(); err != nil {
+		z.file.Close()
+		return err
+	}
+	return z.file.Close()
+}
+
+// newEventExportSink opens a zip archive when target ends in .zip, otherwise a directory
+func newEventExportSink(target string) (eventExportSink, error) {
+	if strings.EqualFold(filepath.Ext(target), ".zip") {
+		file, err := os.Create(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive: %v", err)
+		}
+		return &zipExportSink{file: file, writer: zip.NewWriter(file)}, nil
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %v", err)
+	}
+	return &dirExportSink{dir: target}, nil
+}
+
+// parseEventTime reads an RFC 3339 timestamp, a YYYY-MM-DD date, or a duration ago (e.g. 7d)
+func parseEventTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.UTC(), nil
+	}
+	seconds, err := helpers.ParseDuration(value, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected an RFC 3339 time, YYYY-MM-DD, or a duration such as 7d")
+	}
+	return now.Add(-time.Duration(seconds) * time.Second), nil
+}
+
+// eventRecordWriter streams events of one type as a JSON array or CSV rows
+type eventRecordWriter struct {
+	format  string
+	out     io.Writer
+	csv     *csv.Writer
+	written int
+}
+
+func newEventRecordWriter(out io.Writer, format string, header []string) (*eventRecordWriter, error) {
+	w := &eventRecordWriter{format: format, out: out}
+	if format == "csv" {
+		w.csv = csv.NewWriter(out)
+		if err := w.csv.Write(header); err != nil {
+			return nil, err
+		}
+		return w, nil
+	}
+	_, err := io.WriteString(out, "[")
+	return w, err
+}
+
+// write appends one event; row is only used for CSV
+func (w *eventRecordWriter) write(item interface{}, row []string) error {
+	w.written++
+	if w.format == "csv" {
+		return w.csv.Write(row)
+	}
+	data, err := json.MarshalIndent(item, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+	separator := ",\n  "
+	if w.written == 1 {
+		separator = "\n  "
+	}
+	if _, err := io.WriteString(w.out, separator); err != nil {
+		return err
+	}
+	_, err = w.out.Write(data)
+	return err
+}
+
+func (w *eventRecordWriter) finish() error {
+	if w.format == "csv" {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	closing := "\n]\n"
+	if w.written == 0 {
+		closing = "]\n"
+	}
+	_, err := io.WriteString(w.out, closing)
+	return err
+}
+
+var auditExportHeader = []string{"id", "timestamp", "activity_code", "activity", "initiator_id", "initiator_name", "initiator_email", "target_id"}
+
+var trafficExportHeader = []string{
+	"id", "timestamp", "user_id", "user_email", "reporter_id", "reporter_name", "protocol", "type",
+	"connection_type", "direction", "source_ip", "destination_ip", "bytes_sent", "bytes_received",
+	"packets_sent", "packets_received", "policy_id",
+}
+
+func auditExportRow(e models.AuditEvent) []string {
+	return []string{e.ID, e.Timestamp, e.ActivityCode, e.Activity, e.InitiatorID, e.InitiatorName, e.InitiatorEmail, e.TargetID}
+}
+
+func trafficExportRow(e models.TrafficEvent) []string {
+	return []string{
+		e.ID, e.Timestamp, e.UserID, e.UserEmail, e.ReporterID, e.ReporterName, strconv.Itoa(e.Protocol), e.Type,
+		e.ConnectionType, e.Direction, e.SourceIP, e.DestinationIP,
+		strconv.FormatInt(e.BytesSent, 10), strconv.FormatInt(e.BytesReceived, 10),
+		strconv.FormatInt(e.PacketsSent, 10), strconv.FormatInt(e.PacketsReceived, 10), e.PolicyID,
+	}
+}
+
+// exportEvents implements "event --export <dir-or-zip>"
+func (s *Service) exportEvents(target, since, until, format string, maxEvents int) error {
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid --format '%s' (valid: json, csv)", format)
+	}
+	if maxEvents < 1 {
+		return fmt.Errorf("--max-events must be at least 1")
+	}
+
+	now := time.Now().UTC()
+	start, err := parseEventTime(since, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since value: %v", err)
+	}
+	end := now
+	if until != "" {
+		if end, err = parseEventTime(until, now); err != nil {
+			return fmt.Errorf("invalid --until value: %v", err)
+		}
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("--since (%s) must be before --until (%s)", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	sink, err := newEventExportSink(target)
+	if err != nil {
+		return err
+	}
+
+	manifest := EventExportManifest{
+		CreatedAt: now.Format(time.RFC3339),
+		Since:     start.Format(time.RFC3339),
+		Until:     end.Format(time.RFC3339),
+		Format:    format,
+		MaxEvents: maxEvents,
+	}
+
+	fmt.Printf("Exporting events from %s to %s UTC...\n", start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+
+	manifest.Audit, err = s.exportAuditEvents(sink, start, end, format, maxEvents)
+	if err != nil {
+		sink.Close()
+		return err
+	}
+	fmt.Printf("  Audit events:   %d\n", manifest.Audit.Count)
+
+	manifest.Traffic, err = s.exportTrafficEvents(sink, start, end, format, maxEvents)
+	if err != nil {
+		sink.Close()
+		return err
+	}
+	if manifest.Traffic.Skipped != "" {
+		fmt.Printf("  Traffic events: skipped (%s)\n", manifest.Traffic.Skipped)
+	} else {
+		fmt.Printf("  Traffic events: %d\n", manifest.Traffic.Count)
+	}
+
+	out, err := sink.Create("manifest.json")
+	if err != nil {
+		sink.Close()
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		sink.Close()
+		return fmt.Errorf("failed to marshal manifest: %v", err)
+	}
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		sink.Close()
+		return fmt.Errorf("failed to write manifest: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		return fmt.Errorf("failed to finish export: %v", err)
+	}
+
+	for _, stream := range []struct {
+		name string
+		info EventExportManifestStream
+	}{{"audit", manifest.Audit}, {"traffic", manifest.Traffic}} {
+		if stream.info.Truncated {
+			fmt.Fprintf(os.Stderr, "Warning: %s export stopped at --max-events %d of %d events; narrow the window or raise the cap\n",
+				stream.name, maxEvents, stream.info.Available)
+		}
+	}
+
+	fmt.Printf("Events exported to %s\n", target)
+	return nil
+}
+
+// exportAuditEvents writes audit events for the window. The audit endpoint is not paginated,
+// so the cap bounds the file rather than the fetch.
+func (s *Service) exportAuditEvents(sink eventExportSink, start, end time.Time, format string, maxEvents int) (EventExportManifestStream, error) {
+	info := EventExportManifestStream{File: "audit-events." + format}
+
+	params := url.Values{}
+	params.Add("start_date", start.Format(time.RFC3339))
+	params.Add("end_date", end.Format(time.RFC3339))
+	events, err := client.GetList[models.AuditEvent](s.Client, "/events/audit?"+params.Encode())
+	if err != nil {
+		return info, fmt.Errorf("failed to fetch audit events: %v", err)
+	}
+	info.Available = len(events)
+
+	out, err := sink.Create(info.File)
+	if err != nil {
+		return info, err
+	}
+	writer, err := newEventRecordWriter(out, format, auditExportHeader)
+	if err != nil {
+		return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+	}
+	for _, event := range events {
+		if info.Count >= maxEvents {
+			info.Truncated = true
+			break
+		}
+		if err := writer.write(event, auditExportRow(event)); err != nil {
+			return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+		}
+		info.Count++
+	}
+	if err := writer.finish(); err != nil {
+		return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+	}
+	return info, nil
+}
+
+// exportTrafficEvents streams traffic events page by page so only one page is held in memory.
+// Traffic events are Cloud-only; a forbidden or missing endpoint is recorded as skipped.
+func (s *Service) exportTrafficEvents(sink eventExportSink, start, end time.Time, format string, maxEvents int) (EventExportManifestStream, error) {
+	info := EventExportManifestStream{File: "traffic-events." + format}
+
+	filters := models.TrafficEventFilters{
+		Page:      1,
+		PageSize:  eventExportPageSize,
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   end.Format(time.RFC3339),
+	}
+	response, err := s.fetchTrafficEvents(filters)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && (apiErr.IsNotFound() || apiErr.IsForbidden()) {
+			info.File = ""
+			info.Skipped = fmt.Sprintf("traffic events unavailable: %s", apiErr.Status)
+			return info, nil
+		}
+		return info, fmt.Errorf("failed to fetch traffic events: %v", err)
+	}
+
+	out, err := sink.Create(info.File)
+	if err != nil {
+		return info, err
+	}
+	writer, err := newEventRecordWriter(out, format, trafficExportHeader)
+	if err != nil {
+		return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+	}
+
+	for {
+		info.Available = response.TotalCount
+		for _, event := range response.Data {
+			if info.Count >= maxEvents {
+				info.Truncated = true
+				break
+			}
+			if err := writer.write(event, trafficExportRow(event)); err != nil {
+				return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+			}
+			info.Count++
+		}
+		fetched := (filters.Page-1)*filters.PageSize + len(response.Data)
+		if info.Truncated || len(response.Data) == 0 || fetched >= response.TotalCount {
+			break
+		}
+
+		filters.Page++
+		if response, err = s.fetchTrafficEvents(filters); err != nil {
+			// Keep what was written; the manifest records the failure
+			info.Error = fmt.Sprintf("stopped at page %d: %v", filters.Page, err)
+			fmt.Fprintf(os.Stderr, "Warning: traffic export %s\n", info.Error)
+			break
+		}
+	}
+
+	if err := writer.finish(); err != nil {
+		return info, fmt.Errorf("failed to write %s: %v", info.File, err)
+	}
+	return info, nil
+}
@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
 
@@ -44,8 +48,24 @@ func (s *Service) HandleEventsCommand(args []string) error {
 	pageFlag := eventCmd.Int("page", 1, "Page number")
 	pageSizeFlag := eventCmd.Int("page-size", 100, "Items per page")
 
+	// Traffic summary
+	summaryFlag := eventCmd.Bool("summary", false, "Aggregate traffic events into totals (use with --traffic)")
+	groupByFlag := eventCmd.String("group-by", "peer", "Summary grouping: peer, policy, protocol, direction")
+	maxPagesFlag := eventCmd.Int("max-pages", 50, "Maximum pages to fetch for --summary")
+
+	// Activity code vocabulary
+	activityCodesFlag := eventCmd.Bool("activity-codes", false, "List distinct audit activity codes seen in recent events")
+	sinceFlag := eventCmd.String("since", "30d", "Window start for --activity-codes and --export (e.g. 24h, 7d, 3months)")
+
+	// Evidence export
+	exportFlag := eventCmd.String("export", "", "Export audit and traffic events to a directory or .zip archive")
+	untilFlag := eventCmd.String("until", "", "Window end for --export (RFC 3339, YYYY-MM-DD, or a duration ago; default: now)")
+	formatFlag := eventCmd.String("format", "json", "File format for --export: json or csv")
+	maxEventsFlag := eventCmd.Int("max-events", 100000, "Safety cap on events written per type by --export")
+
 	// Output
-	outputFlag := eventCmd.String("output", "table", "Output format: table or json")
+	outputFlag := eventCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
+	jsonLinesFlag := eventCmd.Bool("json-lines", false, "Print one JSON object per event per line (ndjson; use with --audit or --traffic)")
 
 	// If no flags are provided (just 'netbird-manage event'), show usage
 	if len(args) == 1 {
@@ -58,8 +78,25 @@ func (s *Service) HandleEventsCommand(args []string) error {
 		return nil
 	}
 
+	if *jsonLinesFlag {
+		if *activityCodesFlag || *summaryFlag {
+			return fmt.Errorf("--json-lines only applies to --audit and --traffic event lists")
+		}
+		*outputFlag = outputJSONLines
+	}
+
 	// Handle the flags in priority order
 
+	// Export audit and traffic events as an evidence bundle
+	if *exportFlag != "" {
+		return s.exportEvents(*exportFlag, *sinceFlag, *untilFlag, *formatFlag, *maxEventsFlag)
+	}
+
+	// List the activity codes seen in recent audit events
+	if *activityCodesFlag {
+		return s.listActivityCodes(*sinceFlag, *outputFlag)
+	}
+
 	// List audit events
 	if *auditFlag {
 		filters := models.AuditEventFilters{
@@ -88,6 +125,9 @@ func (s *Service) HandleEventsCommand(args []string) error {
 			StartDate:      *startDateFlag,
 			EndDate:        *endDateFlag,
 		}
+		if *summaryFlag {
+			return s.summarizeTrafficEvents(filters, *groupByFlag, *maxPagesFlag, *outputFlag)
+		}
 		return s.listTrafficEvents(filters, *outputFlag)
 	}
 
@@ -123,20 +163,18 @@ func (s *Service) listAuditEvents(filters models.AuditEventFilters, outputFormat
 		endpoint += "?" + params.Encode()
 	}
 
-	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
+	events, err := client.GetList[models.AuditEvent](s.Client, endpoint)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var events []models.AuditEvent
-	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	if outputFormat == outputJSONLines {
+		return printJSONLines(events)
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(events, "", "  ")
+		output, err := helpers.MarshalJSONOutput(events)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -146,8 +184,7 @@ func (s *Service) listAuditEvents(filters models.AuditEventFilters, outputFormat
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "TIMESTAMP\tACTIVITY\tINITIATOR\tTARGET ID")
-	fmt.Fprintln(w, "---------\t--------\t---------\t---------")
+	helpers.TableHeader(w, "TIMESTAMP", "ACTIVITY", "INITIATOR", "TARGET ID")
 	for _, event := range events {
 		// Format timestamp (remove milliseconds and timezone for readability)
 		timestamp := event.Timestamp
@@ -172,13 +209,104 @@ func (s *Service) listAuditEvents(filters models.AuditEventFilters, outputFormat
 	}
 	w.Flush()
 
-	fmt.Printf("\nTotal events: %d\n", len(events))
+	helpers.TableFooter("\nTotal events: %d\n", len(events))
 
 	return nil
 }
 
-// listTrafficEvents lists network traffic events with pagination and filters
-func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFormat string) error {
+// outputJSONLines is the internal output format selected by --json-lines
+const outputJSONLines = "json-lines"
+
+// printJSONLines writes each item as a compact, self-contained JSON object on its own line,
+// so log shippers can consume events without parsing a surrounding array
+func printJSONLines[T any](items []T) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+	}
+	return nil
+}
+
+// activityCodeCount is one row of 'event --activity-codes'
+type activityCodeCount struct {
+	Code     string `json:"activity_code"`
+	Activity string `json:"activity"`
+	Count    int    `json:"count"`
+}
+
+// listActivityCodes scans audit events from the last window and lists each distinct
+// activity code with its description and how often it occurred
+func (s *Service) listActivityCodes(since, outputFormat string) error {
+	seconds, err := helpers.ParseDuration(since, nil)
+	if err != nil {
+		return fmt.Errorf("invalid --since value: %v", err)
+	}
+	start := time.Now().UTC().Add(-time.Duration(seconds) * time.Second)
+
+	params := url.Values{}
+	params.Add("start_date", start.Format(time.RFC3339))
+	events, err := client.GetList[models.AuditEvent](s.Client, "/events/audit?"+params.Encode())
+	if err != nil {
+		return err
+	}
+
+	byCode := make(map[string]*activityCodeCount)
+	for _, event := range events {
+		code := event.ActivityCode
+		if code == "" {
+			code = "(none)"
+		}
+		entry, ok := byCode[code]
+		if !ok {
+			entry = &activityCodeCount{Code: code, Activity: event.Activity}
+			byCode[code] = entry
+		}
+		entry.Count++
+	}
+
+	codes := make([]activityCodeCount, 0, len(byCode))
+	for _, entry := range byCode {
+		codes = append(codes, *entry)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Count != codes[j].Count {
+			return codes[i].Count > codes[j].Count
+		}
+		return codes[i].Code < codes[j].Code
+	})
+
+	// JSON output
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(codes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(codes) == 0 {
+		fmt.Printf("No audit events found since %s\n", start.Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	helpers.TableHeader(w, "ACTIVITY CODE", "ACTIVITY", "COUNT")
+	for _, entry := range codes {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", entry.Code, entry.Activity, entry.Count)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d distinct codes in %d events since %s UTC\n", len(codes), len(events), start.Format("2006-01-02 15:04"))
+	fmt.Println("Filter with: netbird-manage event --audit --activity-code <code>")
+	return nil
+}
+
+// fetchTrafficEvents fetches a single page of network traffic events
+func (s *Service) fetchTrafficEvents(filters models.TrafficEventFilters) (*models.TrafficEventResponse, error) {
 	// Build query parameters
 	params := url.Values{}
 	if filters.Page > 0 {
@@ -222,18 +350,32 @@ func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFo
 
 	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var response models.TrafficEventResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return &response, nil
+}
+
+// listTrafficEvents lists network traffic events with pagination and filters
+func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFormat string) error {
+	response, err := s.fetchTrafficEvents(filters)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == outputJSONLines {
+		return printJSONLines(response.Data)
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(response, "", "  ")
+		output, err := helpers.MarshalJSONOutput(response)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -243,8 +385,7 @@ func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFo
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "TIMESTAMP\tUSER\tREPORTER\tPROTOCOL\tSRC IP\tDST IP\tBYTES OUT\tBYTES IN")
-	fmt.Fprintln(w, "---------\t----\t--------\t--------\t------\t------\t---------\t--------")
+	helpers.TableHeader(w, "TIMESTAMP", "USER", "REPORTER", "PROTOCOL", "SRC IP", "DST IP", "BYTES OUT", "BYTES IN")
 	for _, event := range response.Data {
 		// Format timestamp
 		timestamp := event.Timestamp
@@ -252,15 +393,7 @@ func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFo
 			timestamp = strings.Replace(timestamp[:19], "T", " ", 1)
 		}
 
-		// Format protocol
-		protocol := fmt.Sprintf("%d", event.Protocol)
-		if event.Protocol == 6 {
-			protocol = "TCP"
-		} else if event.Protocol == 17 {
-			protocol = "UDP"
-		} else if event.Protocol == 1 {
-			protocol = "ICMP"
-		}
+		protocol := formatProtocol(event.Protocol)
 
 		// Truncate email for display
 		user := event.UserEmail
@@ -287,7 +420,7 @@ func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFo
 	}
 	w.Flush()
 
-	fmt.Printf("\nPage %d of %d | Total events: %d | Page size: %d\n",
+	helpers.TableFooter("\nPage %d of %d | Total events: %d | Page size: %d\n",
 		response.Page,
 		(response.TotalCount+response.PageSize-1)/response.PageSize,
 		response.TotalCount,
@@ -296,3 +429,156 @@ func (s *Service) listTrafficEvents(filters models.TrafficEventFilters, outputFo
 
 	return nil
 }
+
+// formatProtocol returns a readable name for an IP protocol number
+func formatProtocol(protocol int) string {
+	switch protocol {
+	case 1:
+		return "ICMP"
+	case 6:
+		return "TCP"
+	case 17:
+		return "UDP"
+	}
+	return fmt.Sprintf("%d", protocol)
+}
+
+// trafficSummary holds aggregated totals for one summary group
+type trafficSummary struct {
+	Key             string `json:"key"`
+	Events          int    `json:"events"`
+	BytesSent       int64  `json:"bytes_sent"`
+	BytesReceived   int64  `json:"bytes_received"`
+	PacketsSent     int64  `json:"packets_sent"`
+	PacketsReceived int64  `json:"packets_received"`
+	TotalBytes      int64  `json:"total_bytes"`
+}
+
+// trafficSummaryKey returns the grouping key for an event
+func trafficSummaryKey(event models.TrafficEvent, groupBy string) string {
+	switch groupBy {
+	case "peer":
+		if event.ReporterName != "" {
+			return event.ReporterName
+		}
+		return event.ReporterID
+	case "policy":
+		if event.PolicyID == "" {
+			return "(no policy)"
+		}
+		return event.PolicyID
+	case "protocol":
+		return formatProtocol(event.Protocol)
+	case "direction":
+		if event.Direction == "" {
+			return "(unknown)"
+		}
+		return event.Direction
+	}
+	return ""
+}
+
+// summarizeTrafficEvents fetches all matching traffic events (up to maxPages) and prints totals per group
+func (s *Service) summarizeTrafficEvents(filters models.TrafficEventFilters, groupBy string, maxPages int, outputFormat string) error {
+	switch groupBy {
+	case "peer", "policy", "protocol", "direction":
+	default:
+		return fmt.Errorf("invalid --group-by '%s' (valid: peer, policy, protocol, direction)", groupBy)
+	}
+	if maxPages < 1 {
+		return fmt.Errorf("--max-pages must be at least 1")
+	}
+
+	// Fetch every page for accurate totals, starting from the first
+	var events []models.TrafficEvent
+	totalCount := 0
+	pagesFetched := 0
+	filters.Page = 1
+	for pagesFetched < maxPages {
+		response, err := s.fetchTrafficEvents(filters)
+		if err != nil {
+			return err
+		}
+		pagesFetched++
+		totalCount = response.TotalCount
+		events = append(events, response.Data...)
+
+		if len(response.Data) == 0 || len(events) >= response.TotalCount {
+			break
+		}
+		filters.Page++
+	}
+
+	truncated := len(events) < totalCount
+	if truncated {
+		fmt.Fprintf(os.Stderr, "Warning: stopped after %d page(s); summarizing %d of %d events (raise --max-pages for full totals)\n",
+			pagesFetched, len(events), totalCount)
+	}
+
+	// Aggregate by key
+	groups := make(map[string]*trafficSummary)
+	for _, event := range events {
+		key := trafficSummaryKey(event, groupBy)
+		summary, ok := groups[key]
+		if !ok {
+			summary = &trafficSummary{Key: key}
+			groups[key] = summary
+		}
+		summary.Events++
+		summary.BytesSent += event.BytesSent
+		summary.BytesReceived += event.BytesReceived
+		summary.PacketsSent += event.PacketsSent
+		summary.PacketsReceived += event.PacketsReceived
+		summary.TotalBytes += event.BytesSent + event.BytesReceived
+	}
+
+	summaries := make([]trafficSummary, 0, len(groups))
+	for _, summary := range groups {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].TotalBytes != summaries[j].TotalBytes {
+			return summaries[i].TotalBytes > summaries[j].TotalBytes
+		}
+		return summaries[i].Key < summaries[j].Key
+	})
+
+	// JSON output
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(map[string]interface{}{
+			"group_by":     groupBy,
+			"events":       len(events),
+			"total_events": totalCount,
+			"truncated":    truncated,
+			"groups":       summaries,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No traffic events found")
+		return nil
+	}
+
+	// Table output
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	helpers.TableHeader(w, strings.ToUpper(groupBy), "EVENTS", "BYTES SENT", "BYTES RECEIVED", "PACKETS", "TOTAL BYTES")
+	for _, summary := range summaries {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n",
+			summary.Key,
+			summary.Events,
+			summary.BytesSent,
+			summary.BytesReceived,
+			summary.PacketsSent+summary.PacketsReceived,
+			summary.TotalBytes,
+		)
+	}
+	w.Flush()
+
+	helpers.TableFooter("\nSummarized %d events across %d page(s) into %d group(s)\n", len(events), pagesFetched, len(summaries))
+	return nil
+}
@@ -2,18 +2,86 @@
 package commands
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
 
+// ExportOptions selects which resource types an export gathers and writes
+type ExportOptions struct {
+	GroupsOnly    bool
+	PoliciesOnly  bool
+	NetworksOnly  bool
+	RoutesOnly    bool
+	DNSOnly       bool
+	PostureOnly   bool
+	SetupKeysOnly bool
+
+	// Gzip compresses every written file and adds a .gz extension (--gzip)
+	Gzip bool
+
+	// Progress, if set, advances once per fetched resource type (--progress)
+	Progress *helpers.Progress
+}
+
+// includeResourceType checks if a resource type should be exported.
+// Selective flags compose: any combination of them exports the union.
+func (opts ExportOptions) includeResourceType(resourceType string) bool {
+	// If no selective flags, export all
+	if !opts.GroupsOnly && !opts.PoliciesOnly && !opts.NetworksOnly &&
+		!opts.RoutesOnly && !opts.DNSOnly && !opts.PostureOnly && !opts.SetupKeysOnly {
+		return true
+	}
+
+	switch resourceType {
+	case "groups":
+		return opts.GroupsOnly
+	case "policies":
+		return opts.PoliciesOnly
+	case "networks":
+		return opts.NetworksOnly
+	case "routes":
+		return opts.RoutesOnly
+	case "dns":
+		return opts.DNSOnly
+	case "posture":
+		return opts.PostureOnly
+	case "setup-keys":
+		return opts.SetupKeysOnly
+	default:
+		return false
+	}
+}
+
+// exportResourceFile describes one resource type in an export
+type exportResourceFile struct {
+	resourceType string // Selective flag name (matches import's skipResourceType)
+	baseName     string // File name used in split mode
+	key          string // Top-level key in the exported document
+}
+
+// exportResourceFiles lists exportable resource types in import dependency order
+var exportResourceFiles = []exportResourceFile{
+	{"groups", "groups", "groups"},
+	{"posture", "posture-checks", "posture_checks"},
+	{"policies", "policies", "policies"},
+	{"routes", "routes", "routes"},
+	{"dns", "dns", "dns"},
+	{"networks", "networks", "networks"},
+	{"setup-keys", "setup-keys", "setup_keys"},
+}
+
 // HandleExportCommand handles the export command
 func (s *Service) HandleExportCommand(args []string) error {
 	exportCmd := flag.NewFlagSet("export", flag.ContinueOnError)
@@ -23,6 +91,16 @@ func (s *Service) HandleExportCommand(args []string) error {
 	splitFlag := exportCmd.Bool("split", false, "Export to multiple files in a directory")
 	formatFlag := exportCmd.String("format", "yaml", "Output format: yaml or json")
 
+	groupsOnlyFlag := exportCmd.Bool("groups-only", false, "Export only groups")
+	policiesOnlyFlag := exportCmd.Bool("policies-only", false, "Export only policies")
+	networksOnlyFlag := exportCmd.Bool("networks-only", false, "Export only networks")
+	routesOnlyFlag := exportCmd.Bool("routes-only", false, "Export only routes")
+	dnsOnlyFlag := exportCmd.Bool("dns-only", false, "Export only DNS nameserver groups")
+	postureOnlyFlag := exportCmd.Bool("posture-only", false, "Export only posture checks")
+	setupKeysOnlyFlag := exportCmd.Bool("setup-keys-only", false, "Export only setup keys")
+	progressFlag := exportCmd.Bool("progress", false, "Show a compact progress indicator while fetching (TTY only)")
+	gzipFlag := exportCmd.Bool("gzip", false, "Compress output files with gzip (adds a .gz extension)")
+
 	if err := exportCmd.Parse(args[1:]); err != nil {
 		return err
 	}
@@ -33,6 +111,17 @@ func (s *Service) HandleExportCommand(args []string) error {
 		return fmt.Errorf("invalid format '%s': must be 'yaml' or 'json'", format)
 	}
 
+	opts := ExportOptions{
+		GroupsOnly:    *groupsOnlyFlag,
+		PoliciesOnly:  *policiesOnlyFlag,
+		NetworksOnly:  *networksOnlyFlag,
+		RoutesOnly:    *routesOnlyFlag,
+		DNSOnly:       *dnsOnlyFlag,
+		PostureOnly:   *postureOnlyFlag,
+		SetupKeysOnly: *setupKeysOnlyFlag,
+		Gzip:          *gzipFlag,
+	}
+
 	// Get optional directory argument
 	directory := "."
 	remainingArgs := exportCmd.Args()
@@ -46,30 +135,38 @@ func (s *Service) HandleExportCommand(args []string) error {
 		useSplitMode = false // Default to single file
 	}
 
+	// Count the resource types to fetch for the progress indicator
+	resourceTypes := 0
+	for _, file := range exportResourceFiles {
+		if opts.includeResourceType(file.resourceType) {
+			resourceTypes++
+		}
+	}
+	opts.Progress = helpers.StartProgress(*progressFlag, resourceTypes)
+	defer opts.Progress.Finish()
+
 	// Generate timestamp for filename/directory
 	timestamp := time.Now().Format("060102") // YYMMDD format
 
 	if useSplitMode {
-		return s.exportSplitFiles(directory, timestamp, format)
+		return s.exportSplitFiles(directory, timestamp, format, opts)
 	}
-	return s.exportFullSingleFile(directory, timestamp, format)
+	return s.exportFullSingleFile(directory, timestamp, format, opts)
 }
 
 // exportFullSingleFile exports all resources to a single file (YAML or JSON)
-func (s *Service) exportFullSingleFile(directory, timestamp, format string) error {
+func (s *Service) exportFullSingleFile(directory, timestamp, format string, opts ExportOptions) error {
 	fmt.Printf("Exporting NetBird configuration to single %s file...\n", format)
 
-	// Fetch all resources
-	data, err := s.fetchAllResources()
+	// Fetch selected resources
+	data, err := s.fetchAllResources(opts)
+	opts.Progress.Finish()
 	if err != nil {
 		return fmt.Errorf("failed to fetch resources: %v", err)
 	}
 
 	// Create output filename with appropriate extension
-	ext := "yml"
-	if format == "json" {
-		ext = "json"
-	}
+	ext := exportFileExtension(format, opts.Gzip)
 	filename := fmt.Sprintf("netbird-manage-export-%s.%s", timestamp, ext)
 	outputPath := filepath.Join(directory, filename)
 
@@ -83,7 +180,7 @@ func (s *Service) exportFullSingleFile(directory, timestamp, format string) erro
 }
 
 // exportSplitFiles exports resources to multiple files in a directory (YAML or JSON)
-func (s *Service) exportSplitFiles(directory, timestamp, format string) error {
+func (s *Service) exportSplitFiles(directory, timestamp, format string, opts ExportOptions) error {
 	fmt.Printf("Exporting NetBird configuration to split %s files...\n", format)
 
 	// Create output directory
@@ -94,31 +191,29 @@ func (s *Service) exportSplitFiles(directory, timestamp, format string) error {
 		return fmt.Errorf("failed to create directory: %v", err)
 	}
 
-	// Fetch all resources
-	allData, err := s.fetchAllResources()
+	// Fetch selected resources
+	allData, err := s.fetchAllResources(opts)
+	opts.Progress.Finish()
 	if err != nil {
 		return fmt.Errorf("failed to fetch resources: %v", err)
 	}
 
 	// Determine file extension
-	ext := "yml"
-	if format == "json" {
-		ext = "json"
+	ext := exportFileExtension(format, opts.Gzip)
+
+	// Build import order from the resource types being exported
+	importOrder := make([]string, 0, len(exportResourceFiles))
+	for _, file := range exportResourceFiles {
+		if opts.includeResourceType(file.resourceType) {
+			importOrder = append(importOrder, fmt.Sprintf("%s.%s", file.baseName, ext))
+		}
 	}
 
 	// Extract metadata for config file
 	metadata := allData["metadata"]
 	configData := map[string]interface{}{
-		"metadata": metadata,
-		"import_order": []string{
-			fmt.Sprintf("groups.%s", ext),
-			fmt.Sprintf("posture-checks.%s", ext),
-			fmt.Sprintf("policies.%s", ext),
-			fmt.Sprintf("routes.%s", ext),
-			fmt.Sprintf("dns.%s", ext),
-			fmt.Sprintf("networks.%s", ext),
-			fmt.Sprintf("setup-keys.%s", ext),
-		},
+		"metadata":     metadata,
+		"import_order": importOrder,
 	}
 
 	// Write config file
@@ -129,20 +224,13 @@ func (s *Service) exportSplitFiles(directory, timestamp, format string) error {
 	fmt.Printf("  %s\n", configFilename)
 
 	// Write individual resource files
-	files := map[string]string{
-		"groups":         "groups",
-		"posture-checks": "posture_checks",
-		"policies":       "policies",
-		"routes":         "routes",
-		"dns":            "dns",
-		"networks":       "networks",
-		"setup-keys":     "setup_keys",
-	}
-
-	for baseName, key := range files {
-		filename := fmt.Sprintf("%s.%s", baseName, ext)
+	for _, file := range exportResourceFiles {
+		if !opts.includeResourceType(file.resourceType) {
+			continue
+		}
+		filename := fmt.Sprintf("%s.%s", file.baseName, ext)
 		fileData := map[string]interface{}{
-			key: allData[key],
+			file.key: allData[file.key],
 		}
 		if err := writeDataFile(filepath.Join(dirPath, filename), fileData, format); err != nil {
 			return err
@@ -154,7 +242,20 @@ func (s *Service) exportSplitFiles(directory, timestamp, format string) error {
 	return nil
 }
 
-// writeDataFile writes data to a file in the specified format (yaml or json)
+// exportFileExtension returns the file extension for a format, with .gz appended when compressing
+func exportFileExtension(format string, compress bool) string {
+	ext := "yml"
+	if format == "json" {
+		ext = "json"
+	}
+	if compress {
+		ext += ".gz"
+	}
+	return ext
+}
+
+// writeDataFile writes data to a file in the specified format (yaml or json).
+// Paths ending in .gz are written gzip-compressed.
 func writeDataFile(outputPath string, data interface{}, format string) error {
 	var fileData []byte
 	var err error
@@ -171,6 +272,10 @@ func writeDataFile(outputPath string, data interface{}, format string) error {
 		}
 	}
 
+	if strings.HasSuffix(outputPath, ".gz") {
+		return writeGzipFile(outputPath, fileData)
+	}
+
 	if err := os.WriteFile(outputPath, fileData, 0644); err != nil {
 		return fmt.Errorf("failed to write %s: %v", outputPath, err)
 	}
@@ -178,83 +283,131 @@ func writeDataFile(outputPath string, data interface{}, format string) error {
 	return nil
 }
 
+// writeGzipFile writes data to a gzip-compressed file
+func writeGzipFile(outputPath string, data []byte) error {
+	file, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", outputPath, err)
+	}
+
+	gz := gzip.NewWriter(file)
+	if _, err := gz.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %v", outputPath, err)
+	}
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %v", outputPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", outputPath, err)
+	}
+	return nil
+}
+
 // writeYAMLFile writes data to a YAML file (kept for compatibility)
 func writeYAMLFile(outputPath string, data interface{}) error {
 	return writeDataFile(outputPath, data, "yaml")
 }
 
-// fetchAllResources fetches all resources from the API and converts to YAML-friendly map structure
-func (s *Service) fetchAllResources() (map[string]interface{}, error) {
+// fetchAllResources fetches the selected resources from the API and converts to YAML-friendly map structure
+func (s *Service) fetchAllResources(opts ExportOptions) (map[string]interface{}, error) {
 	// Create metadata with important warnings
 	metadata := map[string]interface{}{
 		"version":        "1.0",
 		"exported_at":    time.Now().Format(time.RFC3339),
 		"management_url": s.Client.ManagementURL,
+		"tool_version":   toolVersion(),
 		"_important_note": "PEERS CANNOT BE IMPORTED - Use 'netbird-manage migrate' to migrate peers between accounts. " +
 			"Groups will be imported WITHOUT their peers. See 'netbird-manage migrate --help' for peer migration.",
 	}
 
-	// Fetch all resource types
-	groups, err := s.fetchGroupsAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch groups: %v", err)
+	s.addAccountMetadata(metadata)
+
+	result := map[string]interface{}{
+		"metadata": metadata,
 	}
 
-	policies, err := s.fetchPoliciesAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch policies: %v", err)
+	// Fetch selected resource types
+	if opts.includeResourceType("groups") {
+		opts.Progress.Stage("groups")
+		groups, err := s.fetchGroupsAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch groups: %v", err)
+		}
+		result["groups"] = groups
+		opts.Progress.Step()
 	}
 
-	networks, err := s.fetchNetworksAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch networks: %v", err)
+	if opts.includeResourceType("policies") {
+		opts.Progress.Stage("policies")
+		policies, err := s.fetchPoliciesAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch policies: %v", err)
+		}
+		result["policies"] = policies
+		opts.Progress.Step()
 	}
 
-	routes, err := s.fetchRoutesAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch routes: %v", err)
+	if opts.includeResourceType("networks") {
+		opts.Progress.Stage("networks")
+		networks, err := s.fetchNetworksAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch networks: %v", err)
+		}
+		result["networks"] = networks
+		opts.Progress.Step()
 	}
 
-	dns, err := s.fetchDNSAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch DNS: %v", err)
+	if opts.includeResourceType("routes") {
+		opts.Progress.Stage("routes")
+		routes, err := s.fetchRoutesAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch routes: %v", err)
+		}
+		result["routes"] = routes
+		opts.Progress.Step()
 	}
 
-	postureChecks, err := s.fetchPostureChecksAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch posture checks: %v", err)
+	if opts.includeResourceType("dns") {
+		opts.Progress.Stage("DNS")
+		dns, err := s.fetchDNSAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch DNS: %v", err)
+		}
+		result["dns"] = dns
+		opts.Progress.Step()
 	}
 
-	setupKeys, err := s.fetchSetupKeysAsMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch setup keys: %v", err)
-	}
-
-	// Combine all resources
-	return map[string]interface{}{
-		"metadata":       metadata,
-		"groups":         groups,
-		"policies":       policies,
-		"networks":       networks,
-		"routes":         routes,
-		"dns":            dns,
-		"posture_checks": postureChecks,
-		"setup_keys":     setupKeys,
-	}, nil
+	if opts.includeResourceType("posture") {
+		opts.Progress.Stage("posture checks")
+		postureChecks, err := s.fetchPostureChecksAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch posture checks: %v", err)
+		}
+		result["posture_checks"] = postureChecks
+		opts.Progress.Step()
+	}
+
+	if opts.includeResourceType("setup-keys") {
+		opts.Progress.Stage("setup keys")
+		setupKeys, err := s.fetchSetupKeysAsMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch setup keys: %v", err)
+		}
+		result["setup_keys"] = setupKeys
+		opts.Progress.Step()
+	}
+
+	return result, nil
 }
 
 // fetchGroupsAsMap fetches groups and converts to map[groupName]groupData
 func (s *Service) fetchGroupsAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/groups", nil)
+	groups, err := client.GetList[models.GroupDetail](s.Client, "/groups")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return nil, fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	result := make(map[string]interface{})
 
@@ -287,96 +440,116 @@ func (s *Service) fetchGroupsAsMap() (map[string]interface{}, error) {
 
 // fetchPoliciesAsMap fetches policies and converts to map[policyName]policyData
 func (s *Service) fetchPoliciesAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/policies", nil)
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var policies []models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
-		return nil, fmt.Errorf("failed to decode policies: %v", err)
+	// Posture checks are referenced by name so the linkage survives imports into other accounts
+	postureCheckNames, err := s.postureCheckNamesByID()
+	if err != nil {
+		return nil, err
 	}
 
 	result := make(map[string]interface{})
 	for _, policy := range policies {
-		// Convert rules array to map[ruleName]ruleData
-		rules := make(map[string]interface{})
-		for _, rule := range policy.Rules {
-			// Convert source/destination PolicyGroups to string names
-			sourceNames := make([]string, len(rule.Sources))
-			for i, src := range rule.Sources {
-				sourceNames[i] = src.Name
-			}
+		result[policy.Name] = policyToMap(policy, postureCheckNames)
+	}
 
-			destNames := make([]string, len(rule.Destinations))
-			for i, dest := range rule.Destinations {
-				destNames[i] = dest.Name
-			}
+	return result, nil
+}
 
-			ruleData := map[string]interface{}{
-				"description":   rule.Description,
-				"enabled":       rule.Enabled,
-				"action":        rule.Action,
-				"bidirectional": rule.Bidirectional,
-				"protocol":      rule.Protocol,
-			}
+// postureCheckNamesByID maps posture check IDs to names
+func (s *Service) postureCheckNamesByID() (map[string]string, error) {
+	checks, err := client.GetList[models.PostureCheck](s.Client, "/posture-checks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch posture checks: %v", err)
+	}
+	names := make(map[string]string, len(checks))
+	for _, check := range checks {
+		names[check.ID] = check.Name
+	}
+	return names, nil
+}
 
-			if len(rule.Ports) > 0 {
-				ruleData["ports"] = rule.Ports
-			}
+// policyToMap converts a policy to its export form: rules keyed by name, groups and
+// posture checks referenced by name
+func policyToMap(policy models.Policy, postureCheckNames map[string]string) map[string]interface{} {
+	// Convert rules array to map[ruleName]ruleData
+	rules := make(map[string]interface{})
+	for _, rule := range policy.Rules {
+		// Convert source/destination PolicyGroups to string names
+		sourceNames := make([]string, len(rule.Sources))
+		for i, src := range rule.Sources {
+			sourceNames[i] = src.Name
+		}
 
-			if len(rule.PortRanges) > 0 {
-				ruleData["port_ranges"] = rule.PortRanges
-			}
+		destNames := make([]string, len(rule.Destinations))
+		for i, dest := range rule.Destinations {
+			destNames[i] = dest.Name
+		}
 
-			if len(sourceNames) > 0 {
-				ruleData["sources"] = sourceNames
-			}
+		ruleData := map[string]interface{}{
+			"description":   rule.Description,
+			"enabled":       rule.Enabled,
+			"action":        rule.Action,
+			"bidirectional": rule.Bidirectional,
+			"protocol":      rule.Protocol,
+		}
 
-			if len(destNames) > 0 {
-				ruleData["destinations"] = destNames
-			}
+		if len(rule.Ports) > 0 {
+			ruleData["ports"] = rule.Ports
+		}
 
-			if rule.SourceResource != nil {
-				ruleData["source_resource"] = rule.SourceResource
-			}
+		if len(rule.PortRanges) > 0 {
+			ruleData["port_ranges"] = rule.PortRanges
+		}
 
-			if rule.DestinationResource != nil {
-				ruleData["destination_resource"] = rule.DestinationResource
-			}
+		if len(sourceNames) > 0 {
+			ruleData["sources"] = sourceNames
+		}
 
-			rules[rule.Name] = ruleData
+		if len(destNames) > 0 {
+			ruleData["destinations"] = destNames
 		}
 
-		policyData := map[string]interface{}{
-			"description": policy.Description,
-			"enabled":     policy.Enabled,
-			"rules":       rules,
+		if rule.SourceResource != nil {
+			ruleData["source_resource"] = rule.SourceResource
 		}
 
-		if len(policy.SourcePostureChecks) > 0 {
-			policyData["source_posture_checks"] = policy.SourcePostureChecks
+		if rule.DestinationResource != nil {
+			ruleData["destination_resource"] = rule.DestinationResource
 		}
 
-		result[policy.Name] = policyData
+		rules[rule.Name] = ruleData
 	}
 
-	return result, nil
+	policyData := map[string]interface{}{
+		"description": policy.Description,
+		"enabled":     policy.Enabled,
+		"rules":       rules,
+	}
+
+	if len(policy.SourcePostureChecks) > 0 {
+		checkNames := make([]string, len(policy.SourcePostureChecks))
+		for i, checkID := range policy.SourcePostureChecks {
+			checkNames[i] = checkID
+			if name, ok := postureCheckNames[checkID]; ok {
+				checkNames[i] = name
+			}
+		}
+		policyData["source_posture_checks"] = checkNames
+	}
+
+	return policyData
 }
 
 // fetchNetworksAsMap fetches networks and converts to map[networkName]networkData
 func (s *Service) fetchNetworksAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/networks", nil)
+	networks, err := client.GetList[models.Network](s.Client, "/networks")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var networks []models.Network
-	if err := json.NewDecoder(resp.Body).Decode(&networks); err != nil {
-		return nil, fmt.Errorf("failed to decode networks: %v", err)
-	}
 
 	result := make(map[string]interface{})
 	for _, network := range networks {
@@ -453,14 +626,8 @@ func (s *Service) fetchNetworksAsMap() (map[string]interface{}, error) {
 
 // fetchNetworkDetail fetches detailed network information
 func (s *Service) fetchNetworkDetail(networkID string) (*models.NetworkDetail, error) {
-	resp, err := s.Client.MakeRequest("GET", "/networks/"+networkID, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var detail models.NetworkDetail
-	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+	if err := s.Client.GetJSON("/networks/"+networkID, &detail); err != nil {
 		return nil, err
 	}
 
@@ -501,16 +668,10 @@ func (s *Service) fetchNetworkRouters(networkID string) ([]models.NetworkRouter,
 
 // fetchRoutesAsMap fetches routes and converts to map[routeKey]routeData
 func (s *Service) fetchRoutesAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/routes", nil)
+	routes, err := client.GetList[models.Route](s.Client, "/routes")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var routes []models.Route
-	if err := json.NewDecoder(resp.Body).Decode(&routes); err != nil {
-		return nil, fmt.Errorf("failed to decode routes: %v", err)
-	}
 
 	result := make(map[string]interface{})
 	for i, route := range routes {
@@ -546,16 +707,10 @@ func (s *Service) fetchRoutesAsMap() (map[string]interface{}, error) {
 
 // fetchDNSAsMap fetches DNS nameserver groups and converts to map[dnsGroupName]dnsData
 func (s *Service) fetchDNSAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/dns/nameservers", nil)
+	dnsGroups, err := client.GetList[models.DNSNameserverGroup](s.Client, "/dns/nameservers")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var dnsGroups []models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&dnsGroups); err != nil {
-		return nil, fmt.Errorf("failed to decode DNS groups: %v", err)
-	}
 
 	result := make(map[string]interface{})
 	for _, dns := range dnsGroups {
@@ -575,16 +730,10 @@ func (s *Service) fetchDNSAsMap() (map[string]interface{}, error) {
 
 // fetchPostureChecksAsMap fetches posture checks and converts to map[checkName]checkData
 func (s *Service) fetchPostureChecksAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/posture-checks", nil)
+	checks, err := client.GetList[models.PostureCheck](s.Client, "/posture-checks")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var checks []models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&checks); err != nil {
-		return nil, fmt.Errorf("failed to decode posture checks: %v", err)
-	}
 
 	result := make(map[string]interface{})
 	for _, check := range checks {
@@ -599,16 +748,10 @@ func (s *Service) fetchPostureChecksAsMap() (map[string]interface{}, error) {
 
 // fetchSetupKeysAsMap fetches setup keys and converts to map[keyName]keyData
 func (s *Service) fetchSetupKeysAsMap() (map[string]interface{}, error) {
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys", nil)
+	keys, err := client.GetList[models.SetupKey](s.Client, "/setup-keys")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var keys []models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
-		return nil, fmt.Errorf("failed to decode setup keys: %v", err)
-	}
 
 	result := make(map[string]interface{})
 	for _, key := range keys {
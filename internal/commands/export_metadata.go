@@ -0,0 +1,69 @@
+// export_metadata.go - Export provenance (source account, time, tool version) and import checks
+package commands
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+// toolVersion reports the module version embedded at build time, or "dev" for local builds
+func toolVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			return v
+		}
+	}
+	return "dev"
+}
+
+// addAccountMetadata records the source account in export metadata. Failing to read the
+// account (e.g. a token without account access) only omits these fields.
+func (s *Service) addAccountMetadata(metadata map[string]interface{}) {
+	account, err := s.getCurrentAccount()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read account for export metadata: %v\n", err)
+		return
+	}
+	metadata["account_id"] = account.ID
+	metadata["account_domain"] = account.Domain
+}
+
+// checkImportMetadata prints where an export came from and warns when it is being imported
+// into a different account than it was exported from. Files without metadata are accepted.
+func (ctx *ImportContext) checkImportMetadata(data map[string]interface{}) {
+	metadata, ok := data["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	sourceDomain, _ := metadata["account_domain"].(string)
+	sourceID, _ := metadata["account_id"].(string)
+
+	fmt.Println("Source:")
+	for _, field := range []struct{ label, key string }{
+		{"Account", "account_domain"},
+		{"Account ID", "account_id"},
+		{"Management URL", "management_url"},
+		{"Exported at", "exported_at"},
+		{"Tool version", "tool_version"},
+	} {
+		if value, ok := metadata[field.key].(string); ok && value != "" {
+			fmt.Printf("  %-15s %s\n", field.label+":", value)
+		}
+	}
+	fmt.Println()
+
+	if sourceDomain == "" && sourceID == "" {
+		return
+	}
+	account, err := ctx.Service.getCurrentAccount()
+	if err != nil {
+		return
+	}
+	if (sourceID != "" && sourceID != account.ID) || (sourceDomain != "" && !strings.EqualFold(sourceDomain, account.Domain)) {
+		fmt.Fprintf(os.Stderr, "Warning: this file was exported from account '%s' (%s) but is being imported into '%s' (%s)\n\n",
+			sourceDomain, sourceID, account.Domain, account.ID)
+	}
+}
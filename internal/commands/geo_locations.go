@@ -6,8 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
 
@@ -21,12 +24,13 @@ func (s *Service) HandleGeoLocationsCommand(args []string) error {
 	// Define the flags for the 'geo' command
 	countriesFlag := geoCmd.Bool("countries", false, "List all country codes")
 	citiesFlag := geoCmd.Bool("cities", false, "List cities in a country")
+	validateFlag := geoCmd.String("validate", "", "Validate posture-check locations (e.g., \"US:NewYork,GB:London\")")
 
 	// Filters
 	countryFlag := geoCmd.String("country", "", "Country code (ISO 3166-1 alpha-2, e.g., DE, US)")
 
 	// Output
-	outputFlag := geoCmd.String("output", "table", "Output format: table or json")
+	outputFlag := geoCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// If no flags are provided (just 'netbird-manage geo'), show usage
 	if len(args) == 1 {
@@ -54,26 +58,142 @@ func (s *Service) HandleGeoLocationsCommand(args []string) error {
 		return s.listCitiesByCountry(*countryFlag, *outputFlag)
 	}
 
+	// Validate locations
+	if *validateFlag != "" {
+		return s.validateLocations(*validateFlag)
+	}
+
 	geoCmd.Usage()
 	return nil
 }
 
-// listCountryCodes lists all country codes
-func (s *Service) listCountryCodes(outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/locations/countries", nil)
+// validateLocations checks posture-check location strings against the API's country and city data.
+// Every entry is reported individually; returns an error if any entry is invalid.
+func (s *Service) validateLocations(locationsStr string) error {
+	countries, err := s.getCountryCodes()
 	if err != nil {
 		return err
 	}
+
+	countryNames := make(map[string]string, len(countries))
+	countryCodes := make([]string, 0, len(countries))
+	for _, country := range countries {
+		countryNames[country.Code] = country.Name
+		countryCodes = append(countryCodes, country.Code)
+	}
+
+	// Cities are fetched once per country
+	citiesByCountry := make(map[string][]string)
+
+	invalid := 0
+	entries := helpers.SplitCommaList(locationsStr)
+	if len(entries) == 0 {
+		return fmt.Errorf("at least one location is required")
+	}
+
+	for _, entry := range entries {
+		// Parse each entry on its own so every syntax error is reported
+		parsed, err := parseLocations(entry)
+		if err != nil {
+			fmt.Printf("  INVALID  %-24s %v\n", entry, err)
+			invalid++
+			continue
+		}
+		loc := parsed[0]
+
+		countryName, ok := countryNames[loc.CountryCode]
+		if !ok {
+			msg := fmt.Sprintf("unknown country code '%s'", loc.CountryCode)
+			if suggestions := helpers.ClosestMatches(loc.CountryCode, countryCodes, 3); len(suggestions) > 0 {
+				msg += fmt.Sprintf(" (did you mean: %s?)", strings.Join(suggestions, ", "))
+			}
+			fmt.Printf("  INVALID  %-24s %s\n", entry, msg)
+			invalid++
+			continue
+		}
+
+		if loc.CityName == "" {
+			fmt.Printf("  OK       %-24s %s\n", entry, countryName)
+			continue
+		}
+
+		cities, fetched := citiesByCountry[loc.CountryCode]
+		if !fetched {
+			cityList, err := s.getCitiesByCountry(loc.CountryCode)
+			if err != nil {
+				return err
+			}
+			for _, city := range cityList {
+				cities = append(cities, city.CityName)
+			}
+			citiesByCountry[loc.CountryCode] = cities
+		}
+
+		found := false
+		for _, city := range cities {
+			if city == loc.CityName {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			fmt.Printf("  OK       %-24s %s, %s\n", entry, loc.CityName, countryName)
+			continue
+		}
+
+		msg := fmt.Sprintf("unknown city '%s' in %s", loc.CityName, countryName)
+		if suggestions := helpers.ClosestMatches(loc.CityName, cities, 3); len(suggestions) > 0 {
+			msg += fmt.Sprintf(" (did you mean: %s?)", strings.Join(suggestions, ", "))
+		}
+		fmt.Printf("  INVALID  %-24s %s\n", entry, msg)
+		invalid++
+	}
+
+	fmt.Println()
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d location(s) are invalid", invalid, len(entries))
+	}
+
+	fmt.Printf("All %d location(s) are valid\n", len(entries))
+	return nil
+}
+
+// getCountryCodes fetches all country codes
+func (s *Service) getCountryCodes() ([]models.CountryCode, error) {
+	countries, err := client.GetList[models.CountryCode](s.Client, "/locations/countries")
+	if err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+// getCitiesByCountry fetches all cities for a country code
+func (s *Service) getCitiesByCountry(countryCode string) ([]models.City, error) {
+	endpoint := fmt.Sprintf("/locations/countries/%s/cities", countryCode)
+	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
-	var countries []models.CountryCode
-	if err := json.NewDecoder(resp.Body).Decode(&countries); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	var cities []models.City
+	if err := json.NewDecoder(resp.Body).Decode(&cities); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return cities, nil
+}
+
+// listCountryCodes lists all country codes
+func (s *Service) listCountryCodes(outputFormat string) error {
+	countries, err := s.getCountryCodes()
+	if err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(countries, "", "  ")
+		output, err := helpers.MarshalJSONOutput(countries)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -83,8 +203,7 @@ func (s *Service) listCountryCodes(outputFormat string) error {
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "CODE\tCOUNTRY NAME")
-	fmt.Fprintln(w, "----\t------------")
+	helpers.TableHeader(w, "CODE", "COUNTRY NAME")
 	for _, country := range countries {
 		fmt.Fprintf(w, "%s\t%s\n", country.Code, country.Name)
 	}
@@ -95,21 +214,14 @@ func (s *Service) listCountryCodes(outputFormat string) error {
 
 // listCitiesByCountry lists cities in a specific country
 func (s *Service) listCitiesByCountry(countryCode string, outputFormat string) error {
-	endpoint := fmt.Sprintf("/locations/countries/%s/cities", countryCode)
-	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
+	cities, err := s.getCitiesByCountry(countryCode)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var cities []models.City
-	if err := json.NewDecoder(resp.Body).Decode(&cities); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
-	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(cities, "", "  ")
+		output, err := helpers.MarshalJSONOutput(cities)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -119,8 +231,7 @@ func (s *Service) listCitiesByCountry(countryCode string, outputFormat string) e
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "GEONAME ID\tCITY NAME")
-	fmt.Fprintln(w, "----------\t---------")
+	helpers.TableHeader(w, "GEONAME ID", "CITY NAME")
 	for _, city := range cities {
 		fmt.Fprintf(w, "%d\t%s\n", city.GeonameID, city.CityName)
 	}
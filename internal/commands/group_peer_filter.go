@@ -0,0 +1,190 @@
+// group_peer_filter.go - group --create --from-peers-filter: build a group from matching peers
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// peerFilterClause is one "<key><op><value>" term of a --from-peers-filter expression
+type peerFilterClause struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// peerFilterOperators are checked longest first so "<=" is not read as "<"
+var peerFilterOperators = []string{"<=", ">=", "!=", "<", ">", "="}
+
+// parsePeerFilter parses comma-separated clauses such as "os=linux,version<0.30.0".
+// All clauses must match for a peer to be selected.
+func parsePeerFilter(expr string) ([]peerFilterClause, error) {
+	var clauses []peerFilterClause
+	for _, term := range helpers.SplitCommaList(expr) {
+		clause, err := parsePeerFilterClause(term)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("empty peer filter; use clauses like os=linux,version<0.30.0,connected=true,name-pattern=prod-*")
+	}
+	return clauses, nil
+}
+
+func parsePeerFilterClause(term string) (peerFilterClause, error) {
+	// The operator is the first operator character in the term
+	opIndex := strings.IndexAny(term, "<>=!")
+	if opIndex <= 0 {
+		return peerFilterClause{}, fmt.Errorf("invalid filter clause '%s': expected <key><operator><value>", term)
+	}
+
+	clause := peerFilterClause{Key: strings.ToLower(strings.TrimSpace(term[:opIndex]))}
+	rest := term[opIndex:]
+	for _, op := range peerFilterOperators {
+		if strings.HasPrefix(rest, op) {
+			clause.Op = op
+			clause.Value = strings.TrimSpace(rest[len(op):])
+			break
+		}
+	}
+	if clause.Op == "" || clause.Value == "" {
+		return peerFilterClause{}, fmt.Errorf("invalid filter clause '%s': expected <key><operator><value>", term)
+	}
+
+	switch clause.Key {
+	case "version":
+		if _, ok := client.ParseVersion(clause.Value); !ok {
+			return peerFilterClause{}, fmt.Errorf("invalid version '%s' in filter clause '%s'", clause.Value, term)
+		}
+	case "os", "name-pattern":
+		if clause.Op != "=" && clause.Op != "!=" {
+			return peerFilterClause{}, fmt.Errorf("filter key '%s' only supports = and != (got '%s')", clause.Key, clause.Op)
+		}
+	case "connected":
+		if clause.Op != "=" && clause.Op != "!=" {
+			return peerFilterClause{}, fmt.Errorf("filter key 'connected' only supports = and != (got '%s')", clause.Op)
+		}
+		if _, err := strconv.ParseBool(clause.Value); err != nil {
+			return peerFilterClause{}, fmt.Errorf("invalid value '%s' for connected: must be true or false", clause.Value)
+		}
+	default:
+		return peerFilterClause{}, fmt.Errorf("unknown filter key '%s': must be os, version, connected, or name-pattern", clause.Key)
+	}
+
+	return clause, nil
+}
+
+// matches reports whether a peer satisfies the clause
+func (c peerFilterClause) matches(peer models.Peer) bool {
+	var equal bool
+	switch c.Key {
+	case "os":
+		// Match the raw OS string or its display name (e.g. "macOS" for Darwin)
+		equal = helpers.MatchesPattern(peer.OS, c.Value) || helpers.MatchesPattern(helpers.FormatOS(peer.OS), c.Value)
+	case "name-pattern":
+		equal = helpers.MatchesPattern(peer.Name, c.Value)
+	case "connected":
+		want, _ := strconv.ParseBool(c.Value)
+		equal = peer.Connected == want
+	case "version":
+		cmp, ok := client.CompareVersions(peer.Version, c.Value)
+		if !ok {
+			// Development or unknown builds never match a version comparison
+			return false
+		}
+		switch c.Op {
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		case "!=":
+			return cmp != 0
+		default:
+			return cmp == 0
+		}
+	}
+
+	if c.Op == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+// parseVersionFilter parses a --filter-version expression such as "<0.28.0" or ">= 0.30".
+// "==" and a bare version both mean equal.
+func parseVersionFilter(input string) (peerFilterClause, error) {
+	expr := strings.TrimSpace(input)
+	if strings.HasPrefix(expr, "==") {
+		expr = "=" + strings.TrimPrefix(expr, "==")
+	}
+	if expr != "" && !strings.ContainsAny(expr[:1], "<>=!") {
+		expr = "=" + expr
+	}
+	clause, err := parsePeerFilterClause("version" + expr)
+	if err != nil {
+		return peerFilterClause{}, fmt.Errorf("invalid --filter-version '%s': expected an operator (<, <=, >, >=, ==, !=) and a version, e.g. '<0.28.0'", input)
+	}
+	return clause, nil
+}
+
+// createGroupFromPeersFilter implements "group --create <name> --from-peers-filter <expr>"
+func (s *Service) createGroupFromPeersFilter(name, expr string, dryRun bool) error {
+	clauses, err := parsePeerFilter(expr)
+	if err != nil {
+		return err
+	}
+
+	peers, err := client.GetList[models.Peer](s.Client, "/peers")
+	if err != nil {
+		return err
+	}
+
+	var matched []models.Peer
+	for _, peer := range peers {
+		selected := true
+		for _, clause := range clauses {
+			if !clause.matches(peer) {
+				selected = false
+				break
+			}
+		}
+		if selected {
+			matched = append(matched, peer)
+		}
+	}
+
+	if len(matched) == 0 {
+		return fmt.Errorf("no peers match filter '%s'; group '%s' was not created", expr, name)
+	}
+
+	fmt.Printf("%d of %d peer(s) match '%s':\n\n", len(matched), len(peers), expr)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	helpers.TableHeader(w, "NAME", "IP", "OS", "VERSION", "CONNECTED")
+	peerIDs := make([]string, len(matched))
+	for i, peer := range matched {
+		peerIDs[i] = peer.ID
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", peer.Name, peer.IP, helpers.FormatOS(peer.OS), peer.Version, peer.Connected)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if dryRun {
+		fmt.Printf("Dry run: group '%s' was not created. Run without --dry-run to create it.\n", name)
+		return nil
+	}
+
+	return s.createGroup(name, peerIDs)
+}
@@ -0,0 +1,239 @@
+// group_rename.go - group --rename-batch: rename many groups from a name-mapping file
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// groupRenamePair is one "old-name: new-name" entry of a --rename-batch file
+type groupRenamePair struct {
+	Old string
+	New string
+}
+
+// groupRename is a validated rename of a resolved group
+type groupRename struct {
+	GroupID string
+	OldName string
+	NewName string
+}
+
+// parseGroupRenameFile reads rename pairs in file order. Files ending in .csv hold
+// "old,new" rows (an optional header row is skipped); anything else is a YAML mapping.
+func parseGroupRenameFile(path string) ([]groupRenamePair, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rename file: %v", err)
+	}
+
+	var pairs []groupRenamePair
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		pairs, err = parseGroupRenameCSV(string(data))
+	} else {
+		pairs, err = parseGroupRenameYAML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("rename file %s contains no renames", path)
+	}
+	return pairs, nil
+}
+
+// parseGroupRenameCSV parses "old,new" rows, skipping a leading "old...,new..." header
+func parseGroupRenameCSV(data string) ([]groupRenamePair, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rename CSV: %v", err)
+	}
+
+	var pairs []groupRenamePair
+	for i, record := range records {
+		if len(record) != 2 {
+			return nil, fmt.Errorf("rename CSV line %d: expected 2 columns (old,new), got %d", i+1, len(record))
+		}
+		oldName, newName := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if i == 0 && strings.HasPrefix(strings.ToLower(oldName), "old") && strings.HasPrefix(strings.ToLower(newName), "new") {
+			continue
+		}
+		pairs = append(pairs, groupRenamePair{Old: oldName, New: newName})
+	}
+	return pairs, nil
+}
+
+// parseGroupRenameYAML parses a top-level mapping, keeping the order of its keys
+func parseGroupRenameYAML(data []byte) ([]groupRenamePair, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rename YAML: %v", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("rename YAML must be a mapping of 'old-name: new-name' pairs")
+	}
+
+	var pairs []groupRenamePair
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if key.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("rename YAML line %d: expected 'old-name: new-name'", key.Line)
+		}
+		pairs = append(pairs, groupRenamePair{Old: strings.TrimSpace(key.Value), New: strings.TrimSpace(value.Value)})
+	}
+	return pairs, nil
+}
+
+// planGroupRenames resolves each old group by ID or name and checks the whole batch for
+// collisions before anything is renamed. Pairs whose name does not change are skipped.
+func planGroupRenames(pairs []groupRenamePair, groups []models.GroupDetail) ([]groupRename, int, error) {
+	byID := make(map[string]models.GroupDetail, len(groups))
+	idsByName := make(map[string][]string, len(groups))
+	for _, group := range groups {
+		byID[group.ID] = group
+		idsByName[group.Name] = append(idsByName[group.Name], group.ID)
+	}
+
+	var problems []string
+	var renames []groupRename
+	unchanged := 0
+	seenGroups := make(map[string]string)
+	seenTargets := make(map[string]string)
+
+	for _, pair := range pairs {
+		if pair.Old == "" || pair.New == "" {
+			problems = append(problems, fmt.Sprintf("'%s' -> '%s': old and new names are required", pair.Old, pair.New))
+			continue
+		}
+
+		group, ok := byID[pair.Old]
+		if !ok {
+			ids := idsByName[pair.Old]
+			switch len(ids) {
+			case 0:
+				problems = append(problems, fmt.Sprintf("'%s': group not found", pair.Old))
+				continue
+			case 1:
+				group = byID[ids[0]]
+			default:
+				problems = append(problems, fmt.Sprintf("'%s': %d groups have this name; use the group ID", pair.Old, len(ids)))
+				continue
+			}
+		}
+
+		if previous, dup := seenGroups[group.ID]; dup {
+			problems = append(problems, fmt.Sprintf("'%s': group is already renamed by '%s'", pair.Old, previous))
+			continue
+		}
+		seenGroups[group.ID] = pair.Old
+
+		if group.Name == pair.New {
+			unchanged++
+			continue
+		}
+
+		if previous, dup := seenTargets[pair.New]; dup {
+			problems = append(problems, fmt.Sprintf("'%s' -> '%s': target name is also used for '%s'", pair.Old, pair.New, previous))
+			continue
+		}
+		seenTargets[pair.New] = pair.Old
+
+		if len(idsByName[pair.New]) > 0 {
+			problems = append(problems, fmt.Sprintf("'%s' -> '%s': a group with this name already exists", pair.Old, pair.New))
+			continue
+		}
+
+		renames = append(renames, groupRename{GroupID: group.ID, OldName: group.Name, NewName: pair.New})
+	}
+
+	if len(problems) > 0 {
+		return nil, 0, fmt.Errorf("rename file has %d problem(s), nothing was renamed:\n  - %s",
+			len(problems), strings.Join(problems, "\n  - "))
+	}
+	return renames, unchanged, nil
+}
+
+// renameGroupsBatch implements "group --rename-batch <file> [--dry-run]"
+func (s *Service) renameGroupsBatch(path string, dryRun bool) error {
+	pairs, err := parseGroupRenameFile(path)
+	if err != nil {
+		return err
+	}
+
+	groups, err := client.GetList[models.GroupDetail](s.Client, "/groups")
+	if err != nil {
+		return fmt.Errorf("failed to fetch groups: %v", err)
+	}
+
+	renames, unchanged, err := planGroupRenames(pairs, groups)
+	if err != nil {
+		return err
+	}
+
+	if len(renames) == 0 {
+		fmt.Printf("All %d group(s) already have their target names; nothing to do\n", unchanged)
+		return nil
+	}
+
+	fmt.Printf("Renames (%d):\n", len(renames))
+	for _, rename := range renames {
+		fmt.Printf("  - %s -> %s (ID: %s)\n", rename.OldName, rename.NewName, rename.GroupID)
+	}
+	if unchanged > 0 {
+		fmt.Printf("Unchanged: %d group(s) already have their target names\n", unchanged)
+	}
+
+	if dryRun {
+		fmt.Println("\nDry run: no groups were renamed")
+		return nil
+	}
+
+	if !helpers.ConfirmAction(fmt.Sprintf("\nRename %d groups?", len(renames))) {
+		return nil
+	}
+
+	var renamed, failed int
+	for i, rename := range renames {
+		fmt.Printf("[%d/%d] Renaming group '%s' to '%s'... ", i+1, len(renames), rename.OldName, rename.NewName)
+
+		// Re-fetch so the PUT carries the group's current peers and resources
+		group, err := s.getGroupByID(rename.GroupID)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		if err := s.updateGroup(group.ID, groupRenameRequest(group, rename.NewName)); err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Println("Done")
+		renamed++
+	}
+
+	fmt.Println()
+	fmt.Printf("Summary: %d renamed, %d unchanged, %d failed\n", renamed, unchanged, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to rename %d group(s)", failed)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -21,19 +22,26 @@ func (s *Service) HandleGroupsCommand(args []string) error {
 	listFlag := groupCmd.Bool("list", false, "List all groups")
 	inspectFlag := groupCmd.String("inspect", "", "Inspect a group by its ID")
 	filterNameFlag := groupCmd.String("filter-name", "", "Filter groups by name pattern (use with --list)")
+	showUsageFlag := groupCmd.Bool("show-usage", false, "Annotate each group with where it is referenced (use with --list)")
+	unusedOnlyFlag := groupCmd.Bool("unused-only", false, "List only groups that are not used anywhere (use with --list)")
+	emptyOnlyFlag := groupCmd.Bool("empty-only", false, "List only groups with no peers and no resources, ignoring references (use with --list)")
 
 	createFlag := groupCmd.String("create", "", "Create a new group")
 	deleteFlag := groupCmd.String("delete", "", "Delete a group by its ID")
 	deleteBatchFlag := groupCmd.String("delete-batch", "", "Delete multiple groups (comma-separated IDs)")
 	renameFlag := groupCmd.String("rename", "", "Rename a group (requires --new-name)")
 	newNameFlag := groupCmd.String("new-name", "", "New name for the group (requires --rename)")
+	renameBatchFlag := groupCmd.String("rename-batch", "", "Rename groups from a file of 'old-name: new-name' pairs (YAML or CSV)")
 
 	addPeersFlag := groupCmd.String("add-peers", "", "Add peers to a group (requires --peers)")
 	removePeersFlag := groupCmd.String("remove-peers", "", "Remove peers from a group (requires --peers)")
 	peersFlag := groupCmd.String("peers", "", "Comma-separated list of peer IDs")
+	verboseFlag := groupCmd.Bool("verbose", false, "List each peer as added or already present (use with --add-peers)")
+	fromPeersFilterFlag := groupCmd.String("from-peers-filter", "", "Populate a new group with peers matching a filter, e.g. os=linux,version<0.30.0 (use with --create)")
+	dryRunFlag := groupCmd.Bool("dry-run", false, "Preview --from-peers-filter or --rename-batch without making changes")
 
 	deleteUnusedFlag := groupCmd.Bool("delete-unused", false, "Delete all unused groups (not referenced anywhere)")
-	outputFlag := groupCmd.String("output", "table", "Output format: table or json")
+	outputFlag := groupCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	if len(args) == 1 {
 		PrintGroupUsage()
@@ -45,13 +53,20 @@ func (s *Service) HandleGroupsCommand(args []string) error {
 	}
 
 	if *listFlag {
-		return s.listGroups(*filterNameFlag, *outputFlag)
+		return s.listGroups(*filterNameFlag, *showUsageFlag, *unusedOnlyFlag, *emptyOnlyFlag, *outputFlag)
 	}
 
 	if *inspectFlag != "" {
 		return s.inspectGroup(*inspectFlag, *outputFlag)
 	}
 
+	if *createFlag != "" && *fromPeersFilterFlag != "" {
+		if *peersFlag != "" {
+			return fmt.Errorf("--from-peers-filter cannot be combined with --peers")
+		}
+		return s.createGroupFromPeersFilter(*createFlag, *fromPeersFilterFlag, *dryRunFlag)
+	}
+
 	if *createFlag != "" {
 		var peerIDs []string
 		if *peersFlag != "" {
@@ -75,12 +90,17 @@ func (s *Service) HandleGroupsCommand(args []string) error {
 		return s.renameGroup(*renameFlag, *newNameFlag)
 	}
 
+	if *renameBatchFlag != "" {
+		return s.renameGroupsBatch(*renameBatchFlag, *dryRunFlag)
+	}
+
 	if *addPeersFlag != "" {
 		if *peersFlag == "" {
 			return fmt.Errorf("--peers is required with --add-peers")
 		}
 		peerIDs := helpers.SplitCommaList(*peersFlag)
-		return s.addPeersToGroup(*addPeersFlag, peerIDs)
+		// --debug implies the per-peer listing
+		return s.addPeersToGroup(*addPeersFlag, peerIDs, *verboseFlag || s.Client.Debug)
 	}
 
 	if *removePeersFlag != "" {
@@ -100,16 +120,105 @@ func (s *Service) HandleGroupsCommand(args []string) error {
 	return nil
 }
 
-func (s *Service) listGroups(filterName, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/groups", nil)
+// groupUsage counts how many resources of each type reference a group
+type groupUsage struct {
+	Policies  int `json:"policies"`
+	Routes    int `json:"routes"`
+	DNS       int `json:"dns"`
+	SetupKeys int `json:"setup_keys"`
+	Users     int `json:"users"`
+}
+
+// total returns the number of references across all resource types
+func (u groupUsage) total() int {
+	return u.Policies + u.Routes + u.DNS + u.SetupKeys + u.Users
+}
+
+// String formats the usage as compact per-category counts
+func (u groupUsage) String() string {
+	return fmt.Sprintf("pol:%d route:%d dns:%d key:%d user:%d", u.Policies, u.Routes, u.DNS, u.SetupKeys, u.Users)
+}
+
+// groupWithUsage is a group annotated with its references for --show-usage output
+type groupWithUsage struct {
+	models.GroupDetail
+	Usage groupUsage `json:"usage"`
+}
+
+// isGroupUnused reports whether a group has no peers, no resources, and no references
+func isGroupUnused(group models.GroupDetail, usage groupUsage) bool {
+	return group.PeersCount == 0 && group.ResourcesCount == 0 && usage.total() == 0
+}
+
+// getGroupUsage fetches all group dependencies once and counts references per group ID.
+// Each referencing resource is counted once, even if it uses the group in several places.
+func (s *Service) getGroupUsage() (map[string]groupUsage, error) {
+	policies, setupKeys, routes, dnsGroups, users, err := s.getAllGroupDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]groupUsage)
+	countOnce := func(groupIDs []string, add func(*groupUsage)) {
+		seen := make(map[string]bool)
+		for _, id := range groupIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			u := usage[id]
+			add(&u)
+			usage[id] = u
+		}
+	}
+
+	for _, policy := range policies {
+		var ids []string
+		for _, rule := range policy.Rules {
+			for _, src := range rule.Sources {
+				ids = append(ids, src.ID)
+			}
+			for _, dest := range rule.Destinations {
+				ids = append(ids, dest.ID)
+			}
+		}
+		countOnce(ids, func(u *groupUsage) { u.Policies++ })
+	}
+
+	for _, route := range routes {
+		ids := append(append([]string{}, route.Groups...), route.PeerGroups...)
+		ids = append(ids, route.AccessControlGroups...)
+		countOnce(ids, func(u *groupUsage) { u.Routes++ })
+	}
+
+	for _, dnsGroup := range dnsGroups {
+		countOnce(dnsGroup.Groups, func(u *groupUsage) { u.DNS++ })
+	}
+
+	for _, key := range setupKeys {
+		countOnce(key.AutoGroups, func(u *groupUsage) { u.SetupKeys++ })
+	}
+
+	for _, user := range users {
+		countOnce(user.AutoGroups, func(u *groupUsage) { u.Users++ })
+	}
+
+	return usage, nil
+}
+
+func (s *Service) listGroups(filterName string, showUsage, unusedOnly, emptyOnly bool, outputFormat string) error {
+	groups, err := client.GetList[models.GroupDetail](s.Client, "/groups")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return fmt.Errorf("failed to decode groups response: %v", err)
+	// Usage annotations need every dependency listing, so fetch them once up front
+	var usage map[string]groupUsage
+	if showUsage || unusedOnly {
+		usage, err = s.getGroupUsage()
+		if err != nil {
+			return fmt.Errorf("failed to get group usage: %v", err)
+		}
 	}
 
 	var filteredGroups []models.GroupDetail
@@ -117,11 +226,23 @@ func (s *Service) listGroups(filterName, outputFormat string) error {
 		if filterName != "" && !helpers.MatchesPattern(group.Name, filterName) {
 			continue
 		}
+		if unusedOnly && !isGroupUnused(group, usage[group.ID]) {
+			continue
+		}
+		if emptyOnly && (group.PeersCount > 0 || group.ResourcesCount > 0) {
+			continue
+		}
 		filteredGroups = append(filteredGroups, group)
 	}
 
 	if len(filteredGroups) == 0 {
-		if filterName != "" {
+		if outputFormat == "json" {
+			fmt.Println("[]")
+		} else if unusedOnly {
+			fmt.Println("No unused groups found. All groups are in use.")
+		} else if emptyOnly {
+			fmt.Println("No empty groups found. Every group has peers or resources.")
+		} else if filterName != "" {
 			fmt.Println("No groups found matching the specified filter.")
 		} else {
 			fmt.Println("No groups found.")
@@ -129,9 +250,19 @@ func (s *Service) listGroups(filterName, outputFormat string) error {
 		return nil
 	}
 
+	// With usage, JSON and template output include a "usage" object per group
+	var items interface{} = filteredGroups
+	if usage != nil {
+		annotated := make([]groupWithUsage, len(filteredGroups))
+		for i, g := range filteredGroups {
+			annotated[i] = groupWithUsage{GroupDetail: g, Usage: usage[g.ID]}
+		}
+		items = annotated
+	}
+
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filteredGroups, "", "  ")
+		output, err := helpers.MarshalJSONOutput(items)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -139,12 +270,31 @@ func (s *Service) listGroups(filterName, outputFormat string) error {
 		return nil
 	}
 
+	// Template output
+	if helpers.OutputTemplate != "" {
+		return helpers.RenderTemplate(items)
+	}
+
 	// Table output (default)
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tPEERS\tRESOURCES\tISSUED BY")
-	fmt.Fprintln(w, "--\t----\t-----\t---------\t---------")
+	if usage != nil {
+		helpers.TableHeader(w, "ID", "NAME", "PEERS", "RESOURCES", "ISSUED BY", "USAGE")
+	} else {
+		helpers.TableHeader(w, "ID", "NAME", "PEERS", "RESOURCES", "ISSUED BY")
+	}
 
 	for _, g := range filteredGroups {
+		if usage != nil {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n",
+				g.ID,
+				g.Name,
+				g.PeersCount,
+				g.ResourcesCount,
+				g.Issued,
+				usage[g.ID],
+			)
+			continue
+		}
 		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n",
 			g.ID,
 			g.Name,
@@ -158,16 +308,10 @@ func (s *Service) listGroups(filterName, outputFormat string) error {
 }
 
 func (s *Service) getGroupByName(name string) (*models.GroupDetail, error) {
-	resp, err := s.Client.MakeRequest("GET", "/groups", nil)
+	groups, err := client.GetCachedList[models.GroupDetail](s.Client, "/groups")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return nil, fmt.Errorf("failed to decode groups response: %v", err)
-	}
 
 	for _, group := range groups {
 		if group.Name == name {
@@ -178,6 +322,52 @@ func (s *Service) getGroupByName(name string) (*models.GroupDetail, error) {
 	return nil, fmt.Errorf("no group found with name: %s", name)
 }
 
+// getGroupNamesByID fetches all groups once and returns a map of group ID to name
+func (s *Service) getGroupNamesByID() (map[string]string, error) {
+	groups, err := client.GetCachedList[models.GroupDetail](s.Client, "/groups")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(groups))
+	for _, group := range groups {
+		names[group.ID] = group.Name
+	}
+	return names, nil
+}
+
+// resolveGroupRefs resolves group IDs to names, marking IDs that no longer exist as missing
+func (s *Service) resolveGroupRefs(groupIDs []string) ([]models.ResolvedGroupRef, error) {
+	refs := make([]models.ResolvedGroupRef, 0, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return refs, nil
+	}
+
+	names, err := s.getGroupNamesByID()
+	if err != nil {
+		return nil, err
+	}
+	return resolveGroupRefsFromMap(groupIDs, names), nil
+}
+
+// resolveGroupRefsFromMap resolves group IDs against an already fetched ID-to-name map
+func resolveGroupRefsFromMap(groupIDs []string, names map[string]string) []models.ResolvedGroupRef {
+	refs := make([]models.ResolvedGroupRef, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		name, ok := names[id]
+		refs = append(refs, models.ResolvedGroupRef{ID: id, Name: name, Missing: !ok})
+	}
+	return refs
+}
+
+// formatGroupRef renders a resolved group reference for table output
+func formatGroupRef(ref models.ResolvedGroupRef) string {
+	if ref.Missing {
+		return fmt.Sprintf("%s (missing)", ref.ID)
+	}
+	return fmt.Sprintf("%s (%s)", ref.Name, ref.ID)
+}
+
 func (s *Service) getGroupByID(id string) (*models.GroupDetail, error) {
 	endpoint := "/groups/" + id
 	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
@@ -221,7 +411,7 @@ func (s *Service) inspectGroup(groupIdentifier, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(group, "", "  ")
+		output, err := helpers.MarshalJSONOutput(group)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -436,6 +626,18 @@ func (s *Service) renameGroup(groupIdentifier, newName string) error {
 
 	oldName := group.Name
 
+	fmt.Printf("Renaming group '%s' to '%s'...\n", oldName, newName)
+
+	if err := s.updateGroup(groupID, groupRenameRequest(group, newName)); err != nil {
+		return fmt.Errorf("failed to rename group: %v", err)
+	}
+
+	fmt.Printf("Successfully renamed group from '%s' to '%s'\n", oldName, newName)
+	return nil
+}
+
+// groupRenameRequest builds a PUT body that renames a group while keeping its peers and resources
+func groupRenameRequest(group *models.GroupDetail, newName string) models.GroupPutRequest {
 	var peerIDs []string
 	for _, peer := range group.Peers {
 		peerIDs = append(peerIDs, peer.ID)
@@ -446,23 +648,16 @@ func (s *Service) renameGroup(groupIdentifier, newName string) error {
 		resources = append(resources, models.GroupResourcePutRequest{ID: r.ID, Type: r.Type})
 	}
 
-	reqBody := models.GroupPutRequest{
+	return models.GroupPutRequest{
 		Name:      newName,
 		Peers:     peerIDs,
 		Resources: resources,
 	}
-
-	fmt.Printf("Renaming group '%s' to '%s'...\n", oldName, newName)
-
-	if err := s.updateGroup(groupID, reqBody); err != nil {
-		return fmt.Errorf("failed to rename group: %v", err)
-	}
-
-	fmt.Printf("Successfully renamed group from '%s' to '%s'\n", oldName, newName)
-	return nil
 }
 
-func (s *Service) addPeersToGroup(groupIdentifier string, peerIDs []string) error {
+// addPeersToGroup adds peers that are not yet members; peers already in the group are skipped.
+// With verbose set, each requested peer is listed as added or already present.
+func (s *Service) addPeersToGroup(groupIdentifier string, peerIDs []string, verbose bool) error {
 	groupID, err := s.resolveGroupIdentifier(groupIdentifier)
 	if err != nil {
 		return err
@@ -481,16 +676,40 @@ func (s *Service) addPeersToGroup(groupIdentifier string, peerIDs []string) erro
 		existingPeerMap[peer.ID] = true
 	}
 
-	addedCount := 0
-	for _, peerID := range peerIDs {
-		if !existingPeerMap[peerID] {
-			newPeerIDs = append(newPeerIDs, peerID)
-			addedCount++
+	// outcomes records, in --peers order, whether each peer was added or already a member
+	type peerOutcome struct {
+		peerID         string
+		alreadyPresent bool
+	}
+	var outcomes []peerOutcome
+	addedCount, presentCount := 0, 0
+	for _, peerID := range uniqueStrings(peerIDs) {
+		if existingPeerMap[peerID] {
+			outcomes = append(outcomes, peerOutcome{peerID: peerID, alreadyPresent: true})
+			presentCount++
+			continue
+		}
+		newPeerIDs = append(newPeerIDs, peerID)
+		outcomes = append(outcomes, peerOutcome{peerID: peerID})
+		addedCount++
+	}
+
+	printOutcomes := func() {
+		if !verbose {
+			return
+		}
+		for _, outcome := range outcomes {
+			status := "added"
+			if outcome.alreadyPresent {
+				status = "already present"
+			}
+			fmt.Printf("  %-16s %s\n", status, outcome.peerID)
 		}
 	}
 
 	if addedCount == 0 {
-		fmt.Println("All specified peers are already in the group")
+		printOutcomes()
+		fmt.Printf("All %d specified peer(s) are already in group '%s'; nothing to change\n", presentCount, group.Name)
 		return nil
 	}
 
@@ -511,7 +730,12 @@ func (s *Service) addPeersToGroup(groupIdentifier string, peerIDs []string) erro
 		return fmt.Errorf("failed to add peers: %v", err)
 	}
 
-	fmt.Printf("Successfully added %d peer(s) to group '%s'\n", addedCount, group.Name)
+	printOutcomes()
+	if presentCount > 0 {
+		fmt.Printf("Successfully added %d peer(s) to group '%s' (%d already present)\n", addedCount, group.Name, presentCount)
+	} else {
+		fmt.Printf("Successfully added %d peer(s) to group '%s'\n", addedCount, group.Name)
+	}
 	return nil
 }
 
@@ -571,67 +795,24 @@ func (s *Service) removePeersFromGroup(groupIdentifier string, peerIDs []string)
 func (s *Service) deleteUnusedGroups() error {
 	fmt.Println("Scanning for unused groups...")
 
-	resp, err := s.Client.MakeRequest("GET", "/groups", nil)
+	groups, err := client.GetCachedList[models.GroupDetail](s.Client, "/groups")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	if len(groups) == 0 {
 		fmt.Println("No groups found.")
 		return nil
 	}
 
-	policies, setupKeys, routes, dnsGroups, users, err := s.getAllGroupDependencies()
+	usage, err := s.getGroupUsage()
 	if err != nil {
 		return fmt.Errorf("failed to get dependencies: %v", err)
 	}
 
-	referencedGroups := make(map[string]bool)
-
-	for _, policy := range policies {
-		for _, rule := range policy.Rules {
-			for _, src := range rule.Sources {
-				referencedGroups[src.ID] = true
-			}
-			for _, dest := range rule.Destinations {
-				referencedGroups[dest.ID] = true
-			}
-		}
-	}
-
-	for _, key := range setupKeys {
-		for _, groupID := range key.AutoGroups {
-			referencedGroups[groupID] = true
-		}
-	}
-
-	for _, route := range routes {
-		for _, groupID := range route.Groups {
-			referencedGroups[groupID] = true
-		}
-	}
-
-	for _, dnsGroup := range dnsGroups {
-		for _, groupID := range dnsGroup.Groups {
-			referencedGroups[groupID] = true
-		}
-	}
-
-	for _, user := range users {
-		for _, groupID := range user.AutoGroups {
-			referencedGroups[groupID] = true
-		}
-	}
-
 	var unusedGroups []models.GroupDetail
 	for _, group := range groups {
-		if group.PeersCount == 0 && group.ResourcesCount == 0 && !referencedGroups[group.ID] {
+		if isGroupUnused(group, usage[group.ID]) {
 			unusedGroups = append(unusedGroups, group)
 		}
 	}
@@ -678,61 +859,30 @@ func (s *Service) deleteUnusedGroups() error {
 }
 
 func (s *Service) getAllGroupDependencies() ([]models.Policy, []models.SetupKey, []models.Route, []models.DNSNameserverGroup, []models.User, error) {
-	var policies []models.Policy
-	var setupKeys []models.SetupKey
-	var routes []models.Route
-	var dnsGroups []models.DNSNameserverGroup
-	var users []models.User
-
-	resp, err := s.Client.MakeRequest("GET", "/policies", nil)
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("failed to get policies: %v", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
-		resp.Body.Close()
-		return nil, nil, nil, nil, nil, fmt.Errorf("failed to decode policies: %v", err)
-	}
-	resp.Body.Close()
 
-	resp, err = s.Client.MakeRequest("GET", "/setup-keys", nil)
+	setupKeys, err := client.GetList[models.SetupKey](s.Client, "/setup-keys")
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("failed to get setup keys: %v", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&setupKeys); err != nil {
-		resp.Body.Close()
-		return nil, nil, nil, nil, nil, fmt.Errorf("failed to decode setup keys: %v", err)
-	}
-	resp.Body.Close()
 
-	resp, err = s.Client.MakeRequest("GET", "/routes", nil)
+	routes, err := client.GetList[models.Route](s.Client, "/routes")
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("failed to get routes: %v", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&routes); err != nil {
-		resp.Body.Close()
-		return nil, nil, nil, nil, nil, fmt.Errorf("failed to decode routes: %v", err)
-	}
-	resp.Body.Close()
 
-	resp, err = s.Client.MakeRequest("GET", "/dns/nameservers", nil)
+	dnsGroups, err := client.GetList[models.DNSNameserverGroup](s.Client, "/dns/nameservers")
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("failed to get DNS groups: %v", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&dnsGroups); err != nil {
-		resp.Body.Close()
-		return nil, nil, nil, nil, nil, fmt.Errorf("failed to decode DNS groups: %v", err)
-	}
-	resp.Body.Close()
 
-	resp, err = s.Client.MakeRequest("GET", "/users", nil)
+	users, err := client.GetList[models.User](s.Client, "/users")
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("failed to get users: %v", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
-		resp.Body.Close()
-		return nil, nil, nil, nil, nil, fmt.Errorf("failed to decode users: %v", err)
-	}
-	resp.Body.Close()
 
 	return policies, setupKeys, routes, dnsGroups, users, nil
 }
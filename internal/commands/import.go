@@ -3,14 +3,19 @@ package commands
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
-	"gopkg.in/yaml.v3"
-
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -32,6 +37,10 @@ type ImportContext struct {
 	DNSOnly       bool
 	PostureOnly   bool
 	SetupKeysOnly bool
+	PeersOnly     bool
+
+	// Progress indicator (--progress); nil when disabled or stdout isn't a terminal
+	Progress *helpers.Progress
 
 	// Warnings for peers found in config (cannot be imported)
 	PeersFoundInConfig []string
@@ -51,12 +60,20 @@ type ImportContext struct {
 	ExistingDNS       map[string]*models.DNSNameserverGroup
 	ExistingPosture   map[string]*models.PostureCheck
 	ExistingSetupKeys map[string]*models.SetupKey
+	ExistingPeers     map[string][]models.Peer // By name; names aren't unique
 
 	// Import results
 	Created []string
 	Updated []string
 	Skipped []string
+	Pruned  []string
 	Failed  []ImportError
+
+	// Prune deletes existing resources that are absent from the YAML (--prune)
+	Prune bool
+
+	// Source positions of the loaded YAML, used to point errors at the offending line
+	Positions yamlPositions
 }
 
 // ImportError tracks errors during import
@@ -71,10 +88,15 @@ func (s *Service) HandleImportCommand(args []string) error {
 	importCmd.SetOutput(os.Stderr)
 
 	applyFlag := importCmd.Bool("apply", false, "Actually apply changes (default is dry-run)")
-	updateFlag := importCmd.Bool("update", false, "Update existing resources")
-	skipFlag := importCmd.Bool("skip-existing", false, "Skip resources that already exist")
-	forceFlag := importCmd.Bool("force", false, "Create or update all resources (upsert)")
+	onConflictFlag := importCmd.String("on-conflict", "", "How to handle existing resources: fail, update, skip, or upsert (default: fail)")
+	updateFlag := importCmd.Bool("update", false, "Deprecated: use --on-conflict update")
+	skipFlag := importCmd.Bool("skip-existing", false, "Deprecated: use --on-conflict skip")
+	forceFlag := importCmd.Bool("force", false, "Deprecated: use --on-conflict upsert")
 	verboseFlag := importCmd.Bool("verbose", false, "Show detailed output")
+	diffFlag := importCmd.Bool("diff", false, "Show field-level differences against live state (never applies)")
+	pruneFlag := importCmd.Bool("prune", false, "Delete groups, policies, and networks not present in the YAML (requires --apply)")
+	progressFlag := importCmd.Bool("progress", false, "Show a compact progress indicator instead of per-resource lines (TTY only)")
+	summaryOnlyFlag := importCmd.Bool("summary-only", false, "Hide per-resource lines and print only the final summary and errors")
 
 	groupsOnlyFlag := importCmd.Bool("groups-only", false, "Import only groups")
 	policiesOnlyFlag := importCmd.Bool("policies-only", false, "Import only policies")
@@ -83,11 +105,12 @@ func (s *Service) HandleImportCommand(args []string) error {
 	dnsOnlyFlag := importCmd.Bool("dns-only", false, "Import only DNS nameserver groups")
 	postureOnlyFlag := importCmd.Bool("posture-only", false, "Import only posture checks")
 	setupKeysOnlyFlag := importCmd.Bool("setup-keys-only", false, "Import only setup keys")
+	peersOnlyFlag := importCmd.Bool("peers-only", false, "Import only peer settings")
 
 	// Reorder args to put flags before positional arguments
 	// This allows users to write: import config.yml --apply
 	// instead of requiring: import --apply config.yml
-	reorderedArgs := helpers.ReorderArgsForFlags(args[1:])
+	reorderedArgs := helpers.ReorderArgsForFlags(args[1:], "on-conflict")
 
 	if err := importCmd.Parse(reorderedArgs); err != nil {
 		return err
@@ -102,13 +125,18 @@ func (s *Service) HandleImportCommand(args []string) error {
 
 	path := remainingArgs[0]
 
+	conflictMode, err := resolveConflictMode(*onConflictFlag, *updateFlag, *skipFlag, *forceFlag)
+	if err != nil {
+		return err
+	}
+
 	// Create import context
 	ctx := &ImportContext{
 		Service:              s,
 		Apply:                *applyFlag,
-		Update:               *updateFlag,
-		SkipExisting:         *skipFlag,
-		Force:                *forceFlag,
+		Update:               conflictMode == conflictUpdate,
+		SkipExisting:         conflictMode == conflictSkip,
+		Force:                conflictMode == conflictUpsert,
 		Verbose:              *verboseFlag,
 		GroupsOnly:           *groupsOnlyFlag,
 		PoliciesOnly:         *policiesOnlyFlag,
@@ -117,6 +145,8 @@ func (s *Service) HandleImportCommand(args []string) error {
 		DNSOnly:              *dnsOnlyFlag,
 		PostureOnly:          *postureOnlyFlag,
 		SetupKeysOnly:        *setupKeysOnlyFlag,
+		PeersOnly:            *peersOnlyFlag,
+		Prune:                *pruneFlag,
 		GroupNameToID:        make(map[string]string),
 		PeerNameToID:         make(map[string]string),
 		PolicyNameToID:       make(map[string]string),
@@ -128,21 +158,23 @@ func (s *Service) HandleImportCommand(args []string) error {
 		ExistingDNS:          make(map[string]*models.DNSNameserverGroup),
 		ExistingPosture:      make(map[string]*models.PostureCheck),
 		ExistingSetupKeys:    make(map[string]*models.SetupKey),
+		ExistingPeers:        make(map[string][]models.Peer),
 	}
 
-	// Validate conflict resolution flags
-	flagCount := 0
-	if ctx.Update {
-		flagCount++
-	}
-	if ctx.SkipExisting {
-		flagCount++
+	if ctx.Prune && !ctx.Apply {
+		return fmt.Errorf("--prune requires --apply")
 	}
-	if ctx.Force {
-		flagCount++
-	}
-	if flagCount > 1 {
-		return fmt.Errorf("cannot use --update, --skip-existing, and --force together")
+
+	if *diffFlag {
+		if ctx.Apply {
+			return fmt.Errorf("cannot use --diff together with --apply")
+		}
+		yamlData, positions, err := loadYAMLData(path)
+		if err != nil {
+			return fmt.Errorf("failed to load YAML: %v", err)
+		}
+		ctx.Positions = positions
+		return ctx.diffAgainstLiveState(yamlData)
 	}
 
 	// Show mode
@@ -156,10 +188,12 @@ func (s *Service) HandleImportCommand(args []string) error {
 	}
 
 	// Step 1: Parse YAML file(s)
-	yamlData, err := loadYAMLData(path)
+	yamlData, positions, err := loadYAMLData(path)
 	if err != nil {
 		return fmt.Errorf("failed to load YAML: %v", err)
 	}
+	ctx.Positions = positions
+	ctx.checkImportMetadata(yamlData)
 
 	// Step 2: Fetch current state from API
 	if err := ctx.fetchCurrentState(); err != nil {
@@ -170,56 +204,125 @@ func (s *Service) HandleImportCommand(args []string) error {
 	ctx.checkForPeersInConfig(yamlData)
 
 	// Step 3: Import resources in dependency order
-	if err := ctx.importResources(yamlData); err != nil {
-		return err
+	ctx.Progress = helpers.StartProgress(*progressFlag, ctx.countImportItems(yamlData))
+	if ctx.Progress == nil {
+		ctx.Progress = helpers.SuppressOutput(*summaryOnlyFlag)
 	}
+	defer ctx.Progress.Finish()
+	ctx.importResources(yamlData)
+	ctx.Progress.Finish()
 
-	// Step 4: Print summary
+	// Step 4: Delete resources absent from the YAML (--prune)
+	if ctx.Prune {
+		ctx.pruneResources(yamlData)
+	}
+
+	// Step 5: Print summary
 	ctx.printSummary()
 
+	// Exit non-zero when anything failed, after every resource type has been attempted
+	if len(ctx.Failed) > 0 {
+		return fmt.Errorf("%d resource(s) failed to import", len(ctx.Failed))
+	}
 	return nil
 }
 
-// loadYAMLData loads YAML from a file or directory
-func loadYAMLData(path string) (map[string]interface{}, error) {
+// loadYAMLData loads YAML from a file or directory, along with the source position of
+// every key so import errors can name the offending line
+func loadYAMLData(path string) (map[string]interface{}, yamlPositions, error) {
 	info, err := os.Stat(path)
 	if err != nil {
-		return nil, fmt.Errorf("cannot access path: %v", err)
+		return nil, nil, fmt.Errorf("cannot access path: %v", err)
 	}
 
 	if info.IsDir() {
 		return loadYAMLFromDirectory(path)
 	}
-	return loadYAMLFromFile(path)
+	return loadYAMLFileWithPositions(path, "")
 }
 
-// loadYAMLFromFile loads YAML from a single file
+// loadYAMLFromFile loads YAML from a single file, decompressing it if gzipped
 func loadYAMLFromFile(path string) (map[string]interface{}, error) {
+	result, _, err := loadYAMLFileWithPositions(path, "")
+	return result, err
+}
+
+// loadYAMLFileWithPositions loads a single YAML file and its key positions.
+// label names the file in positions; it is empty for single-file imports.
+func loadYAMLFileWithPositions(path, label string) (map[string]interface{}, yamlPositions, error) {
+	data, err := readConfigFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, positions, err := decodeYAMLWithPositions(data, label)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid YAML syntax: %v", err)
+	}
+
+	return result, positions, nil
+}
+
+// gzipMagic is the two-byte header that starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readConfigFile reads a file, transparently decompressing it when it has a .gz
+// extension or starts with the gzip magic bytes
+func readConfigFile(path string) ([]byte, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	if !strings.HasSuffix(path, ".gz") && !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
 
-	var result map[string]interface{}
-	if err := yaml.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("invalid YAML syntax: %v", err)
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %v", path, err)
 	}
+	defer reader.Close()
 
-	return result, nil
+	data, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %v", path, err)
+	}
+	return data, nil
+}
+
+// findSplitFile returns the path of a split export file, preferring the plain name
+// and falling back to its gzipped variant; ok is false when neither exists
+func findSplitFile(dirPath, filename string) (path string, ok bool) {
+	for _, candidate := range []string{filename, filename + ".gz"} {
+		path = filepath.Join(dirPath, candidate)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
 }
 
 // loadYAMLFromDirectory loads YAML from split files in a directory
-func loadYAMLFromDirectory(dirPath string) (map[string]interface{}, error) {
+func loadYAMLFromDirectory(dirPath string) (map[string]interface{}, yamlPositions, error) {
 	result := make(map[string]interface{})
+	positions := make(yamlPositions)
 
-	// Load config.yml to get import order
-	configPath := filepath.Join(dirPath, "config.yml")
+	// Load config.yml (or config.yml.gz) to get import order
+	configPath, ok := findSplitFile(dirPath, "config.yml")
+	if !ok {
+		return loadDefaultDirectoryOrder(dirPath)
+	}
 	configData, err := loadYAMLFromFile(configPath)
 	if err != nil {
 		// If no config.yml, use default order
 		return loadDefaultDirectoryOrder(dirPath)
 	}
 
+	// Keep the export metadata so the source account can be shown and checked
+	if metadata, ok := configData["metadata"]; ok {
+		result["metadata"] = metadata
+	}
+
 	// Get import order from config
 	importOrder, ok := configData["import_order"].([]interface{})
 	if !ok {
@@ -234,7 +337,7 @@ func loadYAMLFromDirectory(dirPath string) (map[string]interface{}, error) {
 		}
 
 		filePath := filepath.Join(dirPath, filename)
-		fileData, err := loadYAMLFromFile(filePath)
+		fileData, filePositions, err := loadYAMLFileWithPositions(filePath, filename)
 		if err != nil {
 			// Skip missing files
 			continue
@@ -244,14 +347,16 @@ func loadYAMLFromDirectory(dirPath string) (map[string]interface{}, error) {
 		for key, value := range fileData {
 			result[key] = value
 		}
+		mergePositions(positions, filePositions)
 	}
 
-	return result, nil
+	return result, positions, nil
 }
 
 // loadDefaultDirectoryOrder loads files in default dependency order
-func loadDefaultDirectoryOrder(dirPath string) (map[string]interface{}, error) {
+func loadDefaultDirectoryOrder(dirPath string) (map[string]interface{}, yamlPositions, error) {
 	result := make(map[string]interface{})
+	positions := make(yamlPositions)
 
 	defaultOrder := []string{
 		"groups.yml",
@@ -261,27 +366,29 @@ func loadDefaultDirectoryOrder(dirPath string) (map[string]interface{}, error) {
 		"dns.yml",
 		"networks.yml",
 		"setup-keys.yml",
+		"peers.yml",
 	}
 
 	for _, filename := range defaultOrder {
-		filePath := filepath.Join(dirPath, filename)
-		if _, err := os.Stat(filePath); err != nil {
+		filePath, ok := findSplitFile(dirPath, filename)
+		if !ok {
 			// Skip missing files
 			continue
 		}
 
-		fileData, err := loadYAMLFromFile(filePath)
+		fileData, filePositions, err := loadYAMLFileWithPositions(filePath, filepath.Base(filePath))
 		if err != nil {
-			return nil, fmt.Errorf("failed to load %s: %v", filename, err)
+			return nil, nil, fmt.Errorf("failed to load %s: %v", filename, err)
 		}
 
 		// Merge file data into result
 		for key, value := range fileData {
 			result[key] = value
 		}
+		mergePositions(positions, filePositions)
 	}
 
-	return result, nil
+	return result, positions, nil
 }
 
 // fetchCurrentState fetches all existing resources from API
@@ -291,16 +398,10 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch groups
-	resp, err := ctx.Service.Client.MakeRequest("GET", "/groups", nil)
+	groups, err := client.GetList[models.GroupDetail](ctx.Service.Client, "/groups")
 	if err != nil {
 		return fmt.Errorf("failed to fetch groups: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	for _, group := range groups {
 		ctx.GroupNameToID[group.Name] = group.ID
@@ -314,16 +415,10 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch policies
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/policies", nil)
+	policies, err := client.GetList[models.Policy](ctx.Service.Client, "/policies")
 	if err != nil {
 		return fmt.Errorf("failed to fetch policies: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var policies []models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
-		return fmt.Errorf("failed to decode policies: %v", err)
-	}
 
 	for _, policy := range policies {
 		ctx.PolicyNameToID[policy.Name] = policy.ID
@@ -332,16 +427,10 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch networks
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/networks", nil)
+	networks, err := client.GetList[models.Network](ctx.Service.Client, "/networks")
 	if err != nil {
 		return fmt.Errorf("failed to fetch networks: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var networks []models.Network
-	if err := json.NewDecoder(resp.Body).Decode(&networks); err != nil {
-		return fmt.Errorf("failed to decode networks: %v", err)
-	}
 
 	for _, network := range networks {
 		ctx.NetworkNameToID[network.Name] = network.ID
@@ -350,27 +439,16 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch routes
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/routes", nil)
+	ctx.ExistingRoutes, err = client.GetList[models.Route](ctx.Service.Client, "/routes")
 	if err != nil {
 		return fmt.Errorf("failed to fetch routes: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if err := json.NewDecoder(resp.Body).Decode(&ctx.ExistingRoutes); err != nil {
-		return fmt.Errorf("failed to decode routes: %v", err)
-	}
 
 	// Fetch DNS
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/dns/nameservers", nil)
+	dnsGroups, err := client.GetList[models.DNSNameserverGroup](ctx.Service.Client, "/dns/nameservers")
 	if err != nil {
 		return fmt.Errorf("failed to fetch DNS: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var dnsGroups []models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&dnsGroups); err != nil {
-		return fmt.Errorf("failed to decode DNS: %v", err)
-	}
 
 	for _, dns := range dnsGroups {
 		dnsCopy := dns
@@ -378,16 +456,10 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch posture checks
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/posture-checks", nil)
+	checks, err := client.GetList[models.PostureCheck](ctx.Service.Client, "/posture-checks")
 	if err != nil {
 		return fmt.Errorf("failed to fetch posture checks: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var checks []models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&checks); err != nil {
-		return fmt.Errorf("failed to decode posture checks: %v", err)
-	}
 
 	for _, check := range checks {
 		ctx.PostureCheckNameToID[check.Name] = check.ID
@@ -396,85 +468,225 @@ func (ctx *ImportContext) fetchCurrentState() error {
 	}
 
 	// Fetch setup keys
-	resp, err = ctx.Service.Client.MakeRequest("GET", "/setup-keys", nil)
+	keys, err := client.GetList[models.SetupKey](ctx.Service.Client, "/setup-keys")
 	if err != nil {
 		return fmt.Errorf("failed to fetch setup keys: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var keys []models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
-		return fmt.Errorf("failed to decode setup keys: %v", err)
-	}
 
 	for _, key := range keys {
 		keyCopy := key
 		ctx.ExistingSetupKeys[key.Name] = &keyCopy
 	}
 
+	// Fetch peers (full objects carry the settings the peers section reconciles)
+	peers, err := ctx.Service.getAllPeers()
+	if err != nil {
+		return fmt.Errorf("failed to fetch peers: %v", err)
+	}
+
+	for _, peer := range peers {
+		ctx.ExistingPeers[peer.Name] = append(ctx.ExistingPeers[peer.Name], peer)
+		ctx.PeerNameToID[peer.Name] = peer.ID
+	}
+
 	if ctx.Verbose {
-		fmt.Printf("  Found: %d groups, %d policies, %d networks, %d routes, %d DNS, %d posture checks, %d setup keys\n",
+		fmt.Printf("  Found: %d groups, %d policies, %d networks, %d routes, %d DNS, %d posture checks, %d setup keys, %d peers\n",
 			len(ctx.ExistingGroups), len(ctx.ExistingPolicies), len(ctx.ExistingNetworks),
-			len(ctx.ExistingRoutes), len(ctx.ExistingDNS), len(ctx.ExistingPosture), len(ctx.ExistingSetupKeys))
+			len(ctx.ExistingRoutes), len(ctx.ExistingDNS), len(ctx.ExistingPosture), len(ctx.ExistingSetupKeys), len(peers))
 		fmt.Println()
 	}
 
 	return nil
 }
 
-// importResources imports all resources in dependency order
-func (ctx *ImportContext) importResources(data map[string]interface{}) error {
-	// Import in dependency order
-	if !ctx.skipResourceType("groups") {
-		if err := ctx.importGroups(data); err != nil {
-			return err
+// countImportItems counts the resources importResources will process, for --progress
+func (ctx *ImportContext) countImportItems(data map[string]interface{}) int {
+	total := 0
+	for _, section := range []struct{ resourceType, key string }{
+		{"groups", "groups"},
+		{"policies", "policies"},
+		{"networks", "networks"},
+		{"peers", "peers"},
+	} {
+		if ctx.skipResourceType(section.resourceType) {
+			continue
+		}
+		if items, ok := data[section.key].(map[string]interface{}); ok {
+			total += len(items)
 		}
 	}
+	return total
+}
 
-	if !ctx.skipResourceType("posture") {
-		if err := ctx.importPostureChecks(data); err != nil {
-			return err
+// importResources imports all resources in dependency order. A failure in one resource
+// type is recorded and the remaining types still run, so a bad policy doesn't stop
+// networks or peers from importing; failures are reported together in the summary.
+func (ctx *ImportContext) importResources(data map[string]interface{}) {
+	stages := []struct {
+		resourceType string
+		label        string
+		run          func(map[string]interface{}) error
+	}{
+		{"groups", "Groups", ctx.importGroups},
+		{"posture", "Posture checks", ctx.importPostureChecks},
+		{"policies", "Policies", ctx.importPolicies},
+		{"routes", "Routes", ctx.importRoutes},
+		{"dns", "DNS", ctx.importDNS},
+		{"networks", "Networks", ctx.importNetworks},
+		{"setup-keys", "Setup keys", ctx.importSetupKeys},
+		{"peers", "Peers", ctx.importPeers},
+	}
+
+	for _, stage := range stages {
+		if ctx.skipResourceType(stage.resourceType) {
+			continue
+		}
+		if err := stage.run(data); err != nil {
+			ctx.addError(stage.label, err)
 		}
 	}
+}
 
-	if !ctx.skipResourceType("policies") {
-		if err := ctx.importPolicies(data); err != nil {
-			return err
+// diffAgainstLiveState prints field-level differences between the YAML data and the
+// current API state. Returns an error when differences exist so CI can detect drift.
+func (ctx *ImportContext) diffAgainstLiveState(data map[string]interface{}) error {
+	// Only compare resource types that are selected and present in the file
+	opts := ExportOptions{}
+	var sections []exportResourceFile
+	for _, file := range exportResourceFiles {
+		if ctx.skipResourceType(file.resourceType) {
+			continue
 		}
+		if _, ok := data[file.key]; !ok {
+			continue
+		}
+		sections = append(sections, file)
+		switch file.resourceType {
+		case "groups":
+			opts.GroupsOnly = true
+		case "policies":
+			opts.PoliciesOnly = true
+		case "networks":
+			opts.NetworksOnly = true
+		case "routes":
+			opts.RoutesOnly = true
+		case "dns":
+			opts.DNSOnly = true
+		case "posture":
+			opts.PostureOnly = true
+		case "setup-keys":
+			opts.SetupKeysOnly = true
+		}
+	}
+
+	if len(sections) == 0 {
+		fmt.Println("No resources to compare")
+		return nil
 	}
 
-	if !ctx.skipResourceType("routes") {
-		if err := ctx.importRoutes(data); err != nil {
-			return err
-		}
+	fmt.Println("Import Diff (file vs live state)")
+	fmt.Println("================================================")
+	fmt.Println()
+
+	live, err := ctx.Service.fetchAllResources(opts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch current state: %v", err)
 	}
 
-	if !ctx.skipResourceType("dns") {
-		if err := ctx.importDNS(data); err != nil {
-			return err
+	changedResources := 0
+	for _, file := range sections {
+		desired, err := helpers.NormalizeValue(data[file.key])
+		if err != nil {
+			return fmt.Errorf("failed to normalize %s: %v", file.key, err)
+		}
+		current, err := helpers.NormalizeValue(live[file.key])
+		if err != nil {
+			return fmt.Errorf("failed to normalize live %s: %v", file.key, err)
 		}
-	}
 
-	if !ctx.skipResourceType("networks") {
-		if err := ctx.importNetworks(data); err != nil {
-			return err
+		desiredMap, _ := desired.(map[string]interface{})
+		currentMap, _ := current.(map[string]interface{})
+
+		// Collect resource names from both sides
+		nameSet := make(map[string]bool)
+		for name := range desiredMap {
+			nameSet[name] = true
+		}
+		for name := range currentMap {
+			nameSet[name] = true
+		}
+		names := make([]string, 0, len(nameSet))
+		for name := range nameSet {
+			if !strings.HasPrefix(name, "_") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		var lines []string
+		for _, name := range names {
+			currentValue, inCurrent := currentMap[name]
+			desiredValue, inDesired := desiredMap[name]
+
+			switch {
+			case !inCurrent:
+				lines = append(lines, fmt.Sprintf("  + %s (only in file)", name))
+				changedResources++
+			case !inDesired:
+				lines = append(lines, fmt.Sprintf("  - %s (only in live state)", name))
+				changedResources++
+			default:
+				diffs := helpers.DiffValues("", currentValue, desiredValue)
+				if len(diffs) == 0 {
+					continue
+				}
+				changedResources++
+				lines = append(lines, fmt.Sprintf("  ~ %s", name))
+				for _, d := range diffs {
+					switch d.Kind {
+					case "added":
+						lines = append(lines, fmt.Sprintf("      + %s: %s", d.Path, formatDiffValue(d.New)))
+					case "removed":
+						lines = append(lines, fmt.Sprintf("      - %s: %s", d.Path, formatDiffValue(d.Old)))
+					default:
+						lines = append(lines, fmt.Sprintf("      ~ %s: %s -> %s", d.Path, formatDiffValue(d.Old), formatDiffValue(d.New)))
+					}
+				}
+			}
 		}
-	}
 
-	if !ctx.skipResourceType("setup-keys") {
-		if err := ctx.importSetupKeys(data); err != nil {
-			return err
+		if len(lines) == 0 {
+			continue
 		}
+		fmt.Printf("%s:\n", file.key)
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		fmt.Println()
 	}
 
-	return nil
+	if changedResources == 0 {
+		fmt.Println("No differences found")
+		return nil
+	}
+
+	return fmt.Errorf("%d resource(s) differ from live state", changedResources)
+}
+
+// formatDiffValue renders a normalized value compactly for diff output
+func formatDiffValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
 }
 
 // skipResourceType checks if a resource type should be skipped
 func (ctx *ImportContext) skipResourceType(resourceType string) bool {
 	// If no selective flags, import all
 	if !ctx.GroupsOnly && !ctx.PoliciesOnly && !ctx.NetworksOnly &&
-		!ctx.RoutesOnly && !ctx.DNSOnly && !ctx.PostureOnly && !ctx.SetupKeysOnly {
+		!ctx.RoutesOnly && !ctx.DNSOnly && !ctx.PostureOnly && !ctx.SetupKeysOnly && !ctx.PeersOnly {
 		return false
 	}
 
@@ -494,6 +706,8 @@ func (ctx *ImportContext) skipResourceType(resourceType string) bool {
 		return !ctx.PostureOnly
 	case "setup-keys":
 		return !ctx.SetupKeysOnly
+	case "peers":
+		return !ctx.PeersOnly
 	default:
 		return true
 	}
@@ -507,11 +721,13 @@ func (ctx *ImportContext) importGroups(data map[string]interface{}) error {
 	}
 
 	fmt.Println("Groups:")
+	ctx.Progress.Stage("groups")
 
 	for groupName, groupDataInterface := range groupsData {
+		ctx.Progress.Step()
 		groupData, ok := groupDataInterface.(map[string]interface{})
 		if !ok {
-			ctx.addError("Group "+groupName, fmt.Errorf("invalid group data"))
+			ctx.addError("Group "+groupName, fmt.Errorf("invalid group data: expected a mapping%s", ctx.at("groups", groupName)))
 			continue
 		}
 
@@ -538,7 +754,7 @@ func (ctx *ImportContext) importGroup(name string, data map[string]interface{})
 		}
 
 		if !ctx.Update && !ctx.Force {
-			fmt.Printf("  CONFLICT %s (already exists, use --update or --skip-existing)\n", name)
+			fmt.Printf("  CONFLICT %s (already exists, use --on-conflict update or --on-conflict skip)\n", name)
 			return fmt.Errorf("group already exists")
 		}
 
@@ -677,11 +893,13 @@ func (ctx *ImportContext) importPolicies(data map[string]interface{}) error {
 	}
 
 	fmt.Println("Policies:")
+	ctx.Progress.Stage("policies")
 
 	for policyName, policyDataInterface := range policiesData {
+		ctx.Progress.Step()
 		policyData, ok := policyDataInterface.(map[string]interface{})
 		if !ok {
-			ctx.addError("Policy "+policyName, fmt.Errorf("invalid policy data"))
+			ctx.addError("Policy "+policyName, fmt.Errorf("invalid policy data: expected a mapping%s", ctx.at("policies", policyName)))
 			continue
 		}
 
@@ -708,7 +926,7 @@ func (ctx *ImportContext) importPolicy(name string, data map[string]interface{})
 		}
 
 		if !ctx.Update && !ctx.Force {
-			fmt.Printf("  CONFLICT %s (already exists, use --update or --skip-existing)\n", name)
+			fmt.Printf("  CONFLICT %s (already exists, use --on-conflict update or --on-conflict skip)\n", name)
 			return fmt.Errorf("policy already exists")
 		}
 
@@ -741,15 +959,38 @@ func (ctx *ImportContext) importPolicy(name string, data map[string]interface{})
 	return nil
 }
 
+// resolvePostureCheckRefs converts a policy's source_posture_checks names to IDs using
+// the posture checks known to the account (existing or created by this import)
+func (ctx *ImportContext) resolvePostureCheckRefs(refsInterface interface{}, path []string) ([]string, error) {
+	refs, ok := refsInterface.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var ids []string
+	for i, ref := range refs {
+		name, ok := ref.(string)
+		if !ok {
+			continue
+		}
+		checkID, exists := ctx.PostureCheckNameToID[name]
+		if !exists {
+			return nil, fmt.Errorf("source posture check '%s' not found%s", name, ctx.at(subPath(path, strconv.Itoa(i))...))
+		}
+		ids = append(ids, checkID)
+	}
+	return ids, nil
+}
+
 // createPolicy creates a new policy
 func (ctx *ImportContext) createPolicy(name string, data map[string]interface{}) error {
 	description, _ := data["description"].(string)
 	enabled, _ := data["enabled"].(bool)
 
 	// Convert rules
-	rules, err := ctx.convertPolicyRules(data["rules"])
+	rules, err := ctx.convertPolicyRules(data["rules"], []string{"policies", name, "rules"})
 	if err != nil {
-		return fmt.Errorf("failed to convert rules: %v", err)
+		return err
 	}
 
 	reqBody := models.PolicyCreateRequest{
@@ -759,13 +1000,10 @@ func (ctx *ImportContext) createPolicy(name string, data map[string]interface{})
 		Rules:       rules,
 	}
 
-	// Add source posture checks if present
-	if postureChecks, ok := data["source_posture_checks"].([]interface{}); ok {
-		for _, pc := range postureChecks {
-			if pcStr, ok := pc.(string); ok {
-				reqBody.SourcePostureChecks = append(reqBody.SourcePostureChecks, pcStr)
-			}
-		}
+	// Resolve source posture check names to IDs
+	reqBody.SourcePostureChecks, err = ctx.resolvePostureCheckRefs(data["source_posture_checks"], []string{"policies", name, "source_posture_checks"})
+	if err != nil {
+		return err
 	}
 
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -796,9 +1034,9 @@ func (ctx *ImportContext) updatePolicy(name string, data map[string]interface{})
 	enabled, _ := data["enabled"].(bool)
 
 	// Convert rules
-	rules, err := ctx.convertPolicyRules(data["rules"])
+	rules, err := ctx.convertPolicyRules(data["rules"], []string{"policies", name, "rules"})
 	if err != nil {
-		return fmt.Errorf("failed to convert rules: %v", err)
+		return err
 	}
 
 	reqBody := models.PolicyUpdateRequest{
@@ -808,13 +1046,10 @@ func (ctx *ImportContext) updatePolicy(name string, data map[string]interface{})
 		Rules:       rules,
 	}
 
-	// Add source posture checks if present
-	if postureChecks, ok := data["source_posture_checks"].([]interface{}); ok {
-		for _, pc := range postureChecks {
-			if pcStr, ok := pc.(string); ok {
-				reqBody.SourcePostureChecks = append(reqBody.SourcePostureChecks, pcStr)
-			}
-		}
+	// Resolve source posture check names to IDs
+	reqBody.SourcePostureChecks, err = ctx.resolvePostureCheckRefs(data["source_posture_checks"], []string{"policies", name, "source_posture_checks"})
+	if err != nil {
+		return err
 	}
 
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -831,22 +1066,26 @@ func (ctx *ImportContext) updatePolicy(name string, data map[string]interface{})
 	return nil
 }
 
-// convertPolicyRules converts YAML rules to API format
-func (ctx *ImportContext) convertPolicyRules(rulesInterface interface{}) ([]models.PolicyRuleForWrite, error) {
+// convertPolicyRules converts YAML rules to API format. path locates the rules in the
+// YAML source so errors can name the offending line; it may be nil.
+func (ctx *ImportContext) convertPolicyRules(rulesInterface interface{}, path []string) ([]models.PolicyRuleForWrite, error) {
 	if rulesInterface == nil {
 		return []models.PolicyRuleForWrite{}, nil
 	}
 
 	rulesMap, ok := rulesInterface.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("invalid rules format")
+		return nil, fmt.Errorf("invalid rules format: expected a mapping of rule names%s", ctx.at(path...))
 	}
 
 	rules := []models.PolicyRuleForWrite{}
 	for ruleName, ruleDataInterface := range rulesMap {
+		rulePath := subPath(path, ruleName)
+		ruleAt := func(keys ...string) string { return ctx.at(subPath(rulePath, keys...)...) }
+
 		ruleData, ok := ruleDataInterface.(map[string]interface{})
 		if !ok {
-			continue
+			return nil, fmt.Errorf("rule '%s': expected a mapping%s", ruleName, ruleAt())
 		}
 
 		rule := models.PolicyRuleForWrite{
@@ -858,54 +1097,66 @@ func (ctx *ImportContext) convertPolicyRules(rulesInterface interface{}) ([]mode
 			Protocol:      getString(ruleData, "protocol"),
 		}
 
-		// Convert ports
-		if ports, ok := ruleData["ports"].([]interface{}); ok {
-			for _, port := range ports {
-				if portStr, ok := port.(string); ok {
-					rule.Ports = append(rule.Ports, portStr)
+		if rule.Action != "accept" && rule.Action != "drop" {
+			return nil, fmt.Errorf("rule '%s': invalid action '%s' (must be 'accept' or 'drop')%s",
+				ruleName, rule.Action, ruleAt("action"))
+		}
+		if !slices.Contains(validRuleProtocols, rule.Protocol) {
+			return nil, fmt.Errorf("rule '%s': invalid protocol '%s' (must be one of %s)%s",
+				ruleName, rule.Protocol, strings.Join(validRuleProtocols, ", "), ruleAt("protocol"))
+		}
+
+		// Convert ports; unquoted YAML numbers are sent as strings like the API expects
+		if raw, exists := ruleData["ports"]; exists && raw != nil {
+			ports, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("rule '%s': ports must be a list%s", ruleName, ruleAt("ports"))
+			}
+			for i, port := range ports {
+				switch value := port.(type) {
+				case string:
+					rule.Ports = append(rule.Ports, value)
+				case int:
+					rule.Ports = append(rule.Ports, strconv.Itoa(value))
+				default:
+					return nil, fmt.Errorf("rule '%s': invalid port '%v'%s", ruleName, port, ruleAt("ports", strconv.Itoa(i)))
 				}
 			}
 		}
 
 		// Convert port ranges
-		if portRanges, ok := ruleData["port_ranges"].([]interface{}); ok {
-			for _, pr := range portRanges {
-				if prMap, ok := pr.(map[string]interface{}); ok {
-					start, _ := prMap["start"].(int)
-					end, _ := prMap["end"].(int)
-					rule.PortRanges = append(rule.PortRanges, models.PortRange{
-						Start: start,
-						End:   end,
-					})
+		if raw, exists := ruleData["port_ranges"]; exists && raw != nil {
+			portRanges, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("rule '%s': port_ranges must be a list of {start, end}%s", ruleName, ruleAt("port_ranges"))
+			}
+			for i, pr := range portRanges {
+				prMap, _ := pr.(map[string]interface{})
+				start, startOK := prMap["start"].(int)
+				end, endOK := prMap["end"].(int)
+				if !startOK || !endOK {
+					return nil, fmt.Errorf("rule '%s': port range must have numeric 'start' and 'end'%s",
+						ruleName, ruleAt("port_ranges", strconv.Itoa(i)))
 				}
+				rule.PortRanges = append(rule.PortRanges, models.PortRange{
+					Start: start,
+					End:   end,
+				})
 			}
 		}
 
-		// Resolve source group names to IDs
-		if sources, ok := ruleData["sources"].([]interface{}); ok {
-			for _, src := range sources {
-				if srcName, ok := src.(string); ok {
-					srcID, exists := ctx.GroupNameToID[srcName]
-					if !exists {
-						return nil, fmt.Errorf("source group '%s' not found", srcName)
-					}
-					rule.Sources = append(rule.Sources, srcID)
-				}
-			}
+		// Resolve source and destination group names to IDs
+		sources, err := ctx.resolveRuleGroups(ruleName, "sources", ruleData["sources"], rulePath)
+		if err != nil {
+			return nil, err
 		}
+		rule.Sources = sources
 
-		// Resolve destination group names to IDs
-		if dests, ok := ruleData["destinations"].([]interface{}); ok {
-			for _, dest := range dests {
-				if destName, ok := dest.(string); ok {
-					destID, exists := ctx.GroupNameToID[destName]
-					if !exists {
-						return nil, fmt.Errorf("destination group '%s' not found", destName)
-					}
-					rule.Destinations = append(rule.Destinations, destID)
-				}
-			}
+		destinations, err := ctx.resolveRuleGroups(ruleName, "destinations", ruleData["destinations"], rulePath)
+		if err != nil {
+			return nil, err
 		}
+		rule.Destinations = destinations
 
 		rules = append(rules, rule)
 	}
@@ -913,6 +1164,46 @@ func (ctx *ImportContext) convertPolicyRules(rulesInterface interface{}) ([]mode
 	return rules, nil
 }
 
+// resolveRuleGroups converts a rule's sources or destinations group names to IDs
+func (ctx *ImportContext) resolveRuleGroups(ruleName, field string, groupsInterface interface{}, rulePath []string) ([]string, error) {
+	if groupsInterface == nil {
+		return nil, nil
+	}
+	groups, ok := groupsInterface.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rule '%s': %s must be a list of group names%s", ruleName, field, ctx.at(subPath(rulePath, field)...))
+	}
+
+	var ids []string
+	for i, group := range groups {
+		groupName, ok := group.(string)
+		if !ok {
+			return nil, fmt.Errorf("rule '%s': %s entries must be group names, got %v%s",
+				ruleName, field, group, ctx.at(subPath(rulePath, field, strconv.Itoa(i))...))
+		}
+		groupID, exists := ctx.GroupNameToID[groupName]
+		if !exists {
+			return nil, fmt.Errorf("rule '%s': %s group '%s' not found%s",
+				ruleName, strings.TrimSuffix(field, "s"), groupName, ctx.at(subPath(rulePath, field, strconv.Itoa(i))...))
+		}
+		ids = append(ids, groupID)
+	}
+	return ids, nil
+}
+
+// optionalBool returns m[key] as a bool, or def when the key is absent
+func optionalBool(m map[string]interface{}, key string, def bool) (bool, error) {
+	value, exists := m[key]
+	if !exists {
+		return def, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s must be true or false", key)
+	}
+	return b, nil
+}
+
 // Helper functions to safely get values from maps
 func getString(m map[string]interface{}, key string) string {
 	if val, ok := m[key].(string); ok {
@@ -928,13 +1219,6 @@ func getBool(m map[string]interface{}, key string) bool {
 	return false
 }
 
-func getInt(m map[string]interface{}, key string) int {
-	if val, ok := m[key].(int); ok {
-		return val
-	}
-	return 0
-}
-
 // Stub implementations for other resource types (simplified for now)
 func (ctx *ImportContext) importPostureChecks(data map[string]interface{}) error {
 	// TODO: Implement posture checks import
@@ -958,11 +1242,13 @@ func (ctx *ImportContext) importNetworks(data map[string]interface{}) error {
 	}
 
 	fmt.Println("Networks:")
+	ctx.Progress.Stage("networks")
 
 	for networkName, networkDataInterface := range networksData {
+		ctx.Progress.Step()
 		networkData, ok := networkDataInterface.(map[string]interface{})
 		if !ok {
-			ctx.addError("Network "+networkName, fmt.Errorf("invalid network data"))
+			ctx.addError("Network "+networkName, fmt.Errorf("invalid network data: expected a mapping%s", ctx.at("networks", networkName)))
 			continue
 		}
 
@@ -989,7 +1275,7 @@ func (ctx *ImportContext) importNetwork(name string, data map[string]interface{}
 		}
 
 		if !ctx.Update && !ctx.Force {
-			fmt.Printf("  CONFLICT %s (already exists, use --update or --skip-existing)\n", name)
+			fmt.Printf("  CONFLICT %s (already exists, use --on-conflict update or --on-conflict skip)\n", name)
 			return fmt.Errorf("network already exists")
 		}
 
@@ -1053,15 +1339,7 @@ func (ctx *ImportContext) createNetwork(name string, data map[string]interface{}
 	ctx.ExistingNetworks[name] = &createdNetwork
 
 	// Now add resources and routers
-	if err := ctx.addNetworkResources(createdNetwork.ID, data); err != nil {
-		return fmt.Errorf("failed to add resources: %v", err)
-	}
-
-	if err := ctx.addNetworkRouters(createdNetwork.ID, data); err != nil {
-		return fmt.Errorf("failed to add routers: %v", err)
-	}
-
-	return nil
+	return ctx.addNetworkContents(name, createdNetwork.ID, data)
 }
 
 // updateNetwork updates an existing network
@@ -1086,155 +1364,354 @@ func (ctx *ImportContext) updateNetwork(name, networkID string, data map[string]
 	}
 
 	// Update resources and routers
-	if err := ctx.addNetworkResources(networkID, data); err != nil {
-		return fmt.Errorf("failed to add resources: %v", err)
-	}
+	return ctx.addNetworkContents(name, networkID, data)
+}
 
-	if err := ctx.addNetworkRouters(networkID, data); err != nil {
-		return fmt.Errorf("failed to add routers: %v", err)
+// addNetworkContents adds a network's resources and routers. Routers are attempted even
+// when some resources fail, and both sets of failures are reported.
+func (ctx *ImportContext) addNetworkContents(name, networkID string, data map[string]interface{}) error {
+	var failures []string
+	if err := ctx.addNetworkResources(name, networkID, data); err != nil {
+		failures = append(failures, fmt.Sprintf("failed to add resources: %v", err))
+	}
+	if err := ctx.addNetworkRouters(name, networkID, data); err != nil {
+		failures = append(failures, fmt.Sprintf("failed to add routers: %v", err))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
 	}
-
 	return nil
 }
 
-// addNetworkResources adds resources to a network
-func (ctx *ImportContext) addNetworkResources(networkID string, data map[string]interface{}) error {
+// addNetworkResources adds resources to a network. Every resource is attempted; failures are
+// returned together so one bad resource doesn't hide problems with the others.
+func (ctx *ImportContext) addNetworkResources(networkName, networkID string, data map[string]interface{}) error {
 	resourcesData, ok := data["resources"].(map[string]interface{})
 	if !ok || len(resourcesData) == 0 {
 		return nil // No resources to add
 	}
 
-	for resourceName, resourceDataInterface := range resourcesData {
-		resourceData, ok := resourceDataInterface.(map[string]interface{})
-		if !ok {
-			continue
+	var failures []string
+	for _, resourceName := range sortedKeys(resourcesData) {
+		if err := ctx.addNetworkResource(networkName, networkID, resourceName, resourcesData[resourceName]); err != nil {
+			failures = append(failures, err.Error())
 		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d resource(s) failed: %s", len(failures), len(resourcesData), strings.Join(failures, "; "))
+	}
+	return nil
+}
 
-		address, _ := resourceData["address"].(string)
-		description, _ := resourceData["description"].(string)
-		enabled := getBool(resourceData, "enabled")
-		resourceType, _ := resourceData["type"].(string)
-
-		// Resolve group names to IDs
-		var groupIDs []string
-		if groupsInterface, ok := resourceData["groups"].([]interface{}); ok {
-			for _, groupInterface := range groupsInterface {
-				if groupName, ok := groupInterface.(string); ok {
-					groupID, exists := ctx.GroupNameToID[groupName]
-					if !exists {
-						return fmt.Errorf("group '%s' not found for resource '%s'", groupName, resourceName)
-					}
-					groupIDs = append(groupIDs, groupID)
+// addNetworkResource creates one resource from its YAML entry
+func (ctx *ImportContext) addNetworkResource(networkName, networkID, resourceName string, resourceDataInterface interface{}) error {
+	resourcePath := []string{"networks", networkName, "resources", resourceName}
+	resourceData, ok := resourceDataInterface.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("resource '%s': expected a mapping%s", resourceName, ctx.at(resourcePath...))
+	}
+
+	address, _ := resourceData["address"].(string)
+	description, _ := resourceData["description"].(string)
+	enabled := getBool(resourceData, "enabled")
+	resourceType, _ := resourceData["type"].(string)
+
+	// Resolve group names to IDs
+	var groupIDs []string
+	if groupsInterface, ok := resourceData["groups"].([]interface{}); ok {
+		for i, groupInterface := range groupsInterface {
+			if groupName, ok := groupInterface.(string); ok {
+				groupID, exists := ctx.GroupNameToID[groupName]
+				if !exists {
+					return fmt.Errorf("group '%s' not found for resource '%s'%s",
+						groupName, resourceName, ctx.at(subPath(resourcePath, "groups", strconv.Itoa(i))...))
 				}
+				groupIDs = append(groupIDs, groupID)
 			}
 		}
+	}
 
-		if len(groupIDs) == 0 {
-			return fmt.Errorf("resource '%s' must have at least one group", resourceName)
-		}
-
-		if address == "" {
-			return fmt.Errorf("resource '%s' must have an address", resourceName)
-		}
+	if len(groupIDs) == 0 {
+		return fmt.Errorf("resource '%s' must have at least one group%s", resourceName, ctx.at(subPath(resourcePath, "groups")...))
+	}
 
-		// Set type to subnet if not specified
-		if resourceType == "" {
-			resourceType = "subnet"
-		}
+	if address == "" {
+		return fmt.Errorf("resource '%s' must have an address%s", resourceName, ctx.at(subPath(resourcePath, "address")...))
+	}
 
-		// Create the resource
-		resourceReq := models.NetworkResourceRequest{
-			Name:        resourceName,
-			Description: description,
-			Address:     address,
-			Enabled:     enabled,
-			Groups:      groupIDs,
-		}
+	// Set type to subnet if not specified
+	if resourceType == "" {
+		resourceType = "subnet"
+	}
 
-		bodyBytes, _ := json.Marshal(resourceReq)
-		resp, err := ctx.Service.Client.MakeRequest("POST", "/networks/"+networkID+"/resources", bytes.NewReader(bodyBytes))
-		if err != nil {
-			return fmt.Errorf("failed to create resource '%s': %v", resourceName, err)
-		}
-		defer resp.Body.Close()
+	// Create the resource
+	resourceReq := models.NetworkResourceRequest{
+		Name:        resourceName,
+		Description: description,
+		Address:     address,
+		Enabled:     enabled,
+		Groups:      groupIDs,
+	}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return fmt.Errorf("failed to create resource '%s': %s", resourceName, resp.Status)
-		}
+	bodyBytes, _ := json.Marshal(resourceReq)
+	resp, err := ctx.Service.Client.MakeRequest("POST", "/networks/"+networkID+"/resources", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create resource '%s': %v", resourceName, err)
 	}
+	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to create resource '%s': %s", resourceName, resp.Status)
+	}
 	return nil
 }
 
-// addNetworkRouters adds routers to a network
-func (ctx *ImportContext) addNetworkRouters(networkID string, data map[string]interface{}) error {
+// addNetworkRouters adds routers to a network. Every router is attempted; failures are
+// returned together so one bad router doesn't hide problems with the others.
+func (ctx *ImportContext) addNetworkRouters(networkName, networkID string, data map[string]interface{}) error {
 	routersData, ok := data["routers"].(map[string]interface{})
 	if !ok || len(routersData) == 0 {
 		return nil // No routers to add
 	}
 
-	for routerName, routerDataInterface := range routersData {
-		routerData, ok := routerDataInterface.(map[string]interface{})
-		if !ok {
-			continue
+	var failures []string
+	for _, routerName := range sortedKeys(routersData) {
+		if err := ctx.addNetworkRouter(networkName, networkID, routerName, routersData[routerName]); err != nil {
+			failures = append(failures, err.Error())
 		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d router(s) failed: %s", len(failures), len(routersData), strings.Join(failures, "; "))
+	}
+	return nil
+}
 
-		peer, _ := routerData["peer"].(string)
-		metric := getInt(routerData, "metric")
-		if metric == 0 {
-			metric = 100 // Default metric
-		}
-		masquerade := getBool(routerData, "masquerade")
-		enabled := getBool(routerData, "enabled")
+// addNetworkRouter creates one router from its YAML entry
+func (ctx *ImportContext) addNetworkRouter(networkName, networkID, routerName string, routerDataInterface interface{}) error {
+	routerPath := []string{"networks", networkName, "routers", routerName}
+	routerData, ok := routerDataInterface.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("router '%s': expected a mapping%s", routerName, ctx.at(routerPath...))
+	}
 
-		// Resolve peer groups if present
-		var peerGroups []string
-		if peerGroupsInterface, ok := routerData["peer_groups"].([]interface{}); ok {
-			for _, pgInterface := range peerGroupsInterface {
-				if pgName, ok := pgInterface.(string); ok {
-					pgID, exists := ctx.GroupNameToID[pgName]
-					if !exists {
-						return fmt.Errorf("peer group '%s' not found for router '%s'", pgName, routerName)
-					}
-					peerGroups = append(peerGroups, pgID)
+	peer, _ := routerData["peer"].(string)
+
+	// Omitted settings get the same defaults as 'network --add-router'
+	metric := defaultRouterMetric
+	if value, exists := routerData["metric"]; exists {
+		n, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("router '%s': metric must be a number%s", routerName, ctx.at(subPath(routerPath, "metric")...))
+		}
+		metric = n
+	}
+	masquerade, err := optionalBool(routerData, "masquerade", defaultRouterMasquerade)
+	if err != nil {
+		return fmt.Errorf("router '%s': %v%s", routerName, err, ctx.at(subPath(routerPath, "masquerade")...))
+	}
+	enabled, err := optionalBool(routerData, "enabled", defaultRouterEnabled)
+	if err != nil {
+		return fmt.Errorf("router '%s': %v%s", routerName, err, ctx.at(subPath(routerPath, "enabled")...))
+	}
+
+	// Resolve peer groups if present
+	var peerGroups []string
+	if peerGroupsInterface, ok := routerData["peer_groups"].([]interface{}); ok {
+		for i, pgInterface := range peerGroupsInterface {
+			if pgName, ok := pgInterface.(string); ok {
+				pgID, exists := ctx.GroupNameToID[pgName]
+				if !exists {
+					return fmt.Errorf("peer group '%s' not found for router '%s'%s",
+						pgName, routerName, ctx.at(subPath(routerPath, "peer_groups", strconv.Itoa(i))...))
 				}
+				peerGroups = append(peerGroups, pgID)
 			}
 		}
+	}
 
-		// Must have either peer or peer_groups
-		if peer == "" && len(peerGroups) == 0 {
-			return fmt.Errorf("router '%s' must have either a peer or peer_groups", routerName)
-		}
+	// Validated and built exactly as 'network --add-router' does
+	routerReq, err := newNetworkRouterRequest(peer, peerGroups, metric, masquerade, enabled)
+	if err != nil {
+		return fmt.Errorf("router '%s': %v%s", routerName, err, ctx.at(routerPath...))
+	}
 
-		// Create the router
-		routerReq := models.NetworkRouterRequest{
-			Peer:       peer,
-			PeerGroups: peerGroups,
-			Metric:     metric,
-			Masquerade: masquerade,
-			Enabled:    enabled,
-		}
+	bodyBytes, _ := json.Marshal(routerReq)
+	resp, err := ctx.Service.Client.MakeRequest("POST", "/networks/"+networkID+"/routers", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create router '%s': %v", routerName, err)
+	}
+	defer resp.Body.Close()
 
-		bodyBytes, _ := json.Marshal(routerReq)
-		resp, err := ctx.Service.Client.MakeRequest("POST", "/networks/"+networkID+"/routers", bytes.NewReader(bodyBytes))
-		if err != nil {
-			return fmt.Errorf("failed to create router '%s': %v", routerName, err)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to create router '%s': %s", routerName, resp.Status)
+	}
+	return nil
+}
+
+func (ctx *ImportContext) importSetupKeys(data map[string]interface{}) error {
+	// TODO: Implement setup keys import
+	return nil
+}
+
+// importPeers reconciles settings of existing peers from the top-level peers section.
+// Peers can't be created via the API, so unknown peers are skipped with a warning.
+func (ctx *ImportContext) importPeers(data map[string]interface{}) error {
+	peersData, ok := data["peers"].(map[string]interface{})
+	if !ok {
+		return nil // No peers to import
+	}
+
+	fmt.Println("Peers:")
+	ctx.Progress.Stage("peers")
+
+	names := make([]string, 0, len(peersData))
+	for name := range peersData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, peerName := range names {
+		ctx.Progress.Step()
+		peerData, ok := peersData[peerName].(map[string]interface{})
+		if !ok {
+			ctx.addError("Peer "+peerName, fmt.Errorf("invalid peer data: expected a mapping%s", ctx.at("peers", peerName)))
+			continue
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return fmt.Errorf("failed to create router '%s': %s", routerName, resp.Status)
+		if err := ctx.importPeer(peerName, peerData); err != nil {
+			ctx.addError("Peer "+peerName, err)
 		}
 	}
 
+	fmt.Println()
 	return nil
 }
 
-func (ctx *ImportContext) importSetupKeys(data map[string]interface{}) error {
-	// TODO: Implement setup keys import
+// importPeer updates a single existing peer's settings
+func (ctx *ImportContext) importPeer(name string, data map[string]interface{}) error {
+	matches := ctx.ExistingPeers[name]
+	if len(matches) == 0 {
+		fmt.Printf("  SKIP     %s (peer not found; peers cannot be created via import)\n", name)
+		ctx.Skipped = append(ctx.Skipped, "Peer "+name)
+		return nil
+	}
+	if len(matches) > 1 {
+		fmt.Printf("  FAILED   %s (%d peers share this name)\n", name, len(matches))
+		return fmt.Errorf("peer name is ambiguous")
+	}
+	peer := matches[0]
+
+	updateReq, changes, err := peerUpdateFromConfig(peer, data, func(field string) string {
+		return ctx.at("peers", name, field)
+	})
+	if err != nil {
+		fmt.Printf("  FAILED   %s (%v)\n", name, err)
+		return err
+	}
+
+	if len(changes) == 0 {
+		if ctx.Verbose {
+			fmt.Printf("  SKIP     %s (no changes)\n", name)
+		}
+		ctx.Skipped = append(ctx.Skipped, "Peer "+name)
+		return nil
+	}
+
+	if ctx.SkipExisting {
+		fmt.Printf("  SKIP     %s (already exists)\n", name)
+		ctx.Skipped = append(ctx.Skipped, "Peer "+name)
+		return nil
+	}
+
+	if !ctx.Update && !ctx.Force {
+		fmt.Printf("  CONFLICT %s (differs: %s; use --on-conflict update)\n", name, strings.Join(changes, ", "))
+		return fmt.Errorf("peer settings differ")
+	}
+
+	if !ctx.Apply {
+		fmt.Printf("  UPDATE   %s (would update: %s)\n", name, strings.Join(changes, ", "))
+		return nil
+	}
+
+	bodyBytes, err := json.Marshal(updateReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := ctx.Service.Client.MakeRequest("PUT", "/peers/"+peer.ID, bytes.NewReader(bodyBytes))
+	if err != nil {
+		fmt.Printf("  FAILED   %s (%v)\n", name, err)
+		return err
+	}
+	resp.Body.Close()
+
+	fmt.Printf("  UPDATED  %s (%s)\n", name, strings.Join(changes, ", "))
+	ctx.Updated = append(ctx.Updated, "Peer "+name)
 	return nil
 }
 
+// peerUpdateFromConfig builds a full peer update from the current peer and the configured
+// fields, returning the list of changed fields. Fields missing from the config are preserved.
+// at returns the source position suffix for a field's error message.
+func peerUpdateFromConfig(peer models.Peer, data map[string]interface{}, at func(field string) string) (models.PeerUpdateRequest, []string, error) {
+	updateReq := models.PeerUpdateRequest{
+		Name:                        peer.Name,
+		SSHEnabled:                  peer.SSHEnabled,
+		LoginExpirationEnabled:      peer.LoginExpirationEnabled,
+		InactivityExpirationEnabled: peer.InactivityExpirationEnabled,
+		ApprovalRequired:            peer.ApprovalRequired,
+	}
+	var changes []string
+
+	if value, ok := data["name"]; ok {
+		newName, ok := value.(string)
+		if !ok || newName == "" {
+			return updateReq, nil, fmt.Errorf("name must be a non-empty string%s", at("name"))
+		}
+		if newName != peer.Name {
+			updateReq.Name = newName
+			changes = append(changes, fmt.Sprintf("name -> %s", newName))
+		}
+	}
+
+	boolFields := []struct {
+		key     string
+		current bool
+		target  *bool
+	}{
+		{"ssh_enabled", peer.SSHEnabled, &updateReq.SSHEnabled},
+		{"login_expiration_enabled", peer.LoginExpirationEnabled, &updateReq.LoginExpirationEnabled},
+		{"inactivity_expiration_enabled", peer.InactivityExpirationEnabled, &updateReq.InactivityExpirationEnabled},
+	}
+	for _, field := range boolFields {
+		value, ok := data[field.key]
+		if !ok {
+			continue
+		}
+		desired, ok := value.(bool)
+		if !ok {
+			return updateReq, nil, fmt.Errorf("%s must be true or false%s", field.key, at(field.key))
+		}
+		if desired != field.current {
+			*field.target = desired
+			changes = append(changes, fmt.Sprintf("%s -> %t", field.key, desired))
+		}
+	}
+
+	if value, ok := data["approval_required"]; ok {
+		desired, ok := value.(bool)
+		if !ok {
+			return updateReq, nil, fmt.Errorf("approval_required must be true or false%s", at("approval_required"))
+		}
+		if peer.ApprovalRequired == nil || *peer.ApprovalRequired != desired {
+			updateReq.ApprovalRequired = &desired
+			changes = append(changes, fmt.Sprintf("approval_required -> %t", desired))
+		}
+	}
+
+	return updateReq, changes, nil
+}
+
 // checkForPeersInConfig checks if peers are referenced in the YAML config and displays a warning
 // Peers cannot be imported via YAML - they must be migrated using the migrate command
 func (ctx *ImportContext) checkForPeersInConfig(data map[string]interface{}) {
@@ -1278,6 +1755,7 @@ func (ctx *ImportContext) checkForPeersInConfig(data map[string]interface{}) {
 		fmt.Println("================================================")
 		fmt.Printf("Found %d peer(s) referenced in the configuration.\n", len(peerSet))
 		fmt.Println("Groups will be created/updated WITHOUT these peers.")
+		fmt.Println("(Settings of existing peers can be managed in a top-level 'peers' section.)")
 		fmt.Println()
 		fmt.Println("To migrate peers between accounts, use the migrate command:")
 		fmt.Println("  netbird-manage migrate --source-token <token> --dest-token <token> --peer <id>")
@@ -1331,6 +1809,13 @@ func (ctx *ImportContext) printSummary() {
 		}
 	}
 
+	if len(ctx.Pruned) > 0 {
+		fmt.Printf("Pruned:   %d resources\n", len(ctx.Pruned))
+		for _, res := range ctx.Pruned {
+			fmt.Printf("    - %s\n", res)
+		}
+	}
+
 	if len(ctx.Failed) > 0 {
 		fmt.Printf("Failed:   %d resources\n", len(ctx.Failed))
 		fmt.Println()
@@ -1369,12 +1854,12 @@ func (ctx *ImportContext) printSummary() {
 	if !ctx.Apply {
 		fmt.Println("This was a dry run. Use --apply to execute these changes.")
 	} else {
-		totalChanges := len(ctx.Created) + len(ctx.Updated)
+		totalChanges := len(ctx.Created) + len(ctx.Updated) + len(ctx.Pruned)
 		if totalChanges > 0 {
 			fmt.Printf("Successfully applied %d changes!\n", totalChanges)
 		}
 		if len(ctx.Failed) > 0 {
-			fmt.Println("Some resources failed to import. Fix errors and re-run with --skip-existing")
+			fmt.Println("Some resources failed to import. Fix errors and re-run with --on-conflict skip")
 		}
 	}
 }
@@ -0,0 +1,108 @@
+// import_positions.go - YAML source positions for import error messages
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlPos is where a value appears in the imported YAML
+type yamlPos struct {
+	File   string // Set for directory imports, where several files are merged
+	Line   int
+	Column int
+}
+
+// yamlPositions maps a key path (e.g. policies/web/rules/x/action) to its source position
+type yamlPositions map[string]yamlPos
+
+// yamlPathSep joins key paths; resource names may contain '/' so a control character is used
+const yamlPathSep = "\x1f"
+
+// String formats a position for the end of an error message
+func (p yamlPos) String() string {
+	if p.File != "" {
+		return fmt.Sprintf("%s line %d, column %d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+// decodeYAMLWithPositions decodes YAML via yaml.Node, returning the data and the source
+// position of every mapping key and sequence item
+func decodeYAMLWithPositions(data []byte, file string) (map[string]interface{}, yamlPositions, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	positions := make(yamlPositions)
+	if len(doc.Content) == 0 {
+		return nil, positions, nil
+	}
+
+	var result map[string]interface{}
+	if err := doc.Decode(&result); err != nil {
+		return nil, nil, err
+	}
+	indexYAMLNode(doc.Content[0], file, nil, positions)
+	return result, positions, nil
+}
+
+// indexYAMLNode records positions under path. Scalars point at the value itself;
+// mappings and sequences point at their key, since their content starts on a later line.
+func indexYAMLNode(node *yaml.Node, file string, path []string, positions yamlPositions) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				// Merge keys contribute their mapping's entries to this level
+				indexYAMLNode(value, file, path, positions)
+				continue
+			}
+			childPath := subPath(path, key.Value)
+			pos := yamlPos{File: file, Line: value.Line, Column: value.Column}
+			if value.Kind != yaml.ScalarNode {
+				pos = yamlPos{File: file, Line: key.Line, Column: key.Column}
+			}
+			positions[strings.Join(childPath, yamlPathSep)] = pos
+			indexYAMLNode(value, file, childPath, positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			childPath := subPath(path, strconv.Itoa(i))
+			positions[strings.Join(childPath, yamlPathSep)] = yamlPos{File: file, Line: item.Line, Column: item.Column}
+			indexYAMLNode(item, file, childPath, positions)
+		}
+	}
+}
+
+// subPath returns path extended by keys without modifying path's backing array
+func subPath(path []string, keys ...string) []string {
+	return append(append(make([]string, 0, len(path)+len(keys)), path...), keys...)
+}
+
+// mergePositions copies positions from src into dst
+func mergePositions(dst, src yamlPositions) {
+	for key, pos := range src {
+		dst[key] = pos
+	}
+}
+
+// at returns " at line N, column M" for the value at path. A missing key falls back to
+// its nearest parent; "" is returned for data that did not come from a YAML file.
+func (ctx *ImportContext) at(path ...string) string {
+	for n := len(path); n > 0; n-- {
+		if pos, ok := ctx.Positions[strings.Join(path[:n], yamlPathSep)]; ok {
+			return " at " + pos.String()
+		}
+	}
+	return ""
+}
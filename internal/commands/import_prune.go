@@ -0,0 +1,111 @@
+// import_prune.go - import --prune: delete resources absent from the YAML
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"netbird-manage/internal/helpers"
+)
+
+// pruneCandidate is an existing resource that is not declared in the imported YAML
+type pruneCandidate struct {
+	Kind     string // Display label, e.g. "Group"
+	Name     string
+	Endpoint string // DELETE endpoint
+}
+
+// pruneResources deletes resources of each imported type whose names are absent from
+// the YAML. Only groups, policies, and networks are pruned, and only when their section
+// is present in the file; setup keys and peers are never pruned.
+func (ctx *ImportContext) pruneResources(data map[string]interface{}) {
+	candidates := ctx.collectPruneCandidates(data)
+
+	fmt.Println("Prune:")
+	if len(candidates) == 0 {
+		fmt.Println("  Nothing to prune")
+		fmt.Println()
+		return
+	}
+
+	items := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = fmt.Sprintf("%s %s", candidate.Kind, candidate.Name)
+	}
+	if !helpers.ConfirmBulkDeletion("resources", items, len(candidates)) {
+		for _, item := range items {
+			ctx.Skipped = append(ctx.Skipped, item+" (prune cancelled)")
+		}
+		fmt.Println()
+		return
+	}
+
+	for i, candidate := range candidates {
+		resp, err := ctx.Service.Client.MakeRequest("DELETE", candidate.Endpoint, nil)
+		if err != nil {
+			fmt.Printf("  FAILED   %s (%v)\n", items[i], err)
+			ctx.addError(items[i], fmt.Errorf("prune failed: %v", err))
+			continue
+		}
+		resp.Body.Close()
+		fmt.Printf("  PRUNED   %s\n", items[i])
+		ctx.Pruned = append(ctx.Pruned, items[i])
+	}
+	fmt.Println()
+}
+
+// collectPruneCandidates lists existing resources missing from the YAML, ordered so
+// that policies are removed before the networks and groups they may reference
+func (ctx *ImportContext) collectPruneCandidates(data map[string]interface{}) []pruneCandidate {
+	var candidates []pruneCandidate
+
+	if declared, ok := ctx.prunableSection(data, "policies", "policies"); ok {
+		for _, name := range sortedKeys(ctx.ExistingPolicies) {
+			if _, keep := declared[name]; !keep {
+				candidates = append(candidates, pruneCandidate{"Policy", name, "/policies/" + ctx.ExistingPolicies[name].ID})
+			}
+		}
+	}
+
+	if declared, ok := ctx.prunableSection(data, "networks", "networks"); ok {
+		for _, name := range sortedKeys(ctx.ExistingNetworks) {
+			if _, keep := declared[name]; !keep {
+				candidates = append(candidates, pruneCandidate{"Network", name, "/networks/" + ctx.ExistingNetworks[name].ID})
+			}
+		}
+	}
+
+	if declared, ok := ctx.prunableSection(data, "groups", "groups"); ok {
+		for _, name := range sortedKeys(ctx.ExistingGroups) {
+			// The built-in "All" group cannot be deleted
+			if name == "All" {
+				continue
+			}
+			if _, keep := declared[name]; !keep {
+				candidates = append(candidates, pruneCandidate{"Group", name, "/groups/" + ctx.ExistingGroups[name].ID})
+			}
+		}
+	}
+
+	return candidates
+}
+
+// prunableSection returns the declared names of a resource type when it is selected
+// for import and present in the YAML. A missing section never triggers pruning.
+func (ctx *ImportContext) prunableSection(data map[string]interface{}, resourceType, key string) (map[string]interface{}, bool) {
+	if ctx.skipResourceType(resourceType) {
+		return nil, false
+	}
+	section, ok := data[key].(map[string]interface{})
+	return section, ok
+}
+
+// sortedKeys returns a map's keys in sorted order for stable output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -37,7 +38,7 @@ func (s *Service) HandleIngressPortsCommand(args []string) error {
 	descriptionFlag := ingressPortCmd.String("description", "", "Port allocation description")
 
 	// Output format
-	outputFlag := ingressPortCmd.String("output", "table", "Output format: table or json")
+	outputFlag := ingressPortCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// If no flags are provided (just 'netbird-manage ingress-port'), show usage
 	if len(args) == 1 {
@@ -125,6 +126,7 @@ func (s *Service) HandleIngressPeersCommand(args []string) error {
 
 	// Query flags
 	listFlag := ingressPeerCmd.Bool("list", false, "List all ingress peers")
+	filterEnabledFlag := ingressPeerCmd.String("filter-enabled", "", "Filter list by enabled state (true/false)")
 	inspectFlag := ingressPeerCmd.String("inspect", "", "Inspect an ingress peer by its ID")
 
 	// Modification flags
@@ -138,7 +140,7 @@ func (s *Service) HandleIngressPeersCommand(args []string) error {
 	enabledFlag := ingressPeerCmd.String("enabled", "", "Enable/disable ingress peer (true/false)")
 
 	// Output format
-	outputFlag := ingressPeerCmd.String("output", "table", "Output format: table or json")
+	outputFlag := ingressPeerCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// If no flags are provided (just 'netbird-manage ingress-peer'), show usage
 	if len(args) == 1 {
@@ -154,7 +156,15 @@ func (s *Service) HandleIngressPeersCommand(args []string) error {
 
 	// Handle the flags
 	if *listFlag {
-		return s.listIngressPeers(*outputFlag)
+		var enabledFilter *bool
+		if *filterEnabledFlag != "" {
+			enabled, err := strconv.ParseBool(*filterEnabledFlag)
+			if err != nil {
+				return fmt.Errorf("invalid value for --filter-enabled: %v", err)
+			}
+			enabledFilter = &enabled
+		}
+		return s.listIngressPeers(enabledFilter, *outputFlag)
 	}
 
 	if *inspectFlag != "" {
@@ -232,7 +242,7 @@ func (s *Service) listIngressPorts(peerID string, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(allocations, "", "  ")
+		output, err := helpers.MarshalJSONOutput(allocations)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -242,27 +252,47 @@ func (s *Service) listIngressPorts(peerID string, outputFormat string) error {
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ALLOCATION ID\tTARGET PORT\tPUBLIC PORT\tPROTOCOL\tDESCRIPTION")
-	fmt.Fprintln(w, "-------------\t-----------\t-----------\t--------\t-----------")
+	helpers.TableHeader(w, "ALLOCATION ID", "TARGET PORT", "PUBLIC PORT", "PROTOCOL", "INGRESS PEER", "DESCRIPTION")
 
+	pending := 0
 	for _, allocation := range allocations {
 		desc := allocation.Description
 		if desc == "" {
 			desc = "-"
 		}
-		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n",
+		ingressPeer := allocation.IngressPeer
+		if ingressPeer == "" {
+			ingressPeer = "-"
+		}
+		if allocation.PublicPort == 0 {
+			pending++
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
 			allocation.ID,
 			allocation.TargetPort,
-			allocation.PublicPort,
+			formatPublicPort(allocation.PublicPort),
 			allocation.Protocol,
+			ingressPeer,
 			desc,
 		)
 	}
 	w.Flush()
 
+	if pending > 0 {
+		fmt.Printf("\n%d allocation(s) have not been assigned a public port yet\n", pending)
+	}
+
 	return nil
 }
 
+// formatPublicPort renders a public port, marking allocations NetBird Cloud has not assigned yet
+func formatPublicPort(port int) string {
+	if port == 0 {
+		return "(pending)"
+	}
+	return strconv.Itoa(port)
+}
+
 // inspectIngressPort shows detailed information about a port allocation
 func (s *Service) inspectIngressPort(peerID, allocationID string, outputFormat string) error {
 	resp, err := s.Client.MakeRequest("GET", "/peers/"+peerID+"/ingress/ports/"+allocationID, nil)
@@ -278,7 +308,7 @@ func (s *Service) inspectIngressPort(peerID, allocationID string, outputFormat s
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(allocation, "", "  ")
+		output, err := helpers.MarshalJSONOutput(allocation)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -290,7 +320,7 @@ func (s *Service) inspectIngressPort(peerID, allocationID string, outputFormat s
 	fmt.Printf("Allocation ID:  %s\n", allocation.ID)
 	fmt.Printf("Peer ID:        %s\n", allocation.PeerID)
 	fmt.Printf("Target Port:    %d\n", allocation.TargetPort)
-	fmt.Printf("Public Port:    %d\n", allocation.PublicPort)
+	fmt.Printf("Public Port:    %s\n", formatPublicPort(allocation.PublicPort))
 	fmt.Printf("Protocol:       %s\n", allocation.Protocol)
 	fmt.Printf("Description:    %s\n", allocation.Description)
 	if allocation.IngressPeer != "" {
@@ -389,17 +419,22 @@ func (s *Service) deleteIngressPort(peerID, allocationID string) error {
 	return nil
 }
 
-// listIngressPeers lists all ingress peers
-func (s *Service) listIngressPeers(outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/ingress/peers", nil)
+// listIngressPeers lists all ingress peers, optionally filtered by enabled state
+func (s *Service) listIngressPeers(enabledFilter *bool, outputFormat string) error {
+	peers, err := client.GetList[models.IngressPeer](s.Client, "/ingress/peers")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var peers []models.IngressPeer
-	if err := json.NewDecoder(resp.Body).Decode(&peers); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	// Apply enabled filter
+	if enabledFilter != nil {
+		filtered := make([]models.IngressPeer, 0, len(peers))
+		for _, peer := range peers {
+			if peer.Enabled == *enabledFilter {
+				filtered = append(filtered, peer)
+			}
+		}
+		peers = filtered
 	}
 
 	if len(peers) == 0 {
@@ -409,7 +444,7 @@ func (s *Service) listIngressPeers(outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(peers, "", "  ")
+		output, err := helpers.MarshalJSONOutput(peers)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -419,23 +454,18 @@ func (s *Service) listIngressPeers(outputFormat string) error {
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "INGRESS PEER ID\tNAME\tLOCATION\tHOSTNAME\tENABLED")
-	fmt.Fprintln(w, "---------------\t----\t--------\t--------\t-------")
+	helpers.TableHeader(w, "INGRESS PEER ID", "NAME", "LOCATION", "HOSTNAME", "ENABLED")
 
 	for _, peer := range peers {
 		location := peer.Location
 		if location == "" {
 			location = "-"
 		}
-		hostname := peer.Hostname
-		if hostname == "" {
-			hostname = "-"
-		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n",
 			peer.ID,
 			peer.Name,
 			location,
-			hostname,
+			formatIngressHostname(peer.Hostname),
 			peer.Enabled,
 		)
 	}
@@ -444,22 +474,24 @@ func (s *Service) listIngressPeers(outputFormat string) error {
 	return nil
 }
 
-// inspectIngressPeer shows detailed information about an ingress peer
-func (s *Service) inspectIngressPeer(ingressPeerID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/ingress/peers/"+ingressPeerID, nil)
-	if err != nil {
-		return err
+// formatIngressHostname renders the public hostname, marking peers that have none yet
+func formatIngressHostname(hostname string) string {
+	if hostname == "" {
+		return "(not provisioned)"
 	}
-	defer resp.Body.Close()
+	return hostname
+}
 
+// inspectIngressPeer shows detailed information about an ingress peer
+func (s *Service) inspectIngressPeer(ingressPeerID string, outputFormat string) error {
 	var peer models.IngressPeer
-	if err := json.NewDecoder(resp.Body).Decode(&peer); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	if err := s.Client.GetJSON("/ingress/peers/"+ingressPeerID, &peer); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(peer, "", "  ")
+		output, err := helpers.MarshalJSONOutput(peer)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -471,7 +503,7 @@ func (s *Service) inspectIngressPeer(ingressPeerID string, outputFormat string)
 	fmt.Printf("Ingress Peer ID: %s\n", peer.ID)
 	fmt.Printf("Name:            %s\n", peer.Name)
 	fmt.Printf("Location:        %s\n", peer.Location)
-	fmt.Printf("Hostname:        %s\n", peer.Hostname)
+	fmt.Printf("Hostname:        %s\n", formatIngressHostname(peer.Hostname))
 	fmt.Printf("Enabled:         %t\n", peer.Enabled)
 	if peer.CreatedAt != "" {
 		fmt.Printf("Created At:      %s\n", peer.CreatedAt)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -46,6 +47,48 @@ type MigrateOptions struct {
 	Update          bool
 	DryRun          bool
 	Verbose         bool
+	// Bulk peer migration state (--state-file / --resume)
+	StateFile string
+	Resume    bool
+	// Bulk peer selection (--include-peers / --exclude-peers / --peer-filter)
+	PeerSelection peerSelection
+	// Compact progress indicator for configuration migration (--progress)
+	Progress bool
+	// Hide per-resource lines during configuration migration (--summary-only)
+	SummaryOnly bool
+	// JSON summary file for configuration migration (--summary-json)
+	SummaryJSON string
+}
+
+// headerFlag collects repeatable 'Name: Value' header flags such as --source-extra-header
+type headerFlag map[string]string
+
+func (h headerFlag) String() string {
+	return ""
+}
+
+func (h headerFlag) Set(value string) error {
+	name, headerValue, err := config.ParseExtraHeader(value)
+	if err != nil {
+		return err
+	}
+	h[name] = headerValue
+	return nil
+}
+
+// mergeInto returns base overlaid with the flag's headers
+func (h headerFlag) mergeInto(base map[string]string) map[string]string {
+	if len(h) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(h))
+	for name, value := range base {
+		merged[name] = value
+	}
+	for name, value := range h {
+		merged[name] = value
+	}
+	return merged
 }
 
 // HandleMigrateCommand handles the migrate command for peer and configuration migration between accounts
@@ -57,10 +100,14 @@ func HandleMigrateCommand(args []string, debug bool) error {
 	// Source account flags
 	sourceToken := migrateCmd.String("source-token", "", "API token for the source account")
 	sourceURL := migrateCmd.String("source-url", config.DefaultCloudURL, "Management URL for the source account")
+	sourceHeaders := headerFlag{}
+	migrateCmd.Var(sourceHeaders, "source-extra-header", "Extra 'Name: Value' header for source API requests (repeatable)")
 
 	// Destination account flags
 	destToken := migrateCmd.String("dest-token", "", "API token for the destination account")
 	destURL := migrateCmd.String("dest-url", config.DefaultCloudURL, "Management URL for the destination account")
+	destHeaders := headerFlag{}
+	migrateCmd.Var(destHeaders, "dest-extra-header", "Extra 'Name: Value' header for destination API requests (repeatable)")
 
 	// Peer migration target flags
 	peerID := migrateCmd.String("peer", "", "Peer ID to migrate")
@@ -70,6 +117,11 @@ func HandleMigrateCommand(args []string, debug bool) error {
 	createGroups := migrateCmd.Bool("create-groups", true, "Create missing groups in destination")
 	keyExpiry := migrateCmd.String("key-expiry", "24h", "Setup key expiration duration (e.g., 1h, 24h, 7d)")
 	cleanup := migrateCmd.Bool("cleanup", false, "Remove peer from source after generating migration command")
+	stateFile := migrateCmd.String("state-file", "", "Record bulk peer migration progress to this JSON file")
+	resume := migrateCmd.Bool("resume", false, "Resume from --state-file, skipping peers already migrated")
+	includePeers := migrateCmd.String("include-peers", "", "Only migrate these peers (comma-separated IDs or names; --all or --group)")
+	excludePeers := migrateCmd.String("exclude-peers", "", "Skip these peers (comma-separated IDs or names; --all or --group)")
+	peerFilter := migrateCmd.String("peer-filter", "", "Only migrate peers whose name matches this pattern (--all or --group)")
 
 	// Full configuration migration flag
 	migrateConfig := migrateCmd.Bool("config", false, "Migrate configuration (groups, policies, networks, routes, DNS, posture checks)")
@@ -85,10 +137,14 @@ func HandleMigrateCommand(args []string, debug bool) error {
 	migrateSetupKeysOnly := migrateCmd.Bool("setup-keys", false, "Migrate only setup keys")
 
 	// Configuration migration options
-	skipExisting := migrateCmd.Bool("skip-existing", false, "Skip resources that already exist in destination")
-	update := migrateCmd.Bool("update", false, "Update existing resources in destination")
+	onConflict := migrateCmd.String("on-conflict", "", "How to handle resources that exist in destination: fail, update, skip, or upsert (default: fail)")
+	skipExisting := migrateCmd.Bool("skip-existing", false, "Deprecated: use --on-conflict skip")
+	update := migrateCmd.Bool("update", false, "Deprecated: use --on-conflict update")
 	dryRun := migrateCmd.Bool("dry-run", false, "Preview changes without applying them")
 	verbose := migrateCmd.Bool("verbose", false, "Show detailed output")
+	progress := migrateCmd.Bool("progress", false, "Show a compact progress indicator during configuration migration (TTY only)")
+	summaryOnly := migrateCmd.Bool("summary-only", false, "Hide per-resource lines during configuration migration and print only the summary and errors")
+	summaryJSON := migrateCmd.String("summary-json", "", "Write a JSON summary of the configuration migration to this file")
 
 	if len(args) == 1 {
 		PrintMigrateUsage()
@@ -114,10 +170,36 @@ func HandleMigrateCommand(args []string, debug bool) error {
 
 	isPeerMigration := *peerID != "" || *groupName != ""
 
+	if *resume && *stateFile == "" {
+		return fmt.Errorf("--resume requires --state-file")
+	}
+	if *stateFile != "" && *groupName == "" && !*migrateAll {
+		return fmt.Errorf("--state-file applies to bulk peer migrations (--group or --all)")
+	}
+	selection := peerSelection{
+		Include: helpers.SplitCommaList(*includePeers),
+		Exclude: helpers.SplitCommaList(*excludePeers),
+		Pattern: *peerFilter,
+	}
+	if selection.active() && *groupName == "" && !*migrateAll {
+		return fmt.Errorf("--include-peers, --exclude-peers, and --peer-filter apply to bulk peer migrations (--group or --all)")
+	}
+
 	// If neither config nor peer migration specified, require one
 	if !isConfigMigration && !isPeerMigration {
 		return fmt.Errorf("specify migration type: --config, --all, --peer, --group, or specific resource flags (--groups, --policies, etc.)")
 	}
+	if *summaryJSON != "" && !isConfigMigration {
+		return fmt.Errorf("--summary-json applies to configuration migrations (--config, --all, or resource flags)")
+	}
+	if *summaryOnly && !isConfigMigration {
+		return fmt.Errorf("--summary-only applies to configuration migrations (--config, --all, or resource flags)")
+	}
+
+	conflictMode, err := resolveConflictMode(*onConflict, *update, *skipExisting, false)
+	if err != nil {
+		return err
+	}
 
 	// Determine which resources to migrate for config migration
 	migrateGroups := *migrateConfig || *migrateAll || *migrateGroupsOnly
@@ -148,17 +230,26 @@ func HandleMigrateCommand(args []string, debug bool) error {
 		MigrateDNS:       migrateDNS,
 		MigratePosture:   migratePosture,
 		MigrateSetupKeys: migrateSetupKeys,
-		SkipExisting:     *skipExisting,
-		Update:           *update,
+		SkipExisting:     conflictMode == conflictSkip,
+		Update:           conflictMode == conflictUpdate || conflictMode == conflictUpsert,
 		DryRun:           *dryRun,
 		Verbose:          *verbose,
+		StateFile:        *stateFile,
+		Resume:           *resume,
+		PeerSelection:    selection,
+		Progress:         *progress,
+		SummaryOnly:      *summaryOnly,
+		SummaryJSON:      *summaryJSON,
 	}
 
-	// Create clients for both accounts
+	// Create clients for both accounts. Each side gets only the headers stored for its own URL
+	// plus the ones given for that side, so one account's proxy credentials never reach the other.
 	sourceClient := client.New(opts.SourceToken, opts.SourceURL)
 	sourceClient.Debug = debug
+	sourceClient.ExtraHeaders = sourceHeaders.mergeInto(sourceClient.ExtraHeaders)
 	destClient := client.New(opts.DestToken, opts.DestURL)
 	destClient.Debug = debug
+	destClient.ExtraHeaders = destHeaders.mergeInto(destClient.ExtraHeaders)
 
 	// For --all, migrate peers FIRST, then configuration
 	// This ensures peers exist before migrating config that may reference them
@@ -308,6 +399,15 @@ func migrateGroupPeers(sourceClient, destClient *client.Client, opts MigrateOpti
 
 	fmt.Printf("Found %d peers to migrate.\n\n", len(group.Peers))
 
+	group.Peers, err = selectMigrationPeers(group.Peers, opts.PeerSelection)
+	if err != nil {
+		return err
+	}
+	if len(group.Peers) == 0 {
+		fmt.Println("No peers left to migrate after applying the peer selection.")
+		return nil
+	}
+
 	fmt.Println("Connecting to destination account...")
 	fmt.Printf("  Destination: %s\n\n", opts.DestURL)
 
@@ -350,15 +450,50 @@ func migrateGroupPeers(sourceClient, destClient *client.Client, opts MigrateOpti
 		return fmt.Errorf("invalid key expiry: %v", err)
 	}
 
-	// Create setup keys for each peer
-	type migrationInfo struct {
-		Peer     models.Peer
-		SetupKey string
+	migrations, err := createPeerMigrations(destClient, group.Peers, groupIDMap, expiresIn, opts)
+	if err != nil {
+		return err
+	}
+
+	outputPeerMigrations(migrations, opts)
+
+	// Output config cleanup notice
+	outputConfigCleanupNotice()
+
+	return nil
+}
+
+// peerMigration pairs a source peer with the setup key generated for it
+type peerMigration struct {
+	Peer     models.Peer
+	SetupKey string
+	Resumed  bool
+}
+
+// createPeerMigrations creates a one-off setup key in the destination for each peer.
+// With a state file, each outcome is recorded as it happens and completed peers are skipped on resume.
+func createPeerMigrations(destClient *client.Client, peers []models.Peer, groupIDMap map[string]string, expiresIn int, opts MigrateOptions) ([]peerMigration, error) {
+	var state *MigrationState
+	if opts.StateFile != "" {
+		var err error
+		state, err = openMigrationState(opts.StateFile, opts.Resume, opts)
+		if err != nil {
+			return nil, err
+		}
 	}
-	var migrations []migrationInfo
 
-	for i, peer := range group.Peers {
-		fmt.Printf("Peer %d/%d: %s\n", i+1, len(group.Peers), peer.Name)
+	var migrations []peerMigration
+	skipped, failed := 0, 0
+
+	for i, peer := range peers {
+		fmt.Printf("Peer %d/%d: %s\n", i+1, len(peers), peer.Name)
+
+		if entry, ok := state.completed(peer.ID); ok {
+			fmt.Printf("  Already migrated (key %s), skipping\n", entry.KeyName)
+			migrations = append(migrations, peerMigration{Peer: peer, SetupKey: entry.SetupKey, Resumed: true})
+			skipped++
+			continue
+		}
 
 		// Get auto-groups for this peer (excluding "All" group)
 		var autoGroupIDs []string
@@ -376,17 +511,46 @@ func migrateGroupPeers(sourceClient, destClient *client.Client, opts MigrateOpti
 		setupKey, err := createMigrationSetupKey(destClient, keyName, autoGroupIDs, expiresIn)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "  Failed to create setup key: %v\n", err)
+			failed++
+			if err := state.record(peer.ID, MigrationPeerState{
+				Name:   peer.Name,
+				IP:     peer.IP,
+				Status: migrationStatusFailed,
+				Error:  err.Error(),
+			}); err != nil {
+				return nil, err
+			}
 			continue
 		}
 		fmt.Printf("  Creating setup key... Done\n")
 
-		migrations = append(migrations, migrationInfo{
-			Peer:     peer,
+		// Record before moving on so an interruption can't lose a created key
+		if err := state.record(peer.ID, MigrationPeerState{
+			Name:     peer.Name,
+			IP:       peer.IP,
+			Status:   migrationStatusCompleted,
+			KeyName:  keyName,
 			SetupKey: setupKey.Key,
-		})
+		}); err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, peerMigration{Peer: peer, SetupKey: setupKey.Key})
+	}
+
+	if state != nil {
+		fmt.Printf("\nState saved to %s (%d resumed, %d created, %d failed)\n",
+			opts.StateFile, skipped, len(migrations)-skipped, failed)
+		if failed > 0 {
+			fmt.Println("Re-run with --resume to retry failed peers.")
+		}
 	}
 
-	// Output all migration commands
+	return migrations, nil
+}
+
+// outputPeerMigrations prints the migration command for every peer
+func outputPeerMigrations(migrations []peerMigration, opts MigrateOptions) {
 	fmt.Println()
 	fmt.Println(strings.Repeat("=", 72))
 	fmt.Println("MIGRATION COMMANDS - Run on each peer:")
@@ -394,46 +558,33 @@ func migrateGroupPeers(sourceClient, destClient *client.Client, opts MigrateOpti
 	fmt.Println()
 
 	for _, m := range migrations {
-		fmt.Printf("# %s (%s)\n", m.Peer.Name, m.Peer.IP)
+		if m.Resumed {
+			fmt.Printf("# %s (%s) - from previous run\n", m.Peer.Name, m.Peer.IP)
+		} else {
+			fmt.Printf("# %s (%s)\n", m.Peer.Name, m.Peer.IP)
+		}
 		outputMigrationCommandInline(m.Peer, m.SetupKey, opts.DestURL)
 		fmt.Println()
 	}
 
 	fmt.Println(strings.Repeat("=", 72))
-
-	// Output config cleanup notice
-	outputConfigCleanupNotice()
-
-	return nil
 }
 
 // getPeerByID fetches a peer by ID from the given client
 func getPeerByID(c *client.Client, peerID string) (*models.Peer, error) {
-	resp, err := c.MakeRequest("GET", "/peers/"+peerID, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var peer models.Peer
-	if err := json.NewDecoder(resp.Body).Decode(&peer); err != nil {
-		return nil, fmt.Errorf("failed to decode peer: %v", err)
+	if err := c.GetJSON("/peers/"+peerID, &peer); err != nil {
+		return nil, err
 	}
 	return &peer, nil
 }
 
 // getGroupByName finds a group by name from the source account
 func getGroupByName(c *client.Client, name string) (*models.GroupDetail, error) {
-	resp, err := c.MakeRequest("GET", "/groups", nil)
+	groups, err := client.GetList[models.GroupDetail](c, "/groups")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var groups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-		return nil, fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	for _, g := range groups {
 		if strings.EqualFold(g.Name, name) {
@@ -447,15 +598,9 @@ func getGroupByName(c *client.Client, name string) (*models.GroupDetail, error)
 
 // getGroupByID fetches full group details
 func getGroupByID(c *client.Client, groupID string) (*models.GroupDetail, error) {
-	resp, err := c.MakeRequest("GET", "/groups/"+groupID, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var group models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
-		return nil, fmt.Errorf("failed to decode group: %v", err)
+	if err := c.GetJSON("/groups/"+groupID, &group); err != nil {
+		return nil, err
 	}
 	return &group, nil
 }
@@ -473,16 +618,10 @@ func validateConnection(c *client.Client) error {
 // resolveOrCreateGroups resolves group names to IDs, creating missing groups
 func resolveOrCreateGroups(c *client.Client, groupNames []string) ([]string, []string, error) {
 	// Get existing groups from destination
-	resp, err := c.MakeRequest("GET", "/groups", nil)
+	existingGroups, err := client.GetList[models.GroupDetail](c, "/groups")
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	var existingGroups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&existingGroups); err != nil {
-		return nil, nil, fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	// Build map of existing groups
 	existingMap := make(map[string]string) // name -> id
@@ -514,16 +653,10 @@ func resolveOrCreateGroups(c *client.Client, groupNames []string) ([]string, []s
 // resolveOrCreateGroupsMap returns a map of name -> ID for all groups
 func resolveOrCreateGroupsMap(c *client.Client, groupNames []string) (map[string]string, []string, error) {
 	// Get existing groups from destination
-	resp, err := c.MakeRequest("GET", "/groups", nil)
+	existingGroups, err := client.GetList[models.GroupDetail](c, "/groups")
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	var existingGroups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&existingGroups); err != nil {
-		return nil, nil, fmt.Errorf("failed to decode groups: %v", err)
-	}
 
 	// Build map of existing groups
 	existingMap := make(map[string]string) // lowercase name -> id
@@ -638,6 +771,24 @@ func displaySourcePeer(peer *models.Peer) {
 	fmt.Println()
 }
 
+// clientManagementURL converts an API URL into the management URL the netbird client expects.
+// The client takes scheme://host[:port], so the API path (normally /api) is dropped and
+// non-standard ports are kept. A URL without a scheme is treated as https.
+func clientManagementURL(apiURL string) string {
+	raw := strings.TrimSpace(apiURL)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		// Fall back to plain suffix trimming for URLs we can't parse
+		return strings.TrimSuffix(strings.TrimSuffix(apiURL, "/"), "/api")
+	}
+
+	return parsed.Scheme + "://" + parsed.Host
+}
+
 // outputMigrationCommand outputs the migration command for a peer
 func outputMigrationCommand(peer *models.Peer, setupKey, destURL string) {
 	fmt.Println()
@@ -651,9 +802,7 @@ func outputMigrationCommand(peer *models.Peer, setupKey, destURL string) {
 		setupKey, peer.Hostname)
 
 	// Add management URL if not the default cloud
-	if destURL != config.DefaultCloudURL {
-		// Extract management URL (remove /api suffix for netbird client)
-		mgmtURL := strings.TrimSuffix(destURL, "/api")
+	if mgmtURL := clientManagementURL(destURL); mgmtURL != clientManagementURL(config.DefaultCloudURL) {
 		cmd += fmt.Sprintf(" \\\n    --management-url %s", mgmtURL)
 	}
 
@@ -667,8 +816,7 @@ func outputMigrationCommandInline(peer models.Peer, setupKey, destURL string) {
 	cmd := fmt.Sprintf("sudo netbird down && sudo netbird up --setup-key %s --hostname %s",
 		setupKey, peer.Hostname)
 
-	if destURL != config.DefaultCloudURL {
-		mgmtURL := strings.TrimSuffix(destURL, "/api")
+	if mgmtURL := clientManagementURL(destURL); mgmtURL != clientManagementURL(config.DefaultCloudURL) {
 		cmd += fmt.Sprintf(" --management-url %s", mgmtURL)
 	}
 
@@ -770,6 +918,13 @@ type MigrateContext struct {
 	GroupNameToDestID   map[string]string
 	PostureNameToDestID map[string]string
 
+	// Policies whose rules reference groups that will not exist in the destination
+	// (policy name -> missing group names), found by checkPolicyGroupReferences
+	PolicyMissingGroups map[string][]string
+
+	// Progress indicator (--progress); nil when disabled or stdout isn't a terminal
+	Progress *helpers.Progress
+
 	// Results
 	Created []string
 	Updated []string
@@ -778,7 +933,7 @@ type MigrateContext struct {
 }
 
 // migrateConfiguration handles full configuration migration between accounts
-func migrateConfiguration(sourceClient, destClient *client.Client, opts MigrateOptions) error {
+func migrateConfiguration(sourceClient, destClient *client.Client, opts MigrateOptions) (err error) {
 	ctx := &MigrateContext{
 		SourceClient:        sourceClient,
 		DestClient:          destClient,
@@ -794,6 +949,15 @@ func migrateConfiguration(sourceClient, destClient *client.Client, opts MigrateO
 		PostureNameToDestID: make(map[string]string),
 	}
 
+	// Write the JSON summary even when the run stops early, so CI sees why
+	if opts.SummaryJSON != "" {
+		defer func() {
+			if writeErr := ctx.writeSummaryJSON(opts.SummaryJSON, err); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}()
+	}
+
 	if opts.DryRun {
 		fmt.Println("Configuration Migration Preview (Dry Run)")
 		fmt.Println("==========================================")
@@ -817,6 +981,19 @@ func migrateConfiguration(sourceClient, destClient *client.Client, opts MigrateO
 	// Check for peer dependencies and warn if needed
 	ctx.checkPeerDependencies()
 
+	// Report policies with unresolvable group references before creating anything
+	if opts.MigratePolicies {
+		if err := ctx.checkPolicyGroupReferences(); err != nil {
+			return err
+		}
+	}
+
+	ctx.Progress = helpers.StartProgress(opts.Progress, ctx.countMigrationItems())
+	if ctx.Progress == nil {
+		ctx.Progress = helpers.SuppressOutput(opts.SummaryOnly)
+	}
+	defer ctx.Progress.Finish()
+
 	// Migrate resources in dependency order
 	if opts.MigrateGroups {
 		if err := ctx.migrateGroups(); err != nil {
@@ -861,100 +1038,96 @@ func migrateConfiguration(sourceClient, destClient *client.Client, opts MigrateO
 	}
 
 	// Print summary
+	ctx.Progress.Finish()
 	ctx.printMigrationSummary()
 
 	return nil
 }
 
+// countMigrationItems counts the source resources the selected migrations will process
+func (ctx *MigrateContext) countMigrationItems() int {
+	total := 0
+	if ctx.Opts.MigrateGroups {
+		total += len(ctx.SourceGroups)
+	}
+	if ctx.Opts.MigratePosture {
+		total += len(ctx.SourcePostureChecks)
+	}
+	if ctx.Opts.MigratePolicies {
+		total += len(ctx.SourcePolicies)
+	}
+	if ctx.Opts.MigrateRoutes {
+		total += len(ctx.SourceRoutes)
+	}
+	if ctx.Opts.MigrateDNS {
+		total += len(ctx.SourceDNS)
+	}
+	if ctx.Opts.MigrateNetworks {
+		total += len(ctx.SourceNetworks)
+	}
+	if ctx.Opts.MigrateSetupKeys {
+		total += len(ctx.SourceSetupKeys)
+	}
+	return total
+}
+
 // fetchSourceState fetches all resources from the source account
 func (ctx *MigrateContext) fetchSourceState() error {
 	var err error
 
 	// Fetch peers first (needed for dependency checks)
-	resp, err := ctx.SourceClient.MakeRequest("GET", "/peers", nil)
+	ctx.SourcePeers, err = client.GetList[models.Peer](ctx.SourceClient, "/peers")
 	if err != nil {
 		return fmt.Errorf("failed to fetch peers: %v", err)
 	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&ctx.SourcePeers); err != nil {
-		return fmt.Errorf("failed to decode peers: %v", err)
-	}
 
 	if ctx.Opts.MigrateGroups || ctx.Opts.MigratePolicies || ctx.Opts.MigrateNetworks || ctx.Opts.MigrateRoutes || ctx.Opts.MigrateDNS {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/groups", nil)
+		ctx.SourceGroups, err = client.GetList[models.GroupDetail](ctx.SourceClient, "/groups")
 		if err != nil {
 			return fmt.Errorf("failed to fetch groups: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourceGroups); err != nil {
-			return fmt.Errorf("failed to decode groups: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigratePolicies {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/policies", nil)
+		ctx.SourcePolicies, err = client.GetList[models.Policy](ctx.SourceClient, "/policies")
 		if err != nil {
 			return fmt.Errorf("failed to fetch policies: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourcePolicies); err != nil {
-			return fmt.Errorf("failed to decode policies: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigrateNetworks {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/networks", nil)
+		ctx.SourceNetworks, err = client.GetList[models.Network](ctx.SourceClient, "/networks")
 		if err != nil {
 			return fmt.Errorf("failed to fetch networks: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourceNetworks); err != nil {
-			return fmt.Errorf("failed to decode networks: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigrateRoutes {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/routes", nil)
+		ctx.SourceRoutes, err = client.GetList[models.Route](ctx.SourceClient, "/routes")
 		if err != nil {
 			return fmt.Errorf("failed to fetch routes: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourceRoutes); err != nil {
-			return fmt.Errorf("failed to decode routes: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigrateDNS {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/dns/nameservers", nil)
+		ctx.SourceDNS, err = client.GetList[models.DNSNameserverGroup](ctx.SourceClient, "/dns/nameservers")
 		if err != nil {
 			return fmt.Errorf("failed to fetch DNS: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourceDNS); err != nil {
-			return fmt.Errorf("failed to decode DNS: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigratePosture || ctx.Opts.MigratePolicies {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/posture-checks", nil)
+		ctx.SourcePostureChecks, err = client.GetList[models.PostureCheck](ctx.SourceClient, "/posture-checks")
 		if err != nil {
 			return fmt.Errorf("failed to fetch posture checks: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourcePostureChecks); err != nil {
-			return fmt.Errorf("failed to decode posture checks: %v", err)
-		}
 	}
 
 	if ctx.Opts.MigrateSetupKeys {
-		resp, err := ctx.SourceClient.MakeRequest("GET", "/setup-keys", nil)
+		ctx.SourceSetupKeys, err = client.GetList[models.SetupKey](ctx.SourceClient, "/setup-keys")
 		if err != nil {
 			return fmt.Errorf("failed to fetch setup keys: %v", err)
 		}
-		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(&ctx.SourceSetupKeys); err != nil {
-			return fmt.Errorf("failed to decode setup keys: %v", err)
-		}
 	}
 
 	if ctx.Opts.Verbose {
@@ -970,30 +1143,20 @@ func (ctx *MigrateContext) fetchSourceState() error {
 // fetchDestState fetches all resources from the destination account
 func (ctx *MigrateContext) fetchDestState() error {
 	// Fetch destination peers
-	resp, err := ctx.DestClient.MakeRequest("GET", "/peers", nil)
+	destPeers, err := client.GetList[models.Peer](ctx.DestClient, "/peers")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination peers: %v", err)
 	}
-	defer resp.Body.Close()
-	var destPeers []models.Peer
-	if err := json.NewDecoder(resp.Body).Decode(&destPeers); err != nil {
-		return fmt.Errorf("failed to decode destination peers: %v", err)
-	}
 	for _, peer := range destPeers {
 		peerCopy := peer
 		ctx.DestPeers[peer.Name] = &peerCopy
 	}
 
 	// Fetch destination groups
-	resp, err = ctx.DestClient.MakeRequest("GET", "/groups", nil)
+	destGroups, err := client.GetList[models.GroupDetail](ctx.DestClient, "/groups")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination groups: %v", err)
 	}
-	defer resp.Body.Close()
-	var destGroups []models.GroupDetail
-	if err := json.NewDecoder(resp.Body).Decode(&destGroups); err != nil {
-		return fmt.Errorf("failed to decode destination groups: %v", err)
-	}
 	for _, group := range destGroups {
 		groupCopy := group
 		ctx.DestGroups[group.Name] = &groupCopy
@@ -1001,60 +1164,40 @@ func (ctx *MigrateContext) fetchDestState() error {
 	}
 
 	// Fetch destination policies
-	resp, err = ctx.DestClient.MakeRequest("GET", "/policies", nil)
+	destPolicies, err := client.GetList[models.Policy](ctx.DestClient, "/policies")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination policies: %v", err)
 	}
-	defer resp.Body.Close()
-	var destPolicies []models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&destPolicies); err != nil {
-		return fmt.Errorf("failed to decode destination policies: %v", err)
-	}
 	for _, policy := range destPolicies {
 		policyCopy := policy
 		ctx.DestPolicies[policy.Name] = &policyCopy
 	}
 
 	// Fetch destination networks
-	resp, err = ctx.DestClient.MakeRequest("GET", "/networks", nil)
+	destNetworks, err := client.GetList[models.Network](ctx.DestClient, "/networks")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination networks: %v", err)
 	}
-	defer resp.Body.Close()
-	var destNetworks []models.Network
-	if err := json.NewDecoder(resp.Body).Decode(&destNetworks); err != nil {
-		return fmt.Errorf("failed to decode destination networks: %v", err)
-	}
 	for _, network := range destNetworks {
 		networkCopy := network
 		ctx.DestNetworks[network.Name] = &networkCopy
 	}
 
 	// Fetch destination DNS
-	resp, err = ctx.DestClient.MakeRequest("GET", "/dns/nameservers", nil)
+	destDNS, err := client.GetList[models.DNSNameserverGroup](ctx.DestClient, "/dns/nameservers")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination DNS: %v", err)
 	}
-	defer resp.Body.Close()
-	var destDNS []models.DNSNameserverGroup
-	if err := json.NewDecoder(resp.Body).Decode(&destDNS); err != nil {
-		return fmt.Errorf("failed to decode destination DNS: %v", err)
-	}
 	for _, dns := range destDNS {
 		dnsCopy := dns
 		ctx.DestDNS[dns.Name] = &dnsCopy
 	}
 
 	// Fetch destination posture checks
-	resp, err = ctx.DestClient.MakeRequest("GET", "/posture-checks", nil)
+	destPosture, err := client.GetList[models.PostureCheck](ctx.DestClient, "/posture-checks")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination posture checks: %v", err)
 	}
-	defer resp.Body.Close()
-	var destPosture []models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&destPosture); err != nil {
-		return fmt.Errorf("failed to decode destination posture checks: %v", err)
-	}
 	for _, check := range destPosture {
 		checkCopy := check
 		ctx.DestPostureChecks[check.Name] = &checkCopy
@@ -1062,15 +1205,10 @@ func (ctx *MigrateContext) fetchDestState() error {
 	}
 
 	// Fetch destination setup keys
-	resp, err = ctx.DestClient.MakeRequest("GET", "/setup-keys", nil)
+	destSetupKeys, err := client.GetList[models.SetupKey](ctx.DestClient, "/setup-keys")
 	if err != nil {
 		return fmt.Errorf("failed to fetch destination setup keys: %v", err)
 	}
-	defer resp.Body.Close()
-	var destSetupKeys []models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&destSetupKeys); err != nil {
-		return fmt.Errorf("failed to decode destination setup keys: %v", err)
-	}
 	for _, key := range destSetupKeys {
 		keyCopy := key
 		ctx.DestSetupKeys[key.Name] = &keyCopy
@@ -1138,6 +1276,67 @@ func (ctx *MigrateContext) checkPeerDependencies() {
 	}
 }
 
+// checkPolicyGroupReferences verifies that every group referenced by a policy that will be
+// created or updated either exists in the destination or is scheduled for creation by the
+// group migration. All missing references are reported together; the run is aborted unless
+// --on-conflict skip (skip those policies) or --dry-run (report them as failures) is set.
+func (ctx *MigrateContext) checkPolicyGroupReferences() error {
+	scheduled := make(map[string]bool)
+	if ctx.Opts.MigrateGroups {
+		for _, group := range ctx.SourceGroups {
+			scheduled[group.Name] = true
+		}
+	}
+
+	ctx.PolicyMissingGroups = make(map[string][]string)
+	var affected []string
+	for _, policy := range ctx.SourcePolicies {
+		// Existing policies that will be skipped or reported as conflicts are never written
+		if _, exists := ctx.DestPolicies[policy.Name]; exists && (ctx.Opts.SkipExisting || !ctx.Opts.Update) {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		var missing []string
+		for _, rule := range policy.Rules {
+			refs := append(append([]models.PolicyGroup{}, rule.Sources...), rule.Destinations...)
+			for _, ref := range refs {
+				if seen[ref.Name] {
+					continue
+				}
+				seen[ref.Name] = true
+				if _, ok := ctx.GroupNameToDestID[ref.Name]; ok || scheduled[ref.Name] {
+					continue
+				}
+				missing = append(missing, ref.Name)
+			}
+		}
+		if len(missing) > 0 {
+			ctx.PolicyMissingGroups[policy.Name] = missing
+			affected = append(affected, policy.Name)
+		}
+	}
+
+	if len(affected) == 0 {
+		return nil
+	}
+
+	fmt.Println("⚠️  WARNING: Some policies reference groups missing in the destination")
+	fmt.Println("================================================")
+	for _, name := range affected {
+		fmt.Printf("  - Policy '%s': %s\n", name, strings.Join(ctx.PolicyMissingGroups[name], ", "))
+	}
+	fmt.Println()
+	fmt.Println("Create these groups in the destination or add --groups to migrate them.")
+	fmt.Println("================================================")
+	fmt.Println()
+
+	if ctx.Opts.SkipExisting || ctx.Opts.DryRun {
+		return nil
+	}
+	return fmt.Errorf("%d policies reference missing groups; nothing was migrated (use --on-conflict skip to skip these policies)", len(affected))
+}
+
 // migrateGroups migrates groups from source to destination
 func (ctx *MigrateContext) migrateGroups() error {
 	if len(ctx.SourceGroups) == 0 {
@@ -1145,8 +1344,10 @@ func (ctx *MigrateContext) migrateGroups() error {
 	}
 
 	fmt.Println("Groups:")
+	ctx.Progress.Stage("groups")
 
 	for _, group := range ctx.SourceGroups {
+		ctx.Progress.Step()
 		// Skip the "All" group - it's a system group that already exists and can't be modified
 		if isAllGroup(group.Name) {
 			fmt.Printf("  SKIP     %s (system group)\n", group.Name)
@@ -1162,7 +1363,7 @@ func (ctx *MigrateContext) migrateGroups() error {
 				continue
 			}
 			if !ctx.Opts.Update {
-				fmt.Printf("  CONFLICT %s (already exists, use --update or --skip-existing)\n", group.Name)
+				fmt.Printf("  CONFLICT %s (already exists, use --on-conflict update or --on-conflict skip)\n", group.Name)
 				ctx.Failed = append(ctx.Failed, "Group "+group.Name+": already exists")
 				continue
 			}
@@ -1265,8 +1466,10 @@ func (ctx *MigrateContext) migratePostureChecks() error {
 	}
 
 	fmt.Println("Posture Checks:")
+	ctx.Progress.Stage("posture checks")
 
 	for _, check := range ctx.SourcePostureChecks {
+		ctx.Progress.Step()
 		if existing, exists := ctx.DestPostureChecks[check.Name]; exists {
 			if ctx.Opts.SkipExisting {
 				fmt.Printf("  SKIP     %s (already exists)\n", check.Name)
@@ -1370,8 +1573,22 @@ func (ctx *MigrateContext) migratePolicies() error {
 	}
 
 	fmt.Println("Policies:")
+	ctx.Progress.Stage("policies")
 
 	for _, policy := range ctx.SourcePolicies {
+		ctx.Progress.Step()
+		if missing, ok := ctx.PolicyMissingGroups[policy.Name]; ok {
+			reason := "missing groups: " + strings.Join(missing, ", ")
+			if ctx.Opts.SkipExisting {
+				fmt.Printf("  SKIP     %s (%s)\n", policy.Name, reason)
+				ctx.Skipped = append(ctx.Skipped, "Policy "+policy.Name+": "+reason)
+			} else {
+				fmt.Printf("  FAILED   %s (%s)\n", policy.Name, reason)
+				ctx.Failed = append(ctx.Failed, "Policy "+policy.Name+": "+reason)
+			}
+			continue
+		}
+
 		if _, exists := ctx.DestPolicies[policy.Name]; exists {
 			if ctx.Opts.SkipExisting {
 				fmt.Printf("  SKIP     %s (already exists)\n", policy.Name)
@@ -1549,8 +1766,10 @@ func (ctx *MigrateContext) migrateRoutes() error {
 	}
 
 	fmt.Println("Routes:")
+	ctx.Progress.Stage("routes")
 
 	for _, route := range ctx.SourceRoutes {
+		ctx.Progress.Step()
 		routeName := route.Description
 		if routeName == "" {
 			routeName = route.Network
@@ -1582,44 +1801,9 @@ func (ctx *MigrateContext) migrateRoutes() error {
 
 // createRoute creates a route in the destination
 func (ctx *MigrateContext) createRoute(route models.Route) error {
-	// Resolve group IDs
-	var groupIDs []string
-	for _, groupID := range route.Groups {
-		// Find group by ID in source, get name, then get dest ID
-		for _, g := range ctx.SourceGroups {
-			if g.ID == groupID {
-				if destID, ok := ctx.GroupNameToDestID[g.Name]; ok {
-					groupIDs = append(groupIDs, destID)
-				}
-				break
-			}
-		}
-	}
-
-	// Resolve peer group IDs
-	var peerGroupIDs []string
-	for _, pgID := range route.PeerGroups {
-		for _, g := range ctx.SourceGroups {
-			if g.ID == pgID {
-				if destID, ok := ctx.GroupNameToDestID[g.Name]; ok {
-					peerGroupIDs = append(peerGroupIDs, destID)
-				}
-				break
-			}
-		}
-	}
-
-	reqBody := models.RouteRequest{
-		Description: route.Description,
-		NetworkID:   route.NetworkID,
-		Network:     route.Network,
-		Domains:     route.Domains,
-		PeerGroups:  peerGroupIDs,
-		Metric:      route.Metric,
-		Masquerade:  route.Masquerade,
-		Enabled:     route.Enabled,
-		Groups:      groupIDs,
-		KeepRoute:   route.KeepRoute,
+	reqBody, err := ctx.routeRequestFor(route)
+	if err != nil {
+		return err
 	}
 
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -1636,6 +1820,57 @@ func (ctx *MigrateContext) createRoute(route models.Route) error {
 	return nil
 }
 
+// routeRequestFor builds the destination request for a source route, mapping its groups by
+// name. Every access control group must map: dropping one would widen who can use the route,
+// so the route is refused instead of being created less restricted.
+func (ctx *MigrateContext) routeRequestFor(route models.Route) (models.RouteRequest, error) {
+	groupIDs, _ := ctx.resolveSourceGroupIDs(route.Groups)
+	peerGroupIDs, _ := ctx.resolveSourceGroupIDs(route.PeerGroups)
+
+	aclGroupIDs, unresolved := ctx.resolveSourceGroupIDs(route.AccessControlGroups)
+	if len(unresolved) > 0 {
+		return models.RouteRequest{}, fmt.Errorf("access control group(s) %s not found in destination; migrate the groups first (creating the route without them would widen access)",
+			strings.Join(unresolved, ", "))
+	}
+
+	return models.RouteRequest{
+		Description:         route.Description,
+		NetworkID:           route.NetworkID,
+		Network:             route.Network,
+		Domains:             route.Domains,
+		PeerGroups:          peerGroupIDs,
+		Metric:              route.Metric,
+		Masquerade:          route.Masquerade,
+		Enabled:             route.Enabled,
+		Groups:              groupIDs,
+		AccessControlGroups: aclGroupIDs,
+		KeepRoute:           route.KeepRoute,
+	}, nil
+}
+
+// resolveSourceGroupIDs maps source group IDs to destination group IDs by group name.
+// IDs that cannot be mapped are returned separately (by name when known).
+func (ctx *MigrateContext) resolveSourceGroupIDs(sourceIDs []string) (resolved, unresolved []string) {
+	for _, sourceID := range sourceIDs {
+		name := ""
+		for _, g := range ctx.SourceGroups {
+			if g.ID == sourceID {
+				name = g.Name
+				break
+			}
+		}
+		if destID, ok := ctx.GroupNameToDestID[name]; ok && name != "" {
+			resolved = append(resolved, destID)
+			continue
+		}
+		if name == "" {
+			name = sourceID
+		}
+		unresolved = append(unresolved, name)
+	}
+	return resolved, unresolved
+}
+
 // migrateDNS migrates DNS nameserver groups from source to destination
 func (ctx *MigrateContext) migrateDNS() error {
 	if len(ctx.SourceDNS) == 0 {
@@ -1643,8 +1878,10 @@ func (ctx *MigrateContext) migrateDNS() error {
 	}
 
 	fmt.Println("DNS Nameserver Groups:")
+	ctx.Progress.Stage("DNS")
 
 	for _, dns := range ctx.SourceDNS {
+		ctx.Progress.Step()
 		if _, exists := ctx.DestDNS[dns.Name]; exists {
 			if ctx.Opts.SkipExisting {
 				fmt.Printf("  SKIP     %s (already exists)\n", dns.Name)
@@ -1776,8 +2013,10 @@ func (ctx *MigrateContext) migrateNetworks() error {
 	}
 
 	fmt.Println("Networks:")
+	ctx.Progress.Stage("networks")
 
 	for _, network := range ctx.SourceNetworks {
+		ctx.Progress.Step()
 		if _, exists := ctx.DestNetworks[network.Name]; exists {
 			if ctx.Opts.SkipExisting {
 				fmt.Printf("  SKIP     %s (already exists)\n", network.Name)
@@ -1872,8 +2111,10 @@ func (ctx *MigrateContext) migrateSetupKeys() error {
 	}
 
 	fmt.Println("Setup Keys:")
+	ctx.Progress.Stage("setup keys")
 
 	for _, key := range ctx.SourceSetupKeys {
+		ctx.Progress.Step()
 		if _, exists := ctx.DestSetupKeys[key.Name]; exists {
 			if ctx.Opts.SkipExisting {
 				fmt.Printf("  SKIP     %s (already exists)\n", key.Name)
@@ -1993,7 +2234,7 @@ func (ctx *MigrateContext) printMigrationSummary() {
 			fmt.Printf("Successfully migrated %d resources!\n", totalChanges)
 		}
 		if len(ctx.Failed) > 0 {
-			fmt.Println("Some resources failed to migrate. Fix errors and re-run with --skip-existing")
+			fmt.Println("Some resources failed to migrate. Fix errors and re-run with --on-conflict skip")
 		}
 	}
 }
@@ -2006,16 +2247,10 @@ func migrateAllPeers(sourceClient, destClient *client.Client, opts MigrateOption
 	fmt.Println()
 
 	// Fetch all peers from source
-	resp, err := sourceClient.MakeRequest("GET", "/peers", nil)
+	peers, err := client.GetList[models.Peer](sourceClient, "/peers")
 	if err != nil {
 		return fmt.Errorf("failed to fetch peers: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var peers []models.Peer
-	if err := json.NewDecoder(resp.Body).Decode(&peers); err != nil {
-		return fmt.Errorf("failed to decode peers: %v", err)
-	}
 
 	if len(peers) == 0 {
 		fmt.Println("No peers found in source account.")
@@ -2024,6 +2259,15 @@ func migrateAllPeers(sourceClient, destClient *client.Client, opts MigrateOption
 
 	fmt.Printf("Found %d peers to migrate.\n\n", len(peers))
 
+	peers, err = selectMigrationPeers(peers, opts.PeerSelection)
+	if err != nil {
+		return err
+	}
+	if len(peers) == 0 {
+		fmt.Println("No peers left to migrate after applying the peer selection.")
+		return nil
+	}
+
 	// Validate destination connection
 	if err := validateConnection(destClient); err != nil {
 		return fmt.Errorf("failed to connect to destination: %v", err)
@@ -2063,56 +2307,12 @@ func migrateAllPeers(sourceClient, destClient *client.Client, opts MigrateOption
 		return fmt.Errorf("invalid key expiry: %v", err)
 	}
 
-	// Create setup keys for each peer
-	type migrationInfo struct {
-		Peer     models.Peer
-		SetupKey string
-	}
-	var migrations []migrationInfo
-
-	for i, peer := range peers {
-		fmt.Printf("Peer %d/%d: %s\n", i+1, len(peers), peer.Name)
-
-		// Get auto-groups for this peer (excluding "All" group)
-		var autoGroupIDs []string
-		if groupIDMap != nil {
-			for _, g := range peer.Groups {
-				if !isAllGroup(g.Name) {
-					if id, ok := groupIDMap[g.Name]; ok {
-						autoGroupIDs = append(autoGroupIDs, id)
-					}
-				}
-			}
-		}
-
-		keyName := fmt.Sprintf("migrate-%s-%s", peer.Name, time.Now().Format("20060102"))
-		setupKey, err := createMigrationSetupKey(destClient, keyName, autoGroupIDs, expiresIn)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "  Failed to create setup key: %v\n", err)
-			continue
-		}
-		fmt.Printf("  Creating setup key... Done\n")
-
-		migrations = append(migrations, migrationInfo{
-			Peer:     peer,
-			SetupKey: setupKey.Key,
-		})
-	}
-
-	// Output all migration commands
-	fmt.Println()
-	fmt.Println(strings.Repeat("=", 72))
-	fmt.Println("MIGRATION COMMANDS - Run on each peer:")
-	fmt.Println(strings.Repeat("=", 72))
-	fmt.Println()
-
-	for _, m := range migrations {
-		fmt.Printf("# %s (%s)\n", m.Peer.Name, m.Peer.IP)
-		outputMigrationCommandInline(m.Peer, m.SetupKey, opts.DestURL)
-		fmt.Println()
+	migrations, err := createPeerMigrations(destClient, peers, groupIDMap, expiresIn, opts)
+	if err != nil {
+		return err
 	}
 
-	fmt.Println(strings.Repeat("=", 72))
+	outputPeerMigrations(migrations, opts)
 
 	// Output config cleanup notice
 	outputConfigCleanupNotice()
@@ -2153,16 +2353,27 @@ func PrintMigrateUsage() {
 	fmt.Println("    --setup-keys               Migrate setup keys (not included in --config or --all)")
 	fmt.Println()
 	fmt.Println("Configuration Options:")
-	fmt.Println("  --skip-existing              Skip resources that already exist in destination")
-	fmt.Println("  --update                     Update existing resources in destination")
+	fmt.Println("  --on-conflict <mode>         Resources that exist in destination: fail (default),")
+	fmt.Println("                               update, skip, or upsert")
+	fmt.Println("  --skip-existing, --update    Deprecated aliases for --on-conflict skip / update")
 	fmt.Println("  --dry-run                    Preview changes without applying them")
 	fmt.Println("  --verbose                    Show detailed output")
+	fmt.Println("  --progress                   Show a compact progress indicator (TTY only)")
+	fmt.Println("  --summary-only               Hide per-resource lines; print only the summary and errors")
+	fmt.Println("  --summary-json <file>        Write created/updated/skipped/failed resources as JSON")
 	fmt.Println()
 	fmt.Println("Peer Migration Options:")
 	fmt.Println("  --source-url <url>           Source management URL (default: NetBird Cloud)")
 	fmt.Println("  --dest-url <url>             Destination management URL (default: NetBird Cloud)")
+	fmt.Println("  --source-extra-header 'N: V' Extra header for source requests, e.g. proxy credentials (repeatable)")
+	fmt.Println("  --dest-extra-header 'N: V'   Extra header for destination requests (repeatable)")
 	fmt.Println("  --create-groups              Create missing groups in destination (default: true)")
 	fmt.Println("  --key-expiry <duration>      Setup key expiration: 1h, 24h, 7d (default: 24h)")
+	fmt.Println("  --state-file <path>          Record per-peer progress and setup keys (--group, --all)")
+	fmt.Println("  --resume                     Continue from --state-file, skipping completed peers")
+	fmt.Println("  --include-peers <ids/names>  Only migrate these peers (--group, --all)")
+	fmt.Println("  --exclude-peers <ids/names>  Skip these peers, e.g. decommissioned hosts (--group, --all)")
+	fmt.Println("  --peer-filter <pattern>      Only migrate peers whose name matches (wildcards; --group, --all)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println()
@@ -2176,7 +2387,7 @@ func PrintMigrateUsage() {
 	fmt.Println("  netbird-manage migrate \\")
 	fmt.Println("    --source-token \"nbp_source...\" \\")
 	fmt.Println("    --dest-token \"nbp_dest...\" \\")
-	fmt.Println("    --config --skip-existing")
+	fmt.Println("    --config --on-conflict skip")
 	fmt.Println()
 	fmt.Println("  # Migrate everything (config + all peers):")
 	fmt.Println("  netbird-manage migrate \\")
@@ -2188,7 +2399,7 @@ func PrintMigrateUsage() {
 	fmt.Println("  netbird-manage migrate \\")
 	fmt.Println("    --source-token \"nbp_source...\" \\")
 	fmt.Println("    --dest-token \"nbp_dest...\" \\")
-	fmt.Println("    --groups --policies --skip-existing")
+	fmt.Println("    --groups --policies --on-conflict skip")
 	fmt.Println()
 	fmt.Println("  # Migrate a single peer:")
 	fmt.Println("  netbird-manage migrate \\")
@@ -0,0 +1,88 @@
+// migrate_peer_select.go - --include-peers / --exclude-peers / --peer-filter for bulk peer migration
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// peerSelection narrows the peers of a bulk migration (--all or --group)
+type peerSelection struct {
+	Include []string // Peer IDs or names to migrate
+	Exclude []string // Peer IDs or names to skip; wins over Include and Pattern
+	Pattern string   // Peer name pattern to migrate (wildcards supported)
+}
+
+// active reports whether any selection flag was given
+func (sel peerSelection) active() bool {
+	return len(sel.Include) > 0 || len(sel.Exclude) > 0 || sel.Pattern != ""
+}
+
+// peerMatchesAny reports which of identifiers (IDs or names) match the peer
+func peerMatchesAny(peer models.Peer, identifiers []string) (string, bool) {
+	for _, identifier := range identifiers {
+		if peer.ID == identifier || peer.Name == identifier {
+			return identifier, true
+		}
+	}
+	return "", false
+}
+
+// selectMigrationPeers applies the selection to the source peers. With --include-peers or
+// --peer-filter, only peers matching either are kept; --exclude-peers then removes peers.
+// Include entries that match no peer are an error, since a typo would silently drop a host.
+func selectMigrationPeers(peers []models.Peer, sel peerSelection) ([]models.Peer, error) {
+	if !sel.active() {
+		return peers, nil
+	}
+
+	matchedInclude := make(map[string]bool)
+	matchedExclude := make(map[string]bool)
+	var selected []models.Peer
+	var excluded []string
+	for _, peer := range peers {
+		if len(sel.Include) > 0 || sel.Pattern != "" {
+			identifier, included := peerMatchesAny(peer, sel.Include)
+			if included {
+				matchedInclude[identifier] = true
+			}
+			if !included && !(sel.Pattern != "" && helpers.MatchesPattern(peer.Name, sel.Pattern)) {
+				continue
+			}
+		}
+		if identifier, skip := peerMatchesAny(peer, sel.Exclude); skip {
+			matchedExclude[identifier] = true
+			excluded = append(excluded, fmt.Sprintf("%s (%s)", peer.Name, peer.ID))
+			continue
+		}
+		selected = append(selected, peer)
+	}
+
+	var unknown []string
+	for _, identifier := range sel.Include {
+		if !matchedInclude[identifier] {
+			unknown = append(unknown, identifier)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("--include-peers: no source peer matches %s", strings.Join(unknown, ", "))
+	}
+	for _, identifier := range sel.Exclude {
+		if !matchedExclude[identifier] {
+			fmt.Printf("Warning: --exclude-peers entry '%s' matches no selected peer\n", identifier)
+		}
+	}
+
+	fmt.Printf("Peer selection: %d of %d peers selected\n", len(selected), len(peers))
+	for _, peer := range selected {
+		fmt.Printf("  + %s (%s)\n", peer.Name, peer.ID)
+	}
+	for _, label := range excluded {
+		fmt.Printf("  - %s (excluded)\n", label)
+	}
+	fmt.Println()
+	return selected, nil
+}
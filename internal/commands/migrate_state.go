@@ -0,0 +1,128 @@
+// migrate_state.go - Resumable state for bulk peer migrations
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Peer migration statuses recorded in the state file
+const (
+	migrationStatusCompleted = "completed"
+	migrationStatusFailed    = "failed"
+)
+
+// MigrationState records bulk peer migration progress, keyed by source peer ID
+type MigrationState struct {
+	SourceURL string                        `json:"source_url"`
+	DestURL   string                        `json:"dest_url"`
+	UpdatedAt string                        `json:"updated_at"`
+	Peers     map[string]MigrationPeerState `json:"peers"`
+
+	path string
+}
+
+// MigrationPeerState is the recorded outcome for a single source peer
+type MigrationPeerState struct {
+	Name      string `json:"name"`
+	IP        string `json:"ip"`
+	Status    string `json:"status"`
+	KeyName   string `json:"key_name,omitempty"`
+	SetupKey  string `json:"setup_key,omitempty"`
+	Error     string `json:"error,omitempty"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// openMigrationState prepares the state file for a migration run.
+// Without resume, an existing file is refused so completed keys are never overwritten;
+// with resume, the file must exist and belong to the same source/destination pair.
+func openMigrationState(path string, resume bool, opts MigrateOptions) (*MigrationState, error) {
+	state := &MigrationState{
+		SourceURL: opts.SourceURL,
+		DestURL:   opts.DestURL,
+		Peers:     make(map[string]MigrationPeerState),
+		path:      path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read state file: %v", err)
+		}
+		if resume {
+			return nil, fmt.Errorf("state file %s does not exist (run without --resume to start a new migration)", path)
+		}
+		// Create the file up front so an unwritable path fails before any keys are created
+		return state, state.save()
+	}
+
+	if !resume {
+		return nil, fmt.Errorf("state file %s already exists (use --resume to continue that migration, or remove the file)", path)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %v", err)
+	}
+	if state.SourceURL != opts.SourceURL || state.DestURL != opts.DestURL {
+		return nil, fmt.Errorf("state file was written for %s -> %s, not %s -> %s",
+			state.SourceURL, state.DestURL, opts.SourceURL, opts.DestURL)
+	}
+	if state.Peers == nil {
+		state.Peers = make(map[string]MigrationPeerState)
+	}
+	state.path = path
+
+	return state, nil
+}
+
+// completed returns the recorded state for a peer that was already migrated
+func (s *MigrationState) completed(peerID string) (MigrationPeerState, bool) {
+	if s == nil {
+		return MigrationPeerState{}, false
+	}
+	entry, ok := s.Peers[peerID]
+	return entry, ok && entry.Status == migrationStatusCompleted
+}
+
+// record stores a peer's outcome and writes the state file immediately
+func (s *MigrationState) record(peerID string, entry MigrationPeerState) error {
+	if s == nil {
+		return nil
+	}
+	entry.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	s.Peers[peerID] = entry
+	return s.save()
+}
+
+// save writes the state atomically so an interruption never leaves a truncated file
+func (s *MigrationState) save() error {
+	s.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %v", err)
+	}
+
+	// The file contains setup keys, so keep it private
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".migrate-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+
+	return nil
+}
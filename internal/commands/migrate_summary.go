@@ -0,0 +1,89 @@
+// migrate_summary.go - Machine-readable configuration migration summary (--summary-json)
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// migrateResourceTypes are the prefixes configuration migration uses when recording results
+var migrateResourceTypes = []string{"Posture Check", "Setup Key", "Group", "Policy", "Route", "DNS", "Network"}
+
+// MigrationSummary is the JSON document written by migrate --summary-json
+type MigrationSummary struct {
+	SourceURL   string                  `json:"source_url"`
+	DestURL     string                  `json:"dest_url"`
+	DryRun      bool                    `json:"dry_run"`
+	CompletedAt string                  `json:"completed_at"`
+	Created     []MigrationSummaryEntry `json:"created"`
+	Updated     []MigrationSummaryEntry `json:"updated"`
+	Skipped     []MigrationSummaryEntry `json:"skipped"`
+	Failed      []MigrationSummaryEntry `json:"failed"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+// MigrationSummaryEntry is one resource outcome; Reason is set for skips and failures when known
+type MigrationSummaryEntry struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// parseMigrationResult splits a recorded result like "Policy web: already exists"
+// into its resource type, name, and reason
+func parseMigrationResult(result string) MigrationSummaryEntry {
+	var entry MigrationSummaryEntry
+	for _, resourceType := range migrateResourceTypes {
+		if strings.HasPrefix(result, resourceType+" ") {
+			entry.Type = resourceType
+			result = strings.TrimPrefix(result, resourceType+" ")
+			break
+		}
+	}
+
+	entry.Name = result
+	if name, reason, ok := strings.Cut(result, ": "); ok {
+		entry.Name = name
+		entry.Reason = reason
+	}
+	return entry
+}
+
+// parseMigrationResults converts a result list, always returning a non-nil slice for stable JSON
+func parseMigrationResults(results []string) []MigrationSummaryEntry {
+	entries := make([]MigrationSummaryEntry, 0, len(results))
+	for _, result := range results {
+		entries = append(entries, parseMigrationResult(result))
+	}
+	return entries
+}
+
+// writeSummaryJSON writes the migration results to path; runErr, if set, is recorded as the
+// reason the run stopped early
+func (ctx *MigrateContext) writeSummaryJSON(path string, runErr error) error {
+	summary := MigrationSummary{
+		SourceURL:   ctx.Opts.SourceURL,
+		DestURL:     ctx.Opts.DestURL,
+		DryRun:      ctx.Opts.DryRun,
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+		Created:     parseMigrationResults(ctx.Created),
+		Updated:     parseMigrationResults(ctx.Updated),
+		Skipped:     parseMigrationResults(ctx.Skipped),
+		Failed:      parseMigrationResults(ctx.Failed),
+	}
+	if runErr != nil {
+		summary.Error = runErr.Error()
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal migration summary: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write migration summary: %v", err)
+	}
+	return nil
+}
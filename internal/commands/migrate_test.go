@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"netbird-manage/internal/models"
+)
+
+// newRouteMigrateContext returns a context whose source groups "devs" and "ops" exist in the
+// destination and whose "contractors" group does not
+func newRouteMigrateContext() *MigrateContext {
+	return &MigrateContext{
+		SourceGroups: []models.GroupDetail{
+			{ID: "src-devs", Name: "devs"},
+			{ID: "src-ops", Name: "ops"},
+			{ID: "src-contractors", Name: "contractors"},
+		},
+		GroupNameToDestID: map[string]string{
+			"devs": "dst-devs",
+			"ops":  "dst-ops",
+		},
+	}
+}
+
+func TestRouteRequestForMapsAccessControlGroups(t *testing.T) {
+	ctx := newRouteMigrateContext()
+	route := models.Route{
+		Description:         "office LAN",
+		NetworkID:           "office",
+		Network:             "10.0.0.0/24",
+		PeerGroups:          []string{"src-ops"},
+		Groups:              []string{"src-devs"},
+		AccessControlGroups: []string{"src-devs", "src-ops"},
+		Metric:              100,
+		Enabled:             true,
+	}
+
+	req, err := ctx.routeRequestFor(route)
+	if err != nil {
+		t.Fatalf("routeRequestFor: unexpected error: %v", err)
+	}
+	if want := []string{"dst-devs", "dst-ops"}; !reflect.DeepEqual(req.AccessControlGroups, want) {
+		t.Errorf("AccessControlGroups = %v, want %v", req.AccessControlGroups, want)
+	}
+	if want := []string{"dst-devs"}; !reflect.DeepEqual(req.Groups, want) {
+		t.Errorf("Groups = %v, want %v", req.Groups, want)
+	}
+	if want := []string{"dst-ops"}; !reflect.DeepEqual(req.PeerGroups, want) {
+		t.Errorf("PeerGroups = %v, want %v", req.PeerGroups, want)
+	}
+}
+
+func TestRouteRequestForRefusesUnresolvedAccessControlGroup(t *testing.T) {
+	ctx := newRouteMigrateContext()
+	route := models.Route{
+		NetworkID:           "office",
+		Network:             "10.0.0.0/24",
+		Groups:              []string{"src-devs"},
+		AccessControlGroups: []string{"src-devs", "src-contractors"},
+	}
+
+	_, err := ctx.routeRequestFor(route)
+	if err == nil {
+		t.Fatal("routeRequestFor: expected an error for an unmapped access control group")
+	}
+	if !strings.Contains(err.Error(), "contractors") {
+		t.Errorf("error %q does not name the missing group", err)
+	}
+}
+
+func TestClientManagementURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiURL string
+		want   string
+	}{
+		{name: "cloud", apiURL: "https://api.netbird.io/api", want: "https://api.netbird.io"},
+		{name: "self-hosted api path", apiURL: "https://netbird.example.com/api/", want: "https://netbird.example.com"},
+		{name: "self-hosted custom port", apiURL: "http://10.0.0.5:33073/api", want: "http://10.0.0.5:33073"},
+		{name: "bare host and port", apiURL: "netbird.example.com:33073", want: "https://netbird.example.com:33073"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientManagementURL(tt.apiURL); got != tt.want {
+				t.Errorf("clientManagementURL(%q) = %q, want %q", tt.apiURL, got, tt.want)
+			}
+		})
+	}
+}
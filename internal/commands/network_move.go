@@ -0,0 +1,149 @@
+// network_move.go - network --move-resource: recreate a resource in another network
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// resolveNetwork finds a network by ID or exact name
+func resolveNetwork(identifier string, networks []models.Network) (*models.Network, error) {
+	var matches []models.Network
+	for _, network := range networks {
+		if network.ID == identifier {
+			return &network, nil
+		}
+		if network.Name == identifier {
+			matches = append(matches, network)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("network '%s' not found", identifier)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d networks are named '%s'; use the network ID", len(matches), identifier)
+	}
+}
+
+// resourcePolicyRefs lists "policy / rule" labels for rules that reference a resource by ID
+func resourcePolicyRefs(policies []models.Policy, resourceID string) []string {
+	var refs []string
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if (rule.SourceResource != nil && rule.SourceResource.ID == resourceID) ||
+				(rule.DestinationResource != nil && rule.DestinationResource.ID == resourceID) {
+				refs = append(refs, fmt.Sprintf("%s / %s", policy.Name, rule.Name))
+			}
+		}
+	}
+	return refs
+}
+
+// moveNetworkResource implements "network --move-resource --from <net> --to <net> --resource-id <id>".
+// The resource is created in the target network first and the original is deleted only
+// after the copy exists, so a failure never leaves the resource missing from both.
+func (s *Service) moveNetworkResource(fromIdentifier, toIdentifier, resourceID string, dryRun bool) error {
+	networks, err := client.GetList[models.Network](s.Client, "/networks")
+	if err != nil {
+		return fmt.Errorf("failed to fetch networks: %v", err)
+	}
+	from, err := resolveNetwork(fromIdentifier, networks)
+	if err != nil {
+		return fmt.Errorf("source network: %v", err)
+	}
+	to, err := resolveNetwork(toIdentifier, networks)
+	if err != nil {
+		return fmt.Errorf("target network: %v", err)
+	}
+	if from.ID == to.ID {
+		return fmt.Errorf("source and target network are the same (%s)", from.Name)
+	}
+
+	var resource models.NetworkResource
+	if err := s.Client.GetJSON("/networks/"+from.ID+"/resources/"+resourceID, &resource); err != nil {
+		return fmt.Errorf("failed to fetch resource: %v", err)
+	}
+
+	groupIDs := make([]string, len(resource.Groups))
+	groupNames := make([]string, len(resource.Groups))
+	for i, group := range resource.Groups {
+		groupIDs[i] = group.ID
+		groupNames[i] = fmt.Sprintf("%s (%s)", group.Name, group.ID)
+	}
+
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
+	if err != nil {
+		return fmt.Errorf("failed to fetch policies: %v", err)
+	}
+	policyRefs := resourcePolicyRefs(policies, resource.ID)
+
+	fmt.Printf("Move resource '%s' (%s)\n", resource.Name, resource.ID)
+	fmt.Printf("  From:        %s (%s)\n", from.Name, from.ID)
+	fmt.Printf("  To:          %s (%s)\n", to.Name, to.ID)
+	fmt.Printf("  Address:     %s\n", resource.Address)
+	if resource.Description != "" {
+		fmt.Printf("  Description: %s\n", resource.Description)
+	}
+	fmt.Printf("  Enabled:     %v\n", resource.Enabled)
+	fmt.Printf("  Groups:      %s\n", strings.Join(groupNames, ", "))
+	if len(policyRefs) > 0 {
+		fmt.Printf("\nWarning: %d policy rule(s) reference this resource directly and will need to be\n", len(policyRefs))
+		fmt.Println("pointed at the new resource ID after the move:")
+		for _, ref := range policyRefs {
+			fmt.Printf("  - %s\n", ref)
+		}
+	}
+
+	if dryRun {
+		fmt.Println("\nDry run: no changes were made")
+		return nil
+	}
+
+	if !helpers.ConfirmAction(fmt.Sprintf("\nMove resource '%s' to network '%s'? The original will be deleted.", resource.Name, to.Name)) {
+		return nil
+	}
+
+	reqBody := models.NetworkResourceRequest{
+		Name:        resource.Name,
+		Address:     resource.Address,
+		Description: resource.Description,
+		Enabled:     resource.Enabled,
+		Groups:      groupIDs,
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := s.Client.MakeRequest("POST", "/networks/"+to.ID+"/resources", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create resource in network '%s' (original left in place): %v", to.Name, err)
+	}
+	var created models.NetworkResource
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to decode response (the copy may exist in '%s'; original left in place): %v", to.Name, err)
+	}
+	fmt.Printf("Created resource '%s' in network '%s' (ID: %s)\n", created.Name, to.Name, created.ID)
+
+	resp, err = s.Client.MakeRequest("DELETE", "/networks/"+from.ID+"/resources/"+resource.ID, nil)
+	if err != nil {
+		return fmt.Errorf("resource now exists in both networks; failed to delete original %s from '%s': %v",
+			resource.ID, from.Name, err)
+	}
+	resp.Body.Close()
+
+	fmt.Printf("Deleted original resource %s from network '%s'\n", resource.ID, from.Name)
+	fmt.Printf("\nResource moved successfully!\n")
+	fmt.Printf("  New ID: %s\n", created.ID)
+	return nil
+}
@@ -6,9 +6,12 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -23,6 +26,7 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 	// Query flags
 	listFlag := networkCmd.Bool("list", false, "List all networks")
 	filterName := networkCmd.String("filter-name", "", "Filter networks by name (supports wildcards)")
+	sortFlag := networkCmd.String("sort", "", "Sort networks by name, routers, or resources; '-' prefix for descending (use with --list)")
 	inspectFlag := networkCmd.String("inspect", "", "Inspect a specific network by ID")
 
 	// Network CRUD flags
@@ -35,10 +39,16 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 
 	// Resource management flags
 	listResourcesFlag := networkCmd.String("list-resources", "", "List all resources in a network")
+	filterEnabled := networkCmd.String("filter-enabled", "", "Filter resources or routers by state: true or false (use with --list-resources or --list-all-routers)")
+	filterType := networkCmd.String("filter-type", "", "Filter resources by type: host, subnet, or domain (use with --list-resources)")
 	inspectResourceFlag := networkCmd.Bool("inspect-resource", false, "Inspect a resource (requires --network-id and --resource-id)")
 	addResourceFlag := networkCmd.String("add-resource", "", "Add a resource to a network by ID")
 	updateResourceFlag := networkCmd.Bool("update-resource", false, "Update a resource (requires --network-id and --resource-id)")
 	removeResourceFlag := networkCmd.Bool("remove-resource", false, "Remove a resource (requires --network-id and --resource-id)")
+	moveResourceFlag := networkCmd.Bool("move-resource", false, "Move a resource to another network (requires --from, --to, and --resource-id)")
+	fromNetwork := networkCmd.String("from", "", "Source network ID or name (use with --move-resource)")
+	toNetwork := networkCmd.String("to", "", "Target network ID or name (use with --move-resource)")
+	dryRun := networkCmd.Bool("dry-run", false, "Show what --move-resource would do without changing anything")
 
 	// Resource-specific flags
 	networkID := networkCmd.String("network-id", "", "Network ID (for resource/router operations)")
@@ -46,7 +56,7 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 	resourceName := networkCmd.String("name", "", "Resource/Router name")
 	address := networkCmd.String("address", "", "Resource address (IP, subnet, or domain)")
 	groups := networkCmd.String("groups", "", "Comma-separated group IDs")
-	enabled := networkCmd.Bool("enabled", true, "Enable resource/router (default: true)")
+	enabled := networkCmd.Bool("enabled", defaultRouterEnabled, "Enable resource/router (default: true)")
 	disabled := networkCmd.Bool("disabled", false, "Disable resource/router")
 
 	// Router management flags
@@ -60,13 +70,14 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 	// Router-specific flags
 	routerID := networkCmd.String("router-id", "", "Router ID")
 	peer := networkCmd.String("peer", "", "Single peer ID for router")
+	peerName := networkCmd.String("peer-name", "", "Single peer name or hostname for router (alternative to --peer)")
 	peerGroups := networkCmd.String("peer-groups", "", "Comma-separated peer group IDs for router")
-	metric := networkCmd.Int("metric", 100, "Route metric (1-9999, lower = higher priority)")
-	masquerade := networkCmd.Bool("masquerade", false, "Enable masquerading (NAT)")
+	metric := networkCmd.Int("metric", defaultRouterMetric, "Route metric (1-9999, lower = higher priority)")
+	masquerade := networkCmd.Bool("masquerade", defaultRouterMasquerade, "Enable masquerading (NAT)")
 	noMasquerade := networkCmd.Bool("no-masquerade", false, "Disable masquerading")
 
 	// Output format flag
-	outputFlag := networkCmd.String("output", "table", "Output format: table or json")
+	outputFlag := networkCmd.String("output", helpers.DefaultOutputFormat, "Output format: table, wide (list only), or json")
 
 	// If no flags are provided (just 'netbird-manage network'), show usage
 	if len(args) == 1 {
@@ -102,13 +113,14 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 
 	// Handle resource operations
 	if *listResourcesFlag != "" {
-		return s.listNetworkResources(*listResourcesFlag)
+		return s.listNetworkResources(*listResourcesFlag, *filterEnabled, *filterType, *outputFlag)
 	}
 	if *inspectResourceFlag {
 		if *networkID == "" || *resourceID == "" {
 			fmt.Fprintln(os.Stderr, "Error: --network-id and --resource-id are required")
 			return nil
 		}
+		helpers.WarnTableOnly(*outputFlag, "network resources")
 		return s.inspectNetworkResource(*networkID, *resourceID)
 	}
 	if *addResourceFlag != "" {
@@ -134,12 +146,21 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 		}
 		return s.removeNetworkResource(*networkID, *resourceID)
 	}
+	if *moveResourceFlag {
+		if *fromNetwork == "" || *toNetwork == "" || *resourceID == "" {
+			fmt.Fprintln(os.Stderr, "Error: --from, --to, and --resource-id are required")
+			return nil
+		}
+		helpers.WarnTableOnly(*outputFlag, "network resources")
+		return s.moveNetworkResource(*fromNetwork, *toNetwork, *resourceID, *dryRun)
+	}
 
 	// Handle router operations
 	if *listAllRoutersFlag {
-		return s.listAllRouters()
+		return s.listAllRouters(*filterEnabled, *outputFlag)
 	}
 	if *listRoutersFlag != "" {
+		helpers.WarnTableOnly(*outputFlag, "network routers")
 		return s.listNetworkRouters(*listRoutersFlag)
 	}
 	if *inspectRouterFlag {
@@ -147,15 +168,29 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 			fmt.Fprintln(os.Stderr, "Error: --network-id and --router-id are required")
 			return nil
 		}
+		helpers.WarnTableOnly(*outputFlag, "network routers")
 		return s.inspectNetworkRouter(*networkID, *routerID)
 	}
+	if *addRouterFlag != "" || *updateRouterFlag {
+		// Resolve --peer-name to a peer ID before building the router request
+		if *peerName != "" {
+			if *peer != "" {
+				return fmt.Errorf("cannot use both --peer and --peer-name")
+			}
+			peerID, err := s.resolvePeerName(*peerName)
+			if err != nil {
+				return err
+			}
+			*peer = peerID
+		}
+	}
 	if *addRouterFlag != "" {
 		if *peer == "" && *peerGroups == "" {
-			fmt.Fprintln(os.Stderr, "Error: Either --peer or --peer-groups is required")
+			fmt.Fprintln(os.Stderr, "Error: Either --peer, --peer-name, or --peer-groups is required")
 			return nil
 		}
 		if *peer != "" && *peerGroups != "" {
-			fmt.Fprintln(os.Stderr, "Error: Cannot use both --peer and --peer-groups together")
+			fmt.Fprintln(os.Stderr, "Error: Cannot use --peer/--peer-name and --peer-groups together")
 			return nil
 		}
 		masqueradeVal := *masquerade
@@ -171,7 +206,7 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 			return nil
 		}
 		if *peer != "" && *peerGroups != "" {
-			fmt.Fprintln(os.Stderr, "Error: Cannot use both --peer and --peer-groups together")
+			fmt.Fprintln(os.Stderr, "Error: Cannot use --peer/--peer-name and --peer-groups together")
 			return nil
 		}
 		masqueradeVal := *masquerade
@@ -191,7 +226,7 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 
 	// Handle list with optional filter
 	if *listFlag {
-		return s.listNetworks(*filterName, *outputFlag)
+		return s.listNetworks(*filterName, *sortFlag, *outputFlag)
 	}
 
 	// If no known flag was used
@@ -203,16 +238,15 @@ func (s *Service) HandleNetworkCommand(args []string) error {
 // ========== Network CRUD Operations ==========
 
 // listNetworks lists all networks with optional name filtering
-func (s *Service) listNetworks(filterName string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/networks", nil)
+func (s *Service) listNetworks(filterName, sortSpec string, outputFormat string) error {
+	sortField, descending, err := parseNetworkSort(sortSpec)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var networks []models.Network
-	if err := json.NewDecoder(resp.Body).Decode(&networks); err != nil {
-		return fmt.Errorf("failed to decode networks response: %v", err)
+	networks, err := client.GetList[models.Network](s.Client, "/networks")
+	if err != nil {
+		return err
 	}
 
 	// Apply filter if provided
@@ -235,9 +269,11 @@ func (s *Service) listNetworks(filterName string, outputFormat string) error {
 		return nil
 	}
 
+	sortNetworks(networks, sortField, descending)
+
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(networks, "", "  ")
+		output, err := helpers.MarshalJSONOutput(networks)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -245,25 +281,171 @@ func (s *Service) listNetworks(filterName string, outputFormat string) error {
 		return nil
 	}
 
-	// Print a formatted table
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tROUTERS\tRESOURCES\tPOLICIES\tDESCRIPTION")
-	fmt.Fprintln(w, "--\t----\t-------\t---------\t--------\t-----------")
+	// Routing peer details are only looked up for the wide table
+	coverage := make(map[string]networkRouting)
+	if outputFormat == helpers.OutputWide {
+		coverage, err = s.networkRoutingCoverage(networks)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print a formatted table; --output wide adds the routing peers and groups
+	table := helpers.NewTable(outputFormat,
+		helpers.TableColumn{Header: "ID"},
+		helpers.TableColumn{Header: "NAME"},
+		helpers.TableColumn{Header: "ROUTERS"},
+		helpers.TableColumn{Header: "RESOURCES"},
+		helpers.TableColumn{Header: "POLICIES"},
+		helpers.TableColumn{Header: "DESCRIPTION"},
+		helpers.TableColumn{Header: "ROUTING PEERS", Wide: true},
+		helpers.TableColumn{Header: "ROUTING GROUPS", Wide: true},
+	)
 
 	for _, net := range networks {
-		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n",
+		routing := coverage[net.ID]
+		table.Row(
 			net.ID,
 			net.Name,
-			net.RoutingPeersCount,
-			len(net.Resources),
-			len(net.Policies),
+			strconv.Itoa(net.RoutingPeersCount),
+			strconv.Itoa(len(net.Resources)),
+			strconv.Itoa(len(net.Policies)),
 			net.Description,
+			formatRoutingNames(routing.Peers),
+			formatRoutingNames(routing.Groups),
 		)
 	}
-	w.Flush()
+	table.Flush()
 	return nil
 }
 
+// networkRouting holds the routing peer and peer group names of one network's routers
+type networkRouting struct {
+	Peers  []string
+	Groups []string
+}
+
+// networkRoutingCoverage resolves the routing peers and groups of each network. Routers for
+// all networks come from a single /networks/routers call rather than one call per network,
+// and peers and groups are each fetched at most once. Networks without routers are skipped.
+func (s *Service) networkRoutingCoverage(networks []models.Network) (map[string]networkRouting, error) {
+	coverage := make(map[string]networkRouting)
+
+	networkByRouter := make(map[string]models.Network)
+	for _, network := range networks {
+		for _, routerID := range network.Routers {
+			networkByRouter[routerID] = network
+		}
+	}
+	if len(networkByRouter) == 0 {
+		return coverage, nil
+	}
+
+	routers, err := client.GetList[models.NetworkRouter](s.Client, "/networks/routers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch routers: %v", err)
+	}
+
+	var entries []networkRouterEntry
+	for _, router := range routers {
+		network, ok := networkByRouter[router.ID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, networkRouterEntry{
+			NetworkID:     network.ID,
+			NetworkName:   network.Name,
+			NetworkRouter: router,
+		})
+	}
+	if len(entries) == 0 {
+		return coverage, nil
+	}
+
+	groupNames, err := s.getGroupNamesByID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve group names: %v", err)
+	}
+	peerNames, err := s.routerPeerNames(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve peer names: %v", err)
+	}
+
+	for _, entry := range entries {
+		routing := coverage[entry.NetworkID]
+		if entry.Peer != "" {
+			name := entry.Peer
+			if resolved, ok := peerNames[entry.Peer]; ok {
+				name = resolved
+			}
+			routing.Peers = append(routing.Peers, name)
+		}
+		for _, groupID := range entry.PeerGroups {
+			name := groupID
+			if resolved, ok := groupNames[groupID]; ok {
+				name = resolved
+			}
+			routing.Groups = append(routing.Groups, name)
+		}
+		coverage[entry.NetworkID] = routing
+	}
+	for networkID, routing := range coverage {
+		routing.Peers = uniqueStrings(routing.Peers)
+		routing.Groups = uniqueStrings(routing.Groups)
+		sort.Strings(routing.Peers)
+		sort.Strings(routing.Groups)
+		coverage[networkID] = routing
+	}
+	return coverage, nil
+}
+
+// formatRoutingNames renders routing peer or group names inline, or "-" when there are none
+func formatRoutingNames(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// parseNetworkSort parses a --sort value like "name" or "-resources"
+func parseNetworkSort(spec string) (field string, descending bool, err error) {
+	field = strings.ToLower(strings.TrimSpace(spec))
+	if strings.HasPrefix(field, "-") {
+		descending = true
+		field = strings.TrimPrefix(field, "-")
+	}
+	switch field {
+	case "", "name", "routers", "resources":
+		return field, descending, nil
+	}
+	return "", false, fmt.Errorf("invalid --sort key '%s' (valid: name, routers, resources)", spec)
+}
+
+// sortNetworks sorts networks in place by a single field; ties are broken by name
+func sortNetworks(networks []models.Network, field string, descending bool) {
+	if field == "" {
+		return
+	}
+
+	sort.SliceStable(networks, func(i, j int) bool {
+		a, b := networks[i], networks[j]
+		cmp := 0
+		switch field {
+		case "routers":
+			cmp = a.RoutingPeersCount - b.RoutingPeersCount
+		case "resources":
+			cmp = len(a.Resources) - len(b.Resources)
+		}
+		if cmp == 0 {
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
 // inspectNetwork shows detailed information about a specific network
 func (s *Service) inspectNetwork(networkID string, outputFormat string) error {
 	// Fetch basic network details
@@ -317,7 +499,7 @@ func (s *Service) inspectNetwork(networkID string, outputFormat string) error {
 			RoutersDetail:   routers,
 			ResourcesDetail: resources,
 		}
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
+		jsonOutput, err := helpers.MarshalJSONOutput(output)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -529,27 +711,58 @@ func (s *Service) updateNetworkDescription(networkID, description string) error
 
 // ========== Network Resources Management ==========
 
-// listNetworkResources lists all resources in a network
-func (s *Service) listNetworkResources(networkID string) error {
-	resp, err := s.Client.MakeRequest("GET", "/networks/"+networkID+"/resources", nil)
+// listNetworkResources lists all resources in a network, optionally filtered by state and type
+func (s *Service) listNetworkResources(networkID, filterEnabled, filterType, outputFormat string) error {
+	var enabledFilter *bool
+	if filterEnabled != "" {
+		enabled, err := strconv.ParseBool(filterEnabled)
+		if err != nil {
+			return fmt.Errorf("invalid value for --filter-enabled: %s (must be true or false)", filterEnabled)
+		}
+		enabledFilter = &enabled
+	}
+	filterType = strings.ToLower(filterType)
+	if filterType != "" && filterType != "host" && filterType != "subnet" && filterType != "domain" {
+		return fmt.Errorf("invalid value for --filter-type: %s (must be host, subnet, or domain)", filterType)
+	}
+
+	allResources, err := client.GetList[models.NetworkResource](s.Client, "/networks/"+networkID+"/resources")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var resources []models.NetworkResource
-	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
-		return fmt.Errorf("failed to decode resources response: %v", err)
+	resources := make([]models.NetworkResource, 0, len(allResources))
+	for _, resource := range allResources {
+		if enabledFilter != nil && resource.Enabled != *enabledFilter {
+			continue
+		}
+		if filterType != "" && !strings.EqualFold(resource.Type, filterType) {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+
+	// JSON output includes the full group objects
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(resources)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
 	}
 
 	if len(resources) == 0 {
-		fmt.Println("No resources found in this network.")
+		if len(allResources) > 0 {
+			fmt.Println("No resources match the given filters.")
+		} else {
+			fmt.Println("No resources found in this network.")
+		}
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tADDRESS\tTYPE\tGROUPS\tENABLED")
-	fmt.Fprintln(w, "--\t----\t-------\t----\t------\t-------")
+	helpers.TableHeader(w, "ID", "NAME", "ADDRESS", "TYPE", "GROUPS", "ENABLED")
 
 	for _, resource := range resources {
 		// Extract group names from PolicyGroup objects
@@ -576,15 +789,9 @@ func (s *Service) listNetworkResources(networkID string) error {
 
 // inspectNetworkResource shows detailed information about a resource
 func (s *Service) inspectNetworkResource(networkID, resourceID string) error {
-	resp, err := s.Client.MakeRequest("GET", "/networks/"+networkID+"/resources/"+resourceID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var resource models.NetworkResource
-	if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
-		return fmt.Errorf("failed to decode resource response: %v", err)
+	if err := s.Client.GetJSON("/networks/"+networkID+"/resources/"+resourceID, &resource); err != nil {
+		return err
 	}
 
 	// Extract group names from PolicyGroup objects
@@ -752,45 +959,184 @@ func (s *Service) removeNetworkResource(networkID, resourceID string) error {
 
 // ========== Network Routers Management ==========
 
+// Router settings used when none are given, by both 'network --add-router' and import
+const (
+	defaultRouterMetric     = 100
+	defaultRouterMasquerade = false
+	defaultRouterEnabled    = true
+)
+
+// newNetworkRouterRequest validates router settings and builds the request body. It is
+// shared by 'network --add-router', '--update-router', and import so every path applies
+// the same rules and sends identical requests for the same inputs.
+func newNetworkRouterRequest(peer string, peerGroups []string, metric int, masquerade, enabled bool) (models.NetworkRouterRequest, error) {
+	if peer == "" && len(peerGroups) == 0 {
+		return models.NetworkRouterRequest{}, fmt.Errorf("a router needs either a peer or peer groups")
+	}
+	if peer != "" && len(peerGroups) > 0 {
+		return models.NetworkRouterRequest{}, fmt.Errorf("a router cannot use both a peer and peer groups")
+	}
+	if metric < 1 || metric > 9999 {
+		return models.NetworkRouterRequest{}, fmt.Errorf("metric must be between 1 and 9999 (got %d)", metric)
+	}
+	return models.NetworkRouterRequest{
+		Peer:       peer,
+		PeerGroups: peerGroups,
+		Metric:     metric,
+		Masquerade: masquerade,
+		Enabled:    enabled,
+	}, nil
+}
+
 // listAllRouters lists all routers across all networks
-func (s *Service) listAllRouters() error {
-	resp, err := s.Client.MakeRequest("GET", "/networks/routers", nil)
+func (s *Service) listAllRouters(filterEnabled, outputFormat string) error {
+	var enabledFilter *bool
+	if filterEnabled != "" {
+		enabled, err := strconv.ParseBool(filterEnabled)
+		if err != nil {
+			return fmt.Errorf("invalid value for --filter-enabled: %s (must be true or false)", filterEnabled)
+		}
+		enabledFilter = &enabled
+	}
+
+	routers, err := client.GetList[models.NetworkRouter](s.Client, "/networks/routers")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var routers []models.NetworkRouter
-	if err := json.NewDecoder(resp.Body).Decode(&routers); err != nil {
-		return fmt.Errorf("failed to decode routers response: %v", err)
+	// Routers do not carry their network; map them through each network's router IDs
+	networks, err := client.GetList[models.Network](s.Client, "/networks")
+	if err != nil {
+		return fmt.Errorf("failed to fetch networks: %v", err)
+	}
+	networkByRouter := make(map[string]models.Network)
+	for _, network := range networks {
+		for _, routerID := range network.Routers {
+			networkByRouter[routerID] = network
+		}
 	}
 
-	if len(routers) == 0 {
+	entries := make([]networkRouterEntry, 0, len(routers))
+	for _, router := range routers {
+		if enabledFilter != nil && router.Enabled != *enabledFilter {
+			continue
+		}
+		network := networkByRouter[router.ID]
+		entries = append(entries, networkRouterEntry{
+			NetworkID:     network.ID,
+			NetworkName:   network.Name,
+			NetworkRouter: router,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].NetworkName != entries[j].NetworkName {
+			return entries[i].NetworkName < entries[j].NetworkName
+		}
+		return entries[i].NetworkID < entries[j].NetworkID
+	})
+
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(entries)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(entries) == 0 {
 		fmt.Println("No routers found.")
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tPEER/GROUPS\tMETRIC\tMASQUERADE\tENABLED")
-	fmt.Fprintln(w, "--\t-----------\t------\t----------\t-------")
+	groupNames, err := s.getGroupNamesByID()
+	if err != nil {
+		return fmt.Errorf("failed to resolve group names: %v", err)
+	}
+	peerNames, err := s.routerPeerNames(entries)
+	if err != nil {
+		return fmt.Errorf("failed to resolve peer names: %v", err)
+	}
 
-	for _, router := range routers {
-		peerInfo := router.Peer
-		if len(router.PeerGroups) > 0 {
-			peerInfo = fmt.Sprintf("Groups: %s", strings.Join(router.PeerGroups, ", "))
+	networkCount := 0
+	for start := 0; start < len(entries); {
+		end := start
+		enabledCount := 0
+		for end < len(entries) && entries[end].NetworkID == entries[start].NetworkID {
+			if entries[end].Enabled {
+				enabledCount++
+			}
+			end++
 		}
-		fmt.Fprintf(w, "%s\t%s\t%d\t%v\t%v\n",
-			router.ID,
-			peerInfo,
-			router.Metric,
-			router.Masquerade,
-			router.Enabled,
-		)
+		group := entries[start:end]
+		networkCount++
+
+		header := fmt.Sprintf("%s (%s)", group[0].NetworkName, group[0].NetworkID)
+		if group[0].NetworkID == "" {
+			header = "(no network)"
+		}
+		fmt.Printf("Network: %s - %d router(s), %d enabled\n", header, len(group), enabledCount)
+
+		// The empty first column indents each network's table under its heading
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		helpers.TableHeader(w, "", "ID", "PEER/GROUPS", "METRIC", "MASQUERADE", "ENABLED")
+		for _, router := range group {
+			peerInfo := "peer:" + router.Peer
+			if name, ok := peerNames[router.Peer]; ok {
+				peerInfo = "peer:" + name
+			}
+			if len(router.PeerGroups) > 0 {
+				peerInfo = "groups:" + joinGroupNames(router.PeerGroups, groupNames)
+			}
+			fmt.Fprintf(w, "\t%s\t%s\t%d\t%v\t%v\n",
+				router.ID,
+				peerInfo,
+				router.Metric,
+				router.Masquerade,
+				router.Enabled,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+
+		start = end
 	}
-	w.Flush()
+
+	helpers.TableFooter("Total: %d router(s) across %d network(s)\n", len(entries), networkCount)
 	return nil
 }
 
+// networkRouterEntry is a router with the network it belongs to (network --list-all-routers)
+type networkRouterEntry struct {
+	NetworkID   string `json:"network_id"`
+	NetworkName string `json:"network_name"`
+	models.NetworkRouter
+}
+
+// routerPeerNames maps routing peer IDs to names, fetching peers only if a router uses one
+func (s *Service) routerPeerNames(entries []networkRouterEntry) (map[string]string, error) {
+	names := make(map[string]string)
+	needsPeers := false
+	for _, entry := range entries {
+		if entry.Peer != "" {
+			needsPeers = true
+			break
+		}
+	}
+	if !needsPeers {
+		return names, nil
+	}
+
+	peers, err := client.GetList[models.Peer](s.Client, "/peers")
+	if err != nil {
+		return nil, err
+	}
+	for _, peer := range peers {
+		names[peer.ID] = peer.Name
+	}
+	return names, nil
+}
+
 // listNetworkRouters lists all routers in a specific network
 func (s *Service) listNetworkRouters(networkID string) error {
 	resp, err := s.Client.MakeRequest("GET", "/networks/"+networkID+"/routers", nil)
@@ -810,8 +1156,7 @@ func (s *Service) listNetworkRouters(networkID string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tPEER/GROUPS\tMETRIC\tMASQUERADE\tENABLED")
-	fmt.Fprintln(w, "--\t-----------\t------\t----------\t-------")
+	helpers.TableHeader(w, "ID", "PEER/GROUPS", "METRIC", "MASQUERADE", "ENABLED")
 
 	for _, router := range routers {
 		peerInfo := router.Peer
@@ -832,15 +1177,9 @@ func (s *Service) listNetworkRouters(networkID string) error {
 
 // inspectNetworkRouter shows detailed information about a router
 func (s *Service) inspectNetworkRouter(networkID, routerID string) error {
-	resp, err := s.Client.MakeRequest("GET", "/networks/"+networkID+"/routers/"+routerID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var router models.NetworkRouter
-	if err := json.NewDecoder(resp.Body).Decode(&router); err != nil {
-		return fmt.Errorf("failed to decode router response: %v", err)
+	if err := s.Client.GetJSON("/networks/"+networkID+"/routers/"+routerID, &router); err != nil {
+		return err
 	}
 
 	fmt.Printf("Router: %s\n", router.ID)
@@ -860,22 +1199,14 @@ func (s *Service) inspectNetworkRouter(networkID, routerID string) error {
 
 // addNetworkRouter adds a router to a network
 func (s *Service) addNetworkRouter(networkID, peer, peerGroupsStr string, metric int, masquerade, enabled bool) error {
-	// Validate metric range
-	if metric < 1 || metric > 9999 {
-		return fmt.Errorf("metric must be between 1 and 9999")
-	}
-
 	var peerGroups []string
 	if peerGroupsStr != "" {
 		peerGroups = helpers.SplitCommaList(peerGroupsStr)
 	}
 
-	reqBody := models.NetworkRouterRequest{
-		Peer:       peer,
-		PeerGroups: peerGroups,
-		Metric:     metric,
-		Masquerade: masquerade,
-		Enabled:    enabled,
+	reqBody, err := newNetworkRouterRequest(peer, peerGroups, metric, masquerade, enabled)
+	if err != nil {
+		return err
 	}
 
 	bodyBytes, err := json.Marshal(reqBody)
@@ -912,11 +1243,6 @@ func (s *Service) updateNetworkRouter(networkID, routerID, peer, peerGroupsStr s
 	}
 	resp.Body.Close()
 
-	// Validate metric range
-	if metric < 1 || metric > 9999 {
-		return fmt.Errorf("metric must be between 1 and 9999")
-	}
-
 	// Update fields
 	if peer != "" {
 		router.Peer = peer
@@ -926,16 +1252,10 @@ func (s *Service) updateNetworkRouter(networkID, routerID, peer, peerGroupsStr s
 		router.PeerGroups = helpers.SplitCommaList(peerGroupsStr)
 		router.Peer = "" // Clear peer when using peer groups
 	}
-	router.Metric = metric
-	router.Masquerade = masquerade
-	router.Enabled = enabled
 
-	reqBody := models.NetworkRouterRequest{
-		Peer:       router.Peer,
-		PeerGroups: router.PeerGroups,
-		Metric:     router.Metric,
-		Masquerade: router.Masquerade,
-		Enabled:    router.Enabled,
+	reqBody, err := newNetworkRouterRequest(router.Peer, router.PeerGroups, metric, masquerade, enabled)
+	if err != nil {
+		return err
 	}
 
 	bodyBytes, err := json.Marshal(reqBody)
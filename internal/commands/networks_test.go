@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"netbird-manage/internal/client"
+)
+
+// routerBodyRecorder is a fake management API that records the body of each router POST
+type routerBodyRecorder struct {
+	bodies []map[string]interface{}
+}
+
+func (r *routerBodyRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/routers") {
+		var body map[string]interface{}
+		data, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(data, &body); err == nil {
+			r.bodies = append(r.bodies, body)
+		}
+		io.WriteString(w, `{"id":"router-1"}`)
+		return
+	}
+	io.WriteString(w, `[]`)
+}
+
+// TestAddRouterMatchesImport checks that 'network --add-router' and an imported router with the
+// same settings send identical request bodies, including the defaults for omitted settings
+func TestAddRouterMatchesImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		cli    []string
+		config map[string]interface{}
+	}{
+		{
+			name:   "peer with defaults",
+			cli:    []string{"--peer", "peer-1"},
+			config: map[string]interface{}{"peer": "peer-1"},
+		},
+		{
+			name:   "peer groups with defaults",
+			cli:    []string{"--peer-groups", "group-1"},
+			config: map[string]interface{}{"peer_groups": []interface{}{"routers"}},
+		},
+		{
+			name:   "explicit metric, masquerade, disabled",
+			cli:    []string{"--peer", "peer-1", "--metric", "50", "--masquerade", "--disabled"},
+			config: map[string]interface{}{"peer": "peer-1", "metric": 50, "masquerade": true, "enabled": false},
+		},
+		{
+			name:   "no-masquerade",
+			cli:    []string{"--peer-groups", "group-1", "--no-masquerade"},
+			config: map[string]interface{}{"peer_groups": []interface{}{"routers"}, "masquerade": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &routerBodyRecorder{}
+			server := httptest.NewServer(recorder)
+			defer server.Close()
+
+			svc := NewService(client.New("test-token", server.URL))
+			args := append([]string{"network", "--add-router", "net-1"}, tt.cli...)
+			if err := svc.HandleNetworkCommand(args); err != nil {
+				t.Fatalf("network --add-router: %v", err)
+			}
+
+			ctx := &ImportContext{
+				Service:       svc,
+				GroupNameToID: map[string]string{"routers": "group-1"},
+			}
+			if err := ctx.addNetworkRouter("office", "net-1", "router", tt.config); err != nil {
+				t.Fatalf("import router: %v", err)
+			}
+
+			if len(recorder.bodies) != 2 {
+				t.Fatalf("expected 2 router requests, got %d", len(recorder.bodies))
+			}
+			if cli, imported := recorder.bodies[0], recorder.bodies[1]; !reflect.DeepEqual(cli, imported) {
+				t.Errorf("request bodies differ:\n  cli:    %v\n  import: %v", cli, imported)
+			}
+		})
+	}
+}
+
+func TestNewNetworkRouterRequestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		peer       string
+		peerGroups []string
+		metric     int
+	}{
+		{name: "neither peer nor groups", metric: defaultRouterMetric},
+		{name: "both peer and groups", peer: "peer-1", peerGroups: []string{"group-1"}, metric: defaultRouterMetric},
+		{name: "metric too low", peer: "peer-1", metric: 0},
+		{name: "metric too high", peer: "peer-1", metric: 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newNetworkRouterRequest(tt.peer, tt.peerGroups, tt.metric, defaultRouterMasquerade, defaultRouterEnabled); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
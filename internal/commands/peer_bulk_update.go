@@ -0,0 +1,201 @@
+// peer_bulk_update.go - peer --bulk-update-from-csv: apply per-peer settings from a spreadsheet
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// peerCSVColumns maps accepted CSV header names to peerUpdateFromConfig field keys
+var peerCSVColumns = map[string]string{
+	"name":                          "name",
+	"ssh_enabled":                   "ssh_enabled",
+	"login_expiration":              "login_expiration_enabled",
+	"login_expiration_enabled":      "login_expiration_enabled",
+	"inactivity_expiration":         "inactivity_expiration_enabled",
+	"inactivity_expiration_enabled": "inactivity_expiration_enabled",
+	"approval":                      "approval_required",
+	"approval_required":             "approval_required",
+}
+
+// peerCSVRow is one data row of a --bulk-update-from-csv file
+type peerCSVRow struct {
+	Line   int
+	Peer   string                 // Peer ID or name
+	Fields map[string]interface{} // Only the non-empty cells, keyed like an import config
+}
+
+// parsePeerUpdateCSV reads a CSV whose header names a "peer" column (ID or name) and any of
+// the peerCSVColumns. Empty cells leave the field unchanged. All values are validated
+// before anything is updated.
+func parsePeerUpdateCSV(path string) ([]peerCSVRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file %s needs a header row and at least one peer row", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %v", err)
+	}
+
+	// fieldColumns holds the update column of each CSV column index, in header order
+	type fieldColumn struct {
+		index int
+		field string
+	}
+	peerColumn := -1
+	var fieldColumns []fieldColumn
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "peer" {
+			peerColumn = i
+			continue
+		}
+		field, ok := peerCSVColumns[column]
+		if !ok {
+			return nil, fmt.Errorf("CSV header: unknown column '%s' (expected peer, name, ssh_enabled, login_expiration, inactivity_expiration, approval)", column)
+		}
+		fieldColumns = append(fieldColumns, fieldColumn{index: i, field: field})
+	}
+	if peerColumn < 0 {
+		return nil, fmt.Errorf("CSV header: a 'peer' column with the peer ID or name is required")
+	}
+	if len(fieldColumns) == 0 {
+		return nil, fmt.Errorf("CSV header: no columns to update")
+	}
+
+	var rows []peerCSVRow
+	var problems []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %v", err)
+		}
+		// Comment and blank lines are skipped by the reader, so ask it for the real line number
+		line, _ := reader.FieldPos(0)
+		row := peerCSVRow{Line: line, Peer: strings.TrimSpace(record[peerColumn]), Fields: make(map[string]interface{})}
+		if row.Peer == "" {
+			problems = append(problems, fmt.Sprintf("line %d: peer is empty", line))
+			continue
+		}
+		for _, column := range fieldColumns {
+			field := column.field
+			value := strings.TrimSpace(record[column.index])
+			if value == "" {
+				continue
+			}
+			if field == "name" {
+				row.Fields[field] = value
+				continue
+			}
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("line %d: %s must be true or false (got '%s')", line, field, value))
+				continue
+			}
+			row.Fields[field] = parsed
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 && len(problems) == 0 {
+		return nil, fmt.Errorf("CSV file %s needs a header row and at least one peer row", path)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("CSV file has %d problem(s), nothing was updated:\n  - %s",
+			len(problems), strings.Join(problems, "\n  - "))
+	}
+	return rows, nil
+}
+
+// bulkUpdatePeersFromCSV implements "peer --bulk-update-from-csv <file> [--dry-run]".
+// Each row is applied on its own; a failing row does not stop the rest.
+func (s *Service) bulkUpdatePeersFromCSV(path string, dryRun bool) error {
+	rows, err := parsePeerUpdateCSV(path)
+	if err != nil {
+		return err
+	}
+
+	// One listing resolves every row; updates below refetch each peer by ID
+	peers, err := s.getAllPeers()
+	if err != nil {
+		return fmt.Errorf("failed to fetch peers: %v", err)
+	}
+
+	if dryRun {
+		fmt.Println("Dry run: no changes will be made")
+		fmt.Println()
+	}
+
+	var updated, unchanged, failed int
+	for i, row := range rows {
+		fmt.Printf("[%d/%d] Line %d '%s'... ", i+1, len(rows), row.Line, row.Peer)
+
+		peerID, err := matchPeerIdentifier(row.Peer, peers)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+
+		peer, err := s.getPeerByID(peerID)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+
+		updateReq, changes, err := peerUpdateFromConfig(*peer, row.Fields, func(string) string { return "" })
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		if len(changes) == 0 {
+			fmt.Println("No changes")
+			unchanged++
+			continue
+		}
+
+		if dryRun {
+			fmt.Printf("Would update (%s)\n", strings.Join(changes, ", "))
+			updated++
+			continue
+		}
+
+		if err := s.putPeer(peer.ID, updateReq); err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("Done (%s)\n", strings.Join(changes, ", "))
+		updated++
+	}
+
+	fmt.Println()
+	verb := "updated"
+	if dryRun {
+		verb = "would be updated"
+	}
+	fmt.Printf("Summary: %d %s, %d unchanged, %d failed\n", updated, verb, unchanged, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d peer(s)", failed)
+	}
+	return nil
+}
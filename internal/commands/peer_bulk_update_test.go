@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePeerCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "peers.csv")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParsePeerUpdateCSVLineNumbers(t *testing.T) {
+	path := writePeerCSV(t, "# peers to update\npeer,ssh_enabled\n\nweb,true\n# databases\ndb,false\n")
+
+	rows, err := parsePeerUpdateCSV(path)
+	if err != nil {
+		t.Fatalf("parsePeerUpdateCSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].Peer != "web" || rows[0].Line != 4 {
+		t.Errorf("first row = %s on line %d, want web on line 4", rows[0].Peer, rows[0].Line)
+	}
+	if rows[1].Peer != "db" || rows[1].Line != 6 {
+		t.Errorf("second row = %s on line %d, want db on line 6", rows[1].Peer, rows[1].Line)
+	}
+}
+
+func TestParsePeerUpdateCSVReportsSourceLine(t *testing.T) {
+	path := writePeerCSV(t, "peer,approval\n# comment\n\nweb,maybe\n")
+
+	_, err := parsePeerUpdateCSV(path)
+	if err == nil {
+		t.Fatal("expected an error for a non-boolean value")
+	}
+	if !strings.Contains(err.Error(), "line 4:") {
+		t.Errorf("error %q does not point at line 4", err)
+	}
+}
+
+func TestParsePeerUpdateCSVNeedsPeerRow(t *testing.T) {
+	path := writePeerCSV(t, "peer,ssh_enabled\n# nothing yet\n")
+
+	if _, err := parsePeerUpdateCSV(path); err == nil {
+		t.Error("expected an error for a CSV without peer rows")
+	}
+}
@@ -0,0 +1,93 @@
+// peer_inventory.go - CSV asset report of peers
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// peerInventoryHeader lists the CSV columns written by --export-inventory
+var peerInventoryHeader = []string{
+	"name", "hostname", "ip", "os", "version", "connected", "last_seen",
+	"ssh_enabled", "login_expiration_enabled", "groups",
+}
+
+// exportPeerInventory implements "peer --export-inventory <file.csv>"
+func (s *Service) exportPeerInventory(path, groupIdentifier string) error {
+	peers, err := s.getAllPeers()
+	if err != nil {
+		return fmt.Errorf("failed to fetch peers: %v", err)
+	}
+
+	if groupIdentifier != "" {
+		groupID, err := s.resolveGroupIdentifier(groupIdentifier)
+		if err != nil {
+			return err
+		}
+		peers = filterPeersByGroup(peers, groupID)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create inventory file: %v", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(peerInventoryHeader); err != nil {
+		return fmt.Errorf("failed to write inventory: %v", err)
+	}
+	for _, peer := range peers {
+		if err := w.Write(peerInventoryRow(peer)); err != nil {
+			return fmt.Errorf("failed to write inventory: %v", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write inventory: %v", err)
+	}
+
+	fmt.Printf("Exported %d peer(s) to %s\n", len(peers), path)
+	return nil
+}
+
+// filterPeersByGroup keeps only peers that are members of the given group
+func filterPeersByGroup(peers []models.Peer, groupID string) []models.Peer {
+	var filtered []models.Peer
+	for _, peer := range peers {
+		for _, group := range peer.Groups {
+			if group.ID == groupID {
+				filtered = append(filtered, peer)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// peerInventoryRow converts a peer into one CSV record; groups are joined with "; "
+func peerInventoryRow(peer models.Peer) []string {
+	groupNames := make([]string, 0, len(peer.Groups))
+	for _, group := range peer.Groups {
+		groupNames = append(groupNames, group.Name)
+	}
+
+	return []string{
+		peer.Name,
+		peer.Hostname,
+		peer.IP,
+		helpers.FormatOS(peer.OS),
+		peer.Version,
+		strconv.FormatBool(peer.Connected),
+		peer.LastSeen,
+		strconv.FormatBool(peer.SSHEnabled),
+		strconv.FormatBool(peer.LoginExpirationEnabled),
+		strings.Join(groupNames, "; "),
+	}
+}
@@ -0,0 +1,160 @@
+// peer_policies.go - peer --inspect --show-policies: policies that apply to a peer via its groups
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/models"
+)
+
+// peerPolicy is a policy with at least one rule that references the peer
+type peerPolicy struct {
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Enabled bool             `json:"enabled"`
+	Rules   []peerPolicyRule `json:"rules"`
+}
+
+// peerPolicyRule is a policy rule that references the peer as a source and/or destination
+type peerPolicyRule struct {
+	Name          string   `json:"name"`
+	Enabled       bool     `json:"enabled"`
+	Action        string   `json:"action"`
+	Protocol      string   `json:"protocol"`
+	Ports         string   `json:"ports"` // Comma-separated ports and ranges; empty means all ports
+	Bidirectional bool     `json:"bidirectional"`
+	Role          string   `json:"role"`         // "source", "destination", or "source and destination"
+	Via           []string `json:"via"`          // "peer" or "group <name>" for each match
+	Sources       []string `json:"sources"`      // Source group names
+	Destinations  []string `json:"destinations"` // Destination group names
+}
+
+// peerPolicyMatches reports how a rule side (groups plus an optional resource) applies to the peer
+func peerPolicyMatches(peer *models.Peer, groups []models.PolicyGroup, resource *models.PolicyResource) []string {
+	var via []string
+	if resource != nil && resource.Type == "peer" && resource.ID == peer.ID {
+		via = append(via, "peer")
+	}
+	for _, group := range groups {
+		for _, peerGroup := range peer.Groups {
+			if group.ID == peerGroup.ID {
+				via = append(via, "group "+peerGroup.Name)
+				break
+			}
+		}
+	}
+	return via
+}
+
+// getPeerPolicies finds the policies whose rules reference one of the peer's groups
+// (or the peer itself as a resource) as a source or destination
+func (s *Service) getPeerPolicies(peer *models.Peer) ([]peerPolicy, error) {
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policies: %v", err)
+	}
+
+	result := []peerPolicy{}
+	for _, policy := range policies {
+		var rules []peerPolicyRule
+		for _, rule := range policy.Rules {
+			sourceVia := peerPolicyMatches(peer, rule.Sources, rule.SourceResource)
+			destVia := peerPolicyMatches(peer, rule.Destinations, rule.DestinationResource)
+
+			var role string
+			switch {
+			case len(sourceVia) > 0 && len(destVia) > 0:
+				role = "source and destination"
+			case len(sourceVia) > 0:
+				role = "source"
+			case len(destVia) > 0:
+				role = "destination"
+			default:
+				continue
+			}
+
+			rules = append(rules, peerPolicyRule{
+				Name:          rule.Name,
+				Enabled:       rule.Enabled,
+				Action:        rule.Action,
+				Protocol:      rule.Protocol,
+				Ports:         strings.TrimPrefix(formatPorts(rule.Ports, rule.PortRanges), ":"),
+				Bidirectional: rule.Bidirectional,
+				Role:          role,
+				Via:           uniqueStrings(append(sourceVia, destVia...)),
+				Sources:       ruleSideNames(rule.Sources, rule.SourceResource),
+				Destinations:  ruleSideNames(rule.Destinations, rule.DestinationResource),
+			})
+		}
+		if len(rules) > 0 {
+			result = append(result, peerPolicy{ID: policy.ID, Name: policy.Name, Enabled: policy.Enabled, Rules: rules})
+		}
+	}
+	return result, nil
+}
+
+// ruleSideNames names one side of a rule: its group names (IDs if unnamed) and any resource
+func ruleSideNames(groups []models.PolicyGroup, resource *models.PolicyResource) []string {
+	names := make([]string, 0, len(groups)+1)
+	for _, group := range groups {
+		name := group.Name
+		if name == "" {
+			name = group.ID
+		}
+		names = append(names, name)
+	}
+	if resource != nil {
+		names = append(names, fmt.Sprintf("%s %s", resource.Type, resource.ID))
+	}
+	return names
+}
+
+// uniqueStrings drops repeated values, keeping the first occurrence
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// printPeerPolicies prints the policies section of "peer --inspect --show-policies"
+func printPeerPolicies(policies []peerPolicy) {
+	if len(policies) == 0 {
+		fmt.Println("  Policies:    None (no policy rule references this peer or its groups)")
+		return
+	}
+
+	fmt.Println("  Policies:")
+	for _, policy := range policies {
+		state := ""
+		if !policy.Enabled {
+			state = " [disabled]"
+		}
+		fmt.Printf("    - %s (%s)%s\n", policy.Name, policy.ID, state)
+		for _, rule := range policy.Rules {
+			ports := rule.Ports
+			if ports == "" {
+				ports = "all ports"
+			}
+			direction := "->"
+			if rule.Bidirectional {
+				direction = "<->"
+			}
+			ruleState := ""
+			if !rule.Enabled {
+				ruleState = ", disabled"
+			}
+			fmt.Printf("        %s: %s %s %s (as %s via %s%s)\n",
+				rule.Name, rule.Action, rule.Protocol, ports, rule.Role, strings.Join(rule.Via, ", "), ruleState)
+			fmt.Printf("          %s %s %s\n",
+				strings.Join(rule.Sources, ", "), direction, strings.Join(rule.Destinations, ", "))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// peer_routing.go - peer --inspect --with-routing: routes and networks a peer routes for
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/models"
+)
+
+// peerRouting lists the routes and network routers a peer serves, directly or via a group
+type peerRouting struct {
+	Routes   []peerRoutingRoute   `json:"routes"`
+	Networks []peerRoutingNetwork `json:"networks"`
+}
+
+// peerRoutingRoute is a route the peer routes for
+type peerRoutingRoute struct {
+	ID          string `json:"id"`
+	NetworkID   string `json:"network_id"`
+	Destination string `json:"destination"` // CIDR, or comma-separated domains for domain routes
+	Enabled     bool   `json:"enabled"`
+	Via         string `json:"via"` // "peer" or "group <name>"
+}
+
+// peerRoutingNetwork is a network the peer is a router for
+type peerRoutingNetwork struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	RouterID  string   `json:"router_id"`
+	Enabled   bool     `json:"enabled"`
+	Via       string   `json:"via"`
+	Resources []string `json:"resources"` // Resource addresses in the network
+}
+
+// peerRoutingVia reports whether a route or router applies to the peer, and how:
+// "peer" when it names the peer directly, "group <name>" when via one of its groups
+func peerRoutingVia(peer *models.Peer, routerPeer string, routerGroups []string) (string, bool) {
+	if routerPeer != "" && routerPeer == peer.ID {
+		return "peer", true
+	}
+	for _, groupID := range routerGroups {
+		for _, group := range peer.Groups {
+			if group.ID == groupID {
+				return "group " + group.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// getPeerRouting finds the routes and network routers served by a peer
+func (s *Service) getPeerRouting(peer *models.Peer) (*peerRouting, error) {
+	routing := &peerRouting{
+		Routes:   []peerRoutingRoute{},
+		Networks: []peerRoutingNetwork{},
+	}
+
+	routes, err := client.GetList[models.Route](s.Client, "/routes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch routes: %v", err)
+	}
+	for _, route := range routes {
+		via, ok := peerRoutingVia(peer, route.Peer, route.PeerGroups)
+		if !ok {
+			continue
+		}
+		destination := route.Network
+		if len(route.Domains) > 0 {
+			destination = strings.Join(route.Domains, ", ")
+		}
+		routing.Routes = append(routing.Routes, peerRoutingRoute{
+			ID:          route.ID,
+			NetworkID:   route.NetworkID,
+			Destination: destination,
+			Enabled:     route.Enabled,
+			Via:         via,
+		})
+	}
+
+	networks, err := client.GetList[models.Network](s.Client, "/networks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch networks: %v", err)
+	}
+	for _, network := range networks {
+		if len(network.Routers) == 0 {
+			continue
+		}
+		routers, err := s.fetchNetworkRouters(network.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch routers for network '%s': %v", network.Name, err)
+		}
+		for _, router := range routers {
+			via, ok := peerRoutingVia(peer, router.Peer, router.PeerGroups)
+			if !ok {
+				continue
+			}
+
+			resources, err := s.fetchNetworkResources(network.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch resources for network '%s': %v", network.Name, err)
+			}
+			addresses := make([]string, 0, len(resources))
+			for _, resource := range resources {
+				addresses = append(addresses, resource.Address)
+			}
+
+			routing.Networks = append(routing.Networks, peerRoutingNetwork{
+				ID:        network.ID,
+				Name:      network.Name,
+				RouterID:  router.ID,
+				Enabled:   router.Enabled,
+				Via:       via,
+				Resources: addresses,
+			})
+			break
+		}
+	}
+
+	return routing, nil
+}
+
+// printPeerRouting prints the routing section of "peer --inspect --with-routing"
+func printPeerRouting(routing *peerRouting) {
+	if len(routing.Routes) == 0 && len(routing.Networks) == 0 {
+		fmt.Println("  Routing:     Not a routing peer")
+		return
+	}
+
+	if len(routing.Routes) > 0 {
+		fmt.Println("  Routes:")
+		for _, route := range routing.Routes {
+			state := ""
+			if !route.Enabled {
+				state = ", disabled"
+			}
+			fmt.Printf("    - %s [%s] (via %s%s)\n", route.Destination, route.NetworkID, route.Via, state)
+		}
+	}
+
+	if len(routing.Networks) > 0 {
+		fmt.Println("  Networks:")
+		for _, network := range routing.Networks {
+			state := ""
+			if !network.Enabled {
+				state = ", disabled"
+			}
+			fmt.Printf("    - %s (%s) (via %s%s)\n", network.Name, network.ID, network.Via, state)
+			for _, address := range network.Resources {
+				fmt.Printf("        %s\n", address)
+			}
+		}
+	}
+}
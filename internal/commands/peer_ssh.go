@@ -0,0 +1,132 @@
+// peer_ssh.go - Bulk SSH toggle for one peer, a group, or every peer
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"netbird-manage/internal/helpers"
+	"netbird-manage/internal/models"
+)
+
+// setSSHConfirmThreshold is the number of peers above which disabling SSH asks for confirmation
+const setSSHConfirmThreshold = 1
+
+// setPeersSSH implements "peer --set-ssh <true|false>" with --peer, --group, or --all
+func (s *Service) setPeersSSH(value, peerIdentifier string, groupFilter peerGroupFilter, all bool) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for --set-ssh: %s (must be true or false)", value)
+	}
+
+	targets := 0
+	if peerIdentifier != "" {
+		targets++
+	}
+	if len(groupFilter.GroupIDs) > 0 {
+		targets++
+	}
+	if all {
+		targets++
+	}
+	if targets != 1 {
+		return fmt.Errorf("--set-ssh requires exactly one of --peer, --group, or --all")
+	}
+
+	var candidates []models.Peer
+	if peerIdentifier != "" {
+		peerID, err := s.resolvePeerIdentifier(peerIdentifier)
+		if err != nil {
+			return err
+		}
+		peer, err := s.getPeerByID(peerID)
+		if err != nil {
+			return fmt.Errorf("failed to get peer: %v", err)
+		}
+		candidates = append(candidates, *peer)
+	} else {
+		peers, err := s.getAllPeers()
+		if err != nil {
+			return fmt.Errorf("failed to fetch peers: %v", err)
+		}
+		for _, peer := range peers {
+			if groupFilter.matches(peer) {
+				candidates = append(candidates, peer)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No peers matched the target.")
+		return nil
+	}
+
+	var pending []models.Peer
+	for _, peer := range candidates {
+		if peer.SSHEnabled == enabled {
+			continue
+		}
+		pending = append(pending, peer)
+	}
+	skipped := len(candidates) - len(pending)
+
+	if len(pending) == 0 {
+		fmt.Printf("All %d peer(s) already have ssh_enabled=%t; nothing to do\n", len(candidates), enabled)
+		return nil
+	}
+
+	if !enabled && len(pending) > setSSHConfirmThreshold {
+		fmt.Fprintf(os.Stderr, "\nThis will disable SSH on %d peers:\n", len(pending))
+		for i, peer := range pending {
+			if i >= 10 {
+				fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(pending)-10)
+				break
+			}
+			fmt.Fprintf(os.Stderr, "  - %s (ID: %s)\n", peer.Name, peer.ID)
+		}
+		if !helpers.ConfirmAction("\nContinue?") {
+			return nil
+		}
+	}
+
+	var updated, failed int
+	for i, candidate := range pending {
+		fmt.Printf("[%d/%d] Setting ssh_enabled=%t on '%s'... ", i+1, len(pending), enabled, candidate.Name)
+
+		// Re-fetch so the PUT carries the peer's current settings, not the list snapshot
+		peer, err := s.getPeerByID(candidate.ID)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		if peer.SSHEnabled == enabled {
+			fmt.Println("Already set")
+			skipped++
+			continue
+		}
+
+		updateReq := models.PeerUpdateRequest{
+			Name:                        peer.Name,
+			SSHEnabled:                  enabled,
+			LoginExpirationEnabled:      peer.LoginExpirationEnabled,
+			InactivityExpirationEnabled: peer.InactivityExpirationEnabled,
+			ApprovalRequired:            peer.ApprovalRequired,
+		}
+		if err := s.putPeer(peer.ID, updateReq); err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Println("Done")
+		updated++
+	}
+
+	fmt.Println()
+	fmt.Printf("Summary: %d updated, %d already ssh_enabled=%t, %d failed\n", updated, skipped, enabled, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d peer(s)", failed)
+	}
+	return nil
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -23,6 +27,8 @@ func (s *Service) HandlePeersCommand(args []string) error {
 
 	listFlag := peerCmd.Bool("list", false, "List all peers")
 	inspectFlag := peerCmd.String("inspect", "", "Inspect a peer by its ID")
+	withRoutingFlag := peerCmd.Bool("with-routing", false, "With --inspect: show routes and networks the peer routes for")
+	showPoliciesFlag := peerCmd.Bool("show-policies", false, "With --inspect: show policies whose rules reference the peer's groups")
 	removeFlag := peerCmd.String("remove", "", "Remove a peer by its ID")
 	removeBatchFlag := peerCmd.String("remove-batch", "", "Remove multiple peers (comma-separated IDs)")
 	editFlag := peerCmd.String("edit", "", "Edit a peer by its ID (use with --add-group or --remove-group)")
@@ -30,7 +36,8 @@ func (s *Service) HandlePeersCommand(args []string) error {
 	rmGrpFlag := peerCmd.String("remove-group", "", "Group to remove from the peer (requires --edit)")
 
 	updateFlag := peerCmd.String("update", "", "Update a peer by its ID (use with update flags)")
-	renameFlag := peerCmd.String("rename", "", "New name for the peer (requires --update)")
+	renameFlag := peerCmd.String("rename", "", "Rename a peer by ID or name (requires --new-name); with --update, the new name")
+	newNameFlag := peerCmd.String("new-name", "", "New name for the peer (requires --rename)")
 	sshFlag := peerCmd.String("ssh-enabled", "", "Enable/disable SSH (true/false, requires --update)")
 	loginExpFlag := peerCmd.String("login-expiration", "", "Enable/disable login expiration (true/false, requires --update)")
 	inactivityExpFlag := peerCmd.String("inactivity-expiration", "", "Enable/disable inactivity expiration (true/false, requires --update)")
@@ -40,7 +47,21 @@ func (s *Service) HandlePeersCommand(args []string) error {
 	accessiblePeersFlag := peerCmd.String("accessible-peers", "", "List peers accessible from the specified peer ID")
 	filterNameFlag := peerCmd.String("filter-name", "", "Filter peers by name pattern (use with --list)")
 	filterIPFlag := peerCmd.String("filter-ip", "", "Filter peers by IP pattern (use with --list)")
-	outputFlag := peerCmd.String("output", "table", "Output format: table or json")
+	filterVersionFlag := peerCmd.String("filter-version", "", "Filter peers by client version, e.g. '<0.28.0' (<, <=, >, >=, ==, !=; use with --list)")
+	sortFlag := peerCmd.String("sort", "", "Sort by name, ip, last-seen, connected, version; '-' prefix for descending, comma-separated for multiple keys (use with --list)")
+	exportInventoryFlag := peerCmd.String("export-inventory", "", "Write all peers to a CSV asset report")
+	groupFlag := peerCmd.String("group", "", "Only include peers in these groups, comma-separated IDs or names (use with --list, --export-inventory, or --set-ssh)")
+	groupMatchFlag := peerCmd.String("group-match", "any", "With several --group values: any (member of one) or all (member of every one)")
+	filterConnectedFlag := peerCmd.String("filter-connected", "", "Filter peers by connection state: true or false (use with --list)")
+	lastSeenBeforeFlag := peerCmd.String("last-seen-before", "", "Only peers last seen before this time: RFC3339, YYYY-MM-DD, or a duration ago like 90d (use with --list)")
+	lastSeenAfterFlag := peerCmd.String("last-seen-after", "", "Only peers last seen after this time: RFC3339, YYYY-MM-DD, or a duration ago like 24h (use with --list)")
+	outputFlag := peerCmd.String("output", helpers.DefaultOutputFormat, "Output format: table, wide (list only), or json")
+
+	setSSHFlag := peerCmd.String("set-ssh", "", "Enable/disable SSH (true/false) on --peer, --group, or --all")
+	peerFlag := peerCmd.String("peer", "", "Peer ID or name to target (use with --set-ssh)")
+	allFlag := peerCmd.Bool("all", false, "Target every peer (use with --set-ssh)")
+	bulkUpdateCSVFlag := peerCmd.String("bulk-update-from-csv", "", "Update peers from a CSV file keyed by peer ID or name")
+	dryRunFlag := peerCmd.Bool("dry-run", false, "Show what --bulk-update-from-csv would change without updating")
 
 	if len(args) == 1 {
 		PrintPeerUsage()
@@ -52,11 +73,47 @@ func (s *Service) HandlePeersCommand(args []string) error {
 	}
 
 	if *listFlag {
-		return s.listPeers(*filterNameFlag, *filterIPFlag, *outputFlag)
+		sortKeys, err := parsePeerSortKeys(*sortFlag)
+		if err != nil {
+			return err
+		}
+		groupFilter, err := s.parsePeerGroupFilter(*groupFlag, *groupMatchFlag)
+		if err != nil {
+			return err
+		}
+		var versionFilter *peerFilterClause
+		if *filterVersionFlag != "" {
+			clause, err := parseVersionFilter(*filterVersionFlag)
+			if err != nil {
+				return err
+			}
+			versionFilter = &clause
+		}
+		activityFilter, err := parsePeerActivityFilter(*filterConnectedFlag, *lastSeenBeforeFlag, *lastSeenAfterFlag, time.Now())
+		if err != nil {
+			return err
+		}
+		return s.listPeers(*filterNameFlag, *filterIPFlag, versionFilter, groupFilter, activityFilter, sortKeys, *outputFlag)
+	}
+
+	if *bulkUpdateCSVFlag != "" {
+		return s.bulkUpdatePeersFromCSV(*bulkUpdateCSVFlag, *dryRunFlag)
+	}
+
+	if *setSSHFlag != "" {
+		groupFilter, err := s.parsePeerGroupFilter(*groupFlag, *groupMatchFlag)
+		if err != nil {
+			return err
+		}
+		return s.setPeersSSH(*setSSHFlag, *peerFlag, groupFilter, *allFlag)
+	}
+
+	if *exportInventoryFlag != "" {
+		return s.exportPeerInventory(*exportInventoryFlag, *groupFlag)
 	}
 
 	if *inspectFlag != "" {
-		return s.inspectPeer(*inspectFlag, *outputFlag)
+		return s.inspectPeer(*inspectFlag, *withRoutingFlag, *showPoliciesFlag, *outputFlag)
 	}
 
 	if *removeFlag != "" {
@@ -83,7 +140,18 @@ func (s *Service) HandlePeersCommand(args []string) error {
 	}
 
 	if *updateFlag != "" {
-		return s.handlePeerUpdate(*updateFlag, *renameFlag, *sshFlag, *loginExpFlag, *inactivityExpFlag, *approvalFlag, *ipFlag)
+		newName := *renameFlag
+		if *newNameFlag != "" {
+			newName = *newNameFlag
+		}
+		return s.handlePeerUpdate(*updateFlag, newName, *sshFlag, *loginExpFlag, *inactivityExpFlag, *approvalFlag, *ipFlag)
+	}
+
+	if *renameFlag != "" {
+		if *newNameFlag == "" {
+			return fmt.Errorf("--new-name is required with --rename")
+		}
+		return s.renamePeer(*renameFlag, *newNameFlag)
 	}
 
 	fmt.Fprintln(os.Stderr, "Error: Invalid or missing flags for 'peer' command.")
@@ -168,7 +236,242 @@ func (s *Service) handlePeerUpdate(peerID, rename, ssh, loginExp, inactivityExp,
 	return s.updatePeer(peerID, updateReq)
 }
 
-func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
+// renamePeer changes a peer's name while preserving all of its other settings
+func (s *Service) renamePeer(peerIdentifier, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("new peer name cannot be empty")
+	}
+
+	peerID, err := s.resolvePeerIdentifier(peerIdentifier)
+	if err != nil {
+		return err
+	}
+
+	peer, err := s.getPeerByID(peerID)
+	if err != nil {
+		return fmt.Errorf("failed to get peer: %v", err)
+	}
+
+	if peer.Name == newName {
+		fmt.Printf("Peer %s is already named '%s'\n", peer.ID, newName)
+		return nil
+	}
+
+	// Warn about duplicate names, which make name-based lookups ambiguous
+	peers, err := s.getAllPeers()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check for duplicate names: %v\n", err)
+	} else {
+		for _, other := range peers {
+			if other.ID != peer.ID && other.Name == newName {
+				fmt.Fprintf(os.Stderr, "Warning: another peer already has the name '%s' (ID: %s)\n", newName, other.ID)
+			}
+		}
+	}
+
+	updateReq := models.PeerUpdateRequest{
+		Name:                        newName,
+		SSHEnabled:                  peer.SSHEnabled,
+		LoginExpirationEnabled:      peer.LoginExpirationEnabled,
+		InactivityExpirationEnabled: peer.InactivityExpirationEnabled,
+		ApprovalRequired:            peer.ApprovalRequired,
+	}
+
+	if err := s.updatePeer(peer.ID, updateReq); err != nil {
+		return fmt.Errorf("failed to rename peer: %v", err)
+	}
+
+	fmt.Printf("Successfully renamed peer from '%s' to '%s'\n", peer.Name, newName)
+	return nil
+}
+
+// getAllPeers fetches every peer in the account
+func (s *Service) getAllPeers() ([]models.Peer, error) {
+	peers, err := client.GetList[models.Peer](s.Client, "/peers")
+	if err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// resolvePeerIdentifier resolves a peer ID or name to a peer ID
+func (s *Service) resolvePeerIdentifier(identifier string) (string, error) {
+	peer, err := s.getPeerByID(identifier)
+	if err == nil {
+		return peer.ID, nil
+	}
+
+	peers, err := s.getAllPeers()
+	if err != nil {
+		return "", err
+	}
+	return matchPeerIdentifier(identifier, peers)
+}
+
+// matchPeerIdentifier resolves a peer ID or exact name against an already fetched peer list
+func matchPeerIdentifier(identifier string, peers []models.Peer) (string, error) {
+	var matches []models.Peer
+	for _, p := range peers {
+		if p.ID == identifier {
+			return p.ID, nil
+		}
+		if p.Name == identifier {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("peer '%s' not found (tried as both ID and name)", identifier)
+	case 1:
+		return matches[0].ID, nil
+	default:
+		return "", fmt.Errorf("multiple peers are named '%s'; use the peer ID instead", identifier)
+	}
+}
+
+// resolvePeerName resolves a peer name or hostname to a peer ID, erroring if it is ambiguous
+func (s *Service) resolvePeerName(name string) (string, error) {
+	peers, err := s.getAllPeers()
+	if err != nil {
+		return "", err
+	}
+
+	var matches []models.Peer
+	for _, p := range peers {
+		if p.Name == name || p.Hostname == name {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no peer found with name or hostname '%s'", name)
+	case 1:
+		return matches[0].ID, nil
+	default:
+		ids := make([]string, len(matches))
+		for i, p := range matches {
+			ids[i] = fmt.Sprintf("%s (%s)", p.ID, p.Name)
+		}
+		return "", fmt.Errorf("'%s' matches %d peers: %s; use --peer with the peer ID instead", name, len(matches), strings.Join(ids, ", "))
+	}
+}
+
+// peerGroupFilter restricts a peer list to members of one or more groups
+type peerGroupFilter struct {
+	GroupIDs []string
+	MatchAll bool // true: member of every group; false: member of at least one
+}
+
+// parsePeerGroupFilter resolves comma-separated group IDs or names for "peer --list --group"
+func (s *Service) parsePeerGroupFilter(groups, match string) (peerGroupFilter, error) {
+	var filter peerGroupFilter
+	switch match {
+	case "any":
+	case "all":
+		filter.MatchAll = true
+	default:
+		return filter, fmt.Errorf("invalid --group-match value '%s': must be any or all", match)
+	}
+
+	for _, identifier := range helpers.SplitCommaList(groups) {
+		groupID, err := s.resolveGroupIdentifier(identifier)
+		if err != nil {
+			return filter, err
+		}
+		filter.GroupIDs = append(filter.GroupIDs, groupID)
+	}
+	return filter, nil
+}
+
+// matches reports whether a peer satisfies the filter; an empty filter matches every peer
+func (f peerGroupFilter) matches(peer models.Peer) bool {
+	if len(f.GroupIDs) == 0 {
+		return true
+	}
+
+	memberOf := make(map[string]bool, len(peer.Groups))
+	for _, group := range peer.Groups {
+		memberOf[group.ID] = true
+	}
+	for _, groupID := range f.GroupIDs {
+		if memberOf[groupID] && !f.MatchAll {
+			return true
+		}
+		if !memberOf[groupID] && f.MatchAll {
+			return false
+		}
+	}
+	return f.MatchAll
+}
+
+// peerActivityFilter restricts a peer list by connection state and last-seen time
+type peerActivityFilter struct {
+	Connected      *bool     // nil: any state
+	LastSeenBefore time.Time // zero: no bound
+	LastSeenAfter  time.Time // zero: no bound
+}
+
+// parsePeerActivityFilter parses --filter-connected, --last-seen-before, and --last-seen-after
+func parsePeerActivityFilter(connected, before, after string, now time.Time) (peerActivityFilter, error) {
+	var filter peerActivityFilter
+	if connected != "" {
+		value, err := strconv.ParseBool(connected)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --filter-connected value '%s': must be true or false", connected)
+		}
+		filter.Connected = &value
+	}
+	if before != "" {
+		t, err := parseEventTime(before, now)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --last-seen-before '%s': %v", before, err)
+		}
+		filter.LastSeenBefore = t
+	}
+	if after != "" {
+		t, err := parseEventTime(after, now)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --last-seen-after '%s': %v", after, err)
+		}
+		filter.LastSeenAfter = t
+	}
+	if !filter.LastSeenBefore.IsZero() && !filter.LastSeenAfter.IsZero() && !filter.LastSeenAfter.Before(filter.LastSeenBefore) {
+		return filter, fmt.Errorf("--last-seen-after must be earlier than --last-seen-before")
+	}
+	return filter, nil
+}
+
+// active reports whether any activity filter is set
+func (f peerActivityFilter) active() bool {
+	return f.Connected != nil || !f.LastSeenBefore.IsZero() || !f.LastSeenAfter.IsZero()
+}
+
+// matches reports whether a peer satisfies the filter. Peers that were never seen (empty,
+// zero, or unparseable last_seen) count as infinitely old: they match --last-seen-before
+// and never match --last-seen-after.
+func (f peerActivityFilter) matches(peer models.Peer) bool {
+	if f.Connected != nil && peer.Connected != *f.Connected {
+		return false
+	}
+	if f.LastSeenBefore.IsZero() && f.LastSeenAfter.IsZero() {
+		return true
+	}
+
+	lastSeen, err := time.Parse(time.RFC3339, peer.LastSeen)
+	never := err != nil || lastSeen.Year() <= 1
+	if !f.LastSeenBefore.IsZero() && !never && !lastSeen.Before(f.LastSeenBefore) {
+		return false
+	}
+	if !f.LastSeenAfter.IsZero() && (never || !lastSeen.After(f.LastSeenAfter)) {
+		return false
+	}
+	return true
+}
+
+func (s *Service) listPeers(filterName, filterIP string, versionFilter *peerFilterClause, groupFilter peerGroupFilter, activityFilter peerActivityFilter, sortKeys []peerSortKey, outputFormat string) error {
 	// Build query parameters for server-side filtering
 	params := url.Values{}
 	if filterName != "" {
@@ -196,6 +499,7 @@ func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
 
 	// Apply additional local filtering for pattern matching (server does exact match)
 	var filteredPeers []models.Peer
+	unversioned := 0
 	for _, peer := range peers {
 		if filterName != "" && !helpers.MatchesPattern(peer.Name, filterName) {
 			continue
@@ -203,11 +507,30 @@ func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
 		if filterIP != "" && !helpers.MatchesPattern(peer.IP, filterIP) {
 			continue
 		}
+		if !groupFilter.matches(peer) {
+			continue
+		}
+		if !activityFilter.matches(peer) {
+			continue
+		}
+		if versionFilter != nil {
+			if _, ok := client.ParseVersion(peer.Version); !ok {
+				unversioned++
+				continue
+			}
+			if !versionFilter.matches(peer) {
+				continue
+			}
+		}
 		filteredPeers = append(filteredPeers, peer)
 	}
 
+	if unversioned > 0 {
+		fmt.Fprintf(os.Stderr, "Note: excluded %d peer(s) whose version is not a comparable release (e.g. development builds)\n", unversioned)
+	}
+
 	if len(filteredPeers) == 0 {
-		if filterName != "" || filterIP != "" {
+		if filterName != "" || filterIP != "" || versionFilter != nil || len(groupFilter.GroupIDs) > 0 || activityFilter.active() {
 			fmt.Println("No peers found matching the specified filters.")
 		} else {
 			fmt.Println("No peers found in your network.")
@@ -215,9 +538,11 @@ func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
 		return nil
 	}
 
+	sortPeers(filteredPeers, sortKeys)
+
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filteredPeers, "", "  ")
+		output, err := helpers.MarshalJSONOutput(filteredPeers)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -225,17 +550,34 @@ func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
 		return nil
 	}
 
-	// Table output (default)
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tIP\tCONNECTED\tOS\tVERSION\tHOSTNAME")
-	fmt.Fprintln(w, "--\t----\t--\t---------\t--\t-------\t--------")
-
+	// Template output
+	if helpers.OutputTemplate != "" {
+		return helpers.RenderTemplate(filteredPeers)
+	}
+
+	// Table output (default); --output wide adds settings columns
+	table := helpers.NewTable(outputFormat,
+		helpers.TableColumn{Header: "ID"},
+		helpers.TableColumn{Header: "NAME"},
+		helpers.TableColumn{Header: "IP"},
+		helpers.TableColumn{Header: "CONNECTED"},
+		helpers.TableColumn{Header: "OS"},
+		helpers.TableColumn{Header: "VERSION"},
+		helpers.TableColumn{Header: "HOSTNAME"},
+		helpers.TableColumn{Header: "SSH", Wide: true},
+		helpers.TableColumn{Header: "LOGIN EXPIRATION", Wide: true},
+		helpers.TableColumn{Header: "INACTIVITY EXPIRATION", Wide: true},
+		helpers.TableColumn{Header: "GROUPS", Wide: true},
+		helpers.TableColumn{Header: "LAST SEEN", Wide: true},
+	)
+
+	now := time.Now()
 	for _, peer := range filteredPeers {
 		connectedStatus := "Offline"
 		if peer.Connected {
 			connectedStatus = "Online"
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		table.Row(
 			peer.ID,
 			peer.Name,
 			peer.IP,
@@ -243,12 +585,102 @@ func (s *Service) listPeers(filterName, filterIP, outputFormat string) error {
 			helpers.FormatOS(peer.OS),
 			peer.Version,
 			peer.Hostname,
+			strconv.FormatBool(peer.SSHEnabled),
+			strconv.FormatBool(peer.LoginExpirationEnabled),
+			strconv.FormatBool(peer.InactivityExpirationEnabled),
+			strconv.Itoa(len(peer.Groups)),
+			helpers.FormatLastSeen(peer.LastSeen, now),
 		)
 	}
-	w.Flush()
+	table.Flush()
 	return nil
 }
 
+// peerSortKey is a single --sort key
+type peerSortKey struct {
+	field      string
+	descending bool
+}
+
+// parsePeerSortKeys parses a --sort value like "connected,-last-seen"
+func parsePeerSortKeys(spec string) ([]peerSortKey, error) {
+	var keys []peerSortKey
+	for _, part := range helpers.SplitCommaList(spec) {
+		key := peerSortKey{field: strings.ToLower(part)}
+		if strings.HasPrefix(key.field, "-") {
+			key.descending = true
+			key.field = strings.TrimPrefix(key.field, "-")
+		}
+		switch key.field {
+		case "name", "ip", "last-seen", "connected", "version":
+		default:
+			return nil, fmt.Errorf("invalid --sort key '%s' (valid: name, ip, last-seen, connected, version)", part)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortPeers sorts peers in place by the given keys; earlier keys take precedence
+func sortPeers(peers []models.Peer, keys []peerSortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		for _, key := range keys {
+			cmp := comparePeers(peers[i], peers[j], key.field)
+			if cmp == 0 {
+				continue
+			}
+			if key.descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// comparePeers compares two peers by a single sort field, returning -1, 0, or 1
+func comparePeers(a, b models.Peer, field string) int {
+	switch field {
+	case "name":
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case "ip":
+		return bytes.Compare(net.ParseIP(a.IP).To16(), net.ParseIP(b.IP).To16())
+	case "last-seen":
+		// Chronological order; unparseable timestamps sort as oldest
+		ta, _ := time.Parse(time.RFC3339, a.LastSeen)
+		tb, _ := time.Parse(time.RFC3339, b.LastSeen)
+		return ta.Compare(tb)
+	case "connected":
+		// Online peers first in ascending order
+		if a.Connected == b.Connected {
+			return 0
+		}
+		if a.Connected {
+			return -1
+		}
+		return 1
+	case "version":
+		// Oldest release first; development or unknown builds sort last
+		_, okA := client.ParseVersion(a.Version)
+		_, okB := client.ParseVersion(b.Version)
+		switch {
+		case okA && okB:
+			cmp, _ := client.CompareVersions(a.Version, b.Version)
+			return cmp
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return strings.Compare(a.Version, b.Version)
+	}
+	return 0
+}
+
 func (s *Service) getPeerByID(peerID string) (*models.Peer, error) {
 	endpoint := "/peers/" + peerID
 	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
@@ -357,15 +789,40 @@ func (s *Service) removePeersBatch(idList string) error {
 	return nil
 }
 
-func (s *Service) inspectPeer(peerID, outputFormat string) error {
+func (s *Service) inspectPeer(peerID string, withRouting, showPolicies bool, outputFormat string) error {
 	peer, err := s.getPeerByID(peerID)
 	if err != nil {
 		return err
 	}
 
-	// JSON output
+	var routing *peerRouting
+	if withRouting {
+		routing, err = s.getPeerRouting(peer)
+		if err != nil {
+			return err
+		}
+	}
+
+	var policies []peerPolicy
+	if showPolicies {
+		policies, err = s.getPeerPolicies(peer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// JSON output; --with-routing adds a "routing" object and --show-policies a "policies"
+	// array alongside the peer fields
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(peer, "", "  ")
+		var value interface{} = peer
+		if routing != nil || policies != nil {
+			value = struct {
+				*models.Peer
+				Routing  *peerRouting `json:"routing,omitempty"`
+				Policies []peerPolicy `json:"policies,omitzero"`
+			}{peer, routing, policies}
+		}
+		output, err := helpers.MarshalJSONOutput(value)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -381,7 +838,11 @@ func (s *Service) inspectPeer(peerID, outputFormat string) error {
 	fmt.Printf("  OS:          %s\n", helpers.FormatOS(peer.OS))
 	fmt.Printf("  Version:     %s\n", peer.Version)
 	fmt.Printf("  Connected:   %t\n", peer.Connected)
-	fmt.Printf("  Last Seen:   %s\n", peer.LastSeen)
+	if lastSeen := helpers.FormatLastSeen(peer.LastSeen, time.Now()); lastSeen == "never" || lastSeen == peer.LastSeen {
+		fmt.Printf("  Last Seen:   %s\n", lastSeen)
+	} else {
+		fmt.Printf("  Last Seen:   %s (%s)\n", lastSeen, peer.LastSeen)
+	}
 
 	if len(peer.Groups) > 0 {
 		fmt.Println("  Groups:")
@@ -391,6 +852,13 @@ func (s *Service) inspectPeer(peerID, outputFormat string) error {
 	} else {
 		fmt.Println("  Groups:      None")
 	}
+
+	if routing != nil {
+		printPeerRouting(routing)
+	}
+	if policies != nil {
+		printPeerPolicies(policies)
+	}
 	return nil
 }
 
@@ -398,7 +866,7 @@ func (s *Service) modifyPeerGroup(peerID, groupIdentifier, action string) error
 	if groupIdentifier == "" {
 		fmt.Println("Error: No group identifier specified.")
 		fmt.Println("Listing available groups:")
-		if err := s.listGroups("", "table"); err != nil {
+		if err := s.listGroups("", false, false, false, "table"); err != nil {
 			fmt.Fprintf(os.Stderr, "Could not list groups: %v\n", err)
 		}
 		return fmt.Errorf("missing <group-id> or <group-name> argument for --add-group or --remove-group")
@@ -476,6 +944,16 @@ func (s *Service) updatePeer(peerID string, updates models.PeerUpdateRequest) er
 		}
 	}
 
+	if err := s.putPeer(peerID, updates); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully updated peer %s\n", peerID)
+	return nil
+}
+
+// putPeer sends a PeerUpdateRequest without printing, for callers that report progress themselves
+func (s *Service) putPeer(peerID string, updates models.PeerUpdateRequest) error {
 	payload, err := json.Marshal(updates)
 	if err != nil {
 		return fmt.Errorf("failed to marshal update request: %v", err)
@@ -486,9 +964,7 @@ func (s *Service) updatePeer(peerID string, updates models.PeerUpdateRequest) er
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	fmt.Printf("Successfully updated peer %s\n", peerID)
+	resp.Body.Close()
 	return nil
 }
 
@@ -516,7 +992,7 @@ func (s *Service) getAccessiblePeers(peerID, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(accessiblePeers, "", "  ")
+		output, err := helpers.MarshalJSONOutput(accessiblePeers)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -528,8 +1004,7 @@ func (s *Service) getAccessiblePeers(peerID, outputFormat string) error {
 	fmt.Printf("Peers accessible from %s:\n\n", peerID)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tIP\tCONNECTED\tOS\tHOSTNAME")
-	fmt.Fprintln(w, "--\t----\t--\t---------\t--\t--------")
+	helpers.TableHeader(w, "ID", "NAME", "IP", "CONNECTED", "OS", "HOSTNAME")
 
 	for _, peer := range accessiblePeers {
 		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n",
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -46,7 +47,13 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 	// Define the flags for the 'policy' command
 	listFlag := policyCmd.Bool("list", false, "List all policies")
 	inspectFlag := policyCmd.String("inspect", "", "Inspect a specific policy by ID")
+	matrixFlag := policyCmd.Bool("matrix", false, "With --inspect: show the effective source/destination access matrix")
+	validateFlag := policyCmd.String("validate", "", "Check a policy by ID for disabled, duplicate, conflicting, or broken rules")
+	includeDisabledFlag := policyCmd.Bool("include-disabled", false, "With --matrix: include disabled rules")
 	createFlag := policyCmd.String("create", "", "Create a new policy with the given name")
+	createFromFileFlag := policyCmd.String("create-from-file", "", "Create one policy with all its rules from a YAML/JSON file")
+	exportFlag := policyCmd.String("export", "", "Write a policy by ID to a single-policy YAML/JSON file (file path follows the ID; stdout if omitted)")
+	importFlag := policyCmd.String("import", "", "Create a policy from a single-policy file written by --export (same as --create-from-file)")
 	deleteFlag := policyCmd.String("delete", "", "Delete a policy by ID")
 	enableFlag := policyCmd.String("enable", "", "Enable a policy by ID")
 	disableFlag := policyCmd.String("disable", "", "Disable a policy by ID")
@@ -64,10 +71,17 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 	addRuleFlag := policyCmd.String("add-rule", "", "Add a rule to a policy (requires --policy-id)")
 	editRuleFlag := policyCmd.String("edit-rule", "", "Edit a rule by name or ID (requires --policy-id)")
 	removeRuleFlag := policyCmd.String("remove-rule", "", "Remove a rule by name or ID (requires --policy-id)")
+	enableRuleFlag := policyCmd.String("enable-rule", "", "Enable a single rule by name or ID (requires --policy-id)")
+	disableRuleFlag := policyCmd.String("disable-rule", "", "Disable a single rule by name or ID (requires --policy-id)")
+	toggleRuleFlag := policyCmd.String("toggle-rule", "", "Flip a single rule's enabled state by name or ID (requires --policy-id)")
+	moveRuleFlag := policyCmd.String("move-rule", "", "Move a rule by name or ID to a new position (requires --policy-id)")
+	toFlag := policyCmd.Int("to", 0, "Target position for --move-rule (1-based)")
+	beforeFlag := policyCmd.String("before", "", "Place the moved rule before this rule (name or ID)")
+	afterFlag := policyCmd.String("after", "", "Place the moved rule after this rule (name or ID)")
 	policyIDFlag := policyCmd.String("policy-id", "", "Target policy ID for rule operations")
 
 	// Output format flag
-	outputFlag := policyCmd.String("output", "table", "Output format: table or json")
+	outputFlag := policyCmd.String("output", helpers.DefaultOutputFormat, "Output format: table, wide (list only), or json")
 
 	// Rule configuration flags
 	ruleNameFlag := policyCmd.String("rule-name", "", "Rule name")
@@ -80,6 +94,7 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 	portRangeFlag := policyCmd.String("port-range", "", "Port range (e.g., 6000-6100)")
 	bidirectionalFlag := policyCmd.Bool("bidirectional", false, "Apply rule in both directions")
 	ruleEnabledFlag := policyCmd.Bool("rule-enabled", true, "Enable the rule (default: true)")
+	allowDuplicateNamesFlag := policyCmd.Bool("allow-duplicate-names", false, "With --add-rule or --edit-rule --rule-name: allow a rule name already used in the policy")
 
 	// If no flags are provided (just 'netbird-manage policy'), show usage
 	if len(args) == 1 {
@@ -94,6 +109,22 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 
 	// Handle the flags in priority order
 
+	// Export a single policy for use in another account
+	if *exportFlag != "" {
+		if policyCmd.NArg() > 1 {
+			return fmt.Errorf("--export takes one output file, got %d arguments", policyCmd.NArg())
+		}
+		return s.exportPolicyToFile(*exportFlag, policyCmd.Arg(0))
+	}
+
+	// Create a complete policy from a file
+	if *createFromFileFlag != "" {
+		return s.createPolicyFromFile(*createFromFileFlag)
+	}
+	if *importFlag != "" {
+		return s.createPolicyFromFile(*importFlag)
+	}
+
 	// Create policy
 	if *createFlag != "" {
 		// Parse enabled flag (default to true if not provided)
@@ -149,7 +180,7 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 		if *sourcesFlag == "" || *destinationsFlag == "" {
 			return fmt.Errorf("--sources and --destinations are required when adding a rule")
 		}
-		return s.addRuleToPolicy(*policyIDFlag, *addRuleFlag, &ruleConfig{
+		return s.addRuleToPolicy(*policyIDFlag, *addRuleFlag, *allowDuplicateNamesFlag, &ruleConfig{
 			Description:   *ruleDescFlag,
 			Action:        *actionFlag,
 			Protocol:      *protocolFlag,
@@ -167,7 +198,7 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 		if *policyIDFlag == "" {
 			return fmt.Errorf("--policy-id is required when editing a rule")
 		}
-		return s.editRule(*policyIDFlag, *editRuleFlag, &ruleConfig{
+		return s.editRule(*policyIDFlag, *editRuleFlag, *allowDuplicateNamesFlag, &ruleConfig{
 			Name:          *ruleNameFlag,
 			Description:   *ruleDescFlag,
 			Action:        *actionFlag,
@@ -189,8 +220,40 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 		return s.removeRuleFromPolicy(*policyIDFlag, *removeRuleFlag)
 	}
 
+	// Reorder a rule
+	if *moveRuleFlag != "" {
+		if *policyIDFlag == "" {
+			return fmt.Errorf("--policy-id is required when moving a rule")
+		}
+		return s.moveRule(*policyIDFlag, *moveRuleFlag, *toFlag, *beforeFlag, *afterFlag)
+	}
+
+	// Enable, disable, or toggle a single rule
+	if *enableRuleFlag != "" || *disableRuleFlag != "" || *toggleRuleFlag != "" {
+		if *policyIDFlag == "" {
+			return fmt.Errorf("--policy-id is required when changing a rule's enabled state")
+		}
+		enabled, disabled := true, false
+		switch {
+		case *enableRuleFlag != "":
+			return s.setRuleEnabled(*policyIDFlag, *enableRuleFlag, &enabled)
+		case *disableRuleFlag != "":
+			return s.setRuleEnabled(*policyIDFlag, *disableRuleFlag, &disabled)
+		default:
+			return s.setRuleEnabled(*policyIDFlag, *toggleRuleFlag, nil)
+		}
+	}
+
+	// Validate policy rules
+	if *validateFlag != "" {
+		return s.validatePolicy(*validateFlag)
+	}
+
 	// Inspect policy
 	if *inspectFlag != "" {
+		if *matrixFlag {
+			return s.inspectPolicyMatrix(*inspectFlag, *includeDisabledFlag, *outputFlag)
+		}
 		return s.inspectPolicy(*inspectFlag, *outputFlag)
 	}
 
@@ -212,16 +275,10 @@ func (s *Service) HandlePoliciesCommand(args []string) error {
 
 // listPolicies implements the "policy --list" command
 func (s *Service) listPolicies(filters *policyFilters, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/policies", nil)
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var policies []models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
-		return fmt.Errorf("failed to decode policies response: %v", err)
-	}
 
 	// Apply filters
 	var filteredPolicies []models.Policy
@@ -249,7 +306,7 @@ func (s *Service) listPolicies(filters *policyFilters, outputFormat string) erro
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filteredPolicies, "", "  ")
+		output, err := helpers.MarshalJSONOutput(filteredPolicies)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -257,17 +314,28 @@ func (s *Service) listPolicies(filters *policyFilters, outputFormat string) erro
 		return nil
 	}
 
-	// Print a formatted table
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tENABLED\tRULES\tDESCRIPTION")
-	fmt.Fprintln(w, "--\t----\t-------\t-----\t-----------")
+	// Template output
+	if helpers.OutputTemplate != "" {
+		return helpers.RenderTemplate(filteredPolicies)
+	}
+
+	// Print a formatted table; --output wide adds the posture check count
+	table := helpers.NewTable(outputFormat,
+		helpers.TableColumn{Header: "ID"},
+		helpers.TableColumn{Header: "NAME"},
+		helpers.TableColumn{Header: "ENABLED"},
+		helpers.TableColumn{Header: "RULES"},
+		helpers.TableColumn{Header: "POSTURE CHECKS", Wide: true},
+		helpers.TableColumn{Header: "DESCRIPTION"},
+	)
 
 	for _, pol := range filteredPolicies {
-		fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%s\n",
+		table.Row(
 			pol.ID,
 			pol.Name,
-			pol.Enabled,
-			len(pol.Rules),
+			strconv.FormatBool(pol.Enabled),
+			strconv.Itoa(len(pol.Rules)),
+			strconv.Itoa(len(pol.SourcePostureChecks)),
 			pol.Description,
 		)
 		// Print rules
@@ -277,36 +345,29 @@ func (s *Service) listPolicies(filters *policyFilters, outputFormat string) erro
 			if rule.Bidirectional {
 				bidir = " (bidirectional)"
 			}
-			fmt.Fprintf(w, "\t  -> %s\t%s\t%s%s\t%s -> %s\n",
-				rule.Name,
+			table.Line(
+				"",
+				"  -> "+rule.Name,
 				rule.Action,
-				rule.Protocol,
-				ports,
-				getGroupNames(rule.Sources),
-				getGroupNames(rule.Destinations)+bidir,
+				rule.Protocol+ports,
+				getGroupNames(rule.Sources)+" -> "+getGroupNames(rule.Destinations)+bidir,
 			)
 		}
 	}
-	w.Flush()
+	table.Flush()
 	return nil
 }
 
 // inspectPolicy implements the "policy --inspect" command
 func (s *Service) inspectPolicy(policyID, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/policies/"+policyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var policy models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
-		return fmt.Errorf("failed to decode policy response: %v", err)
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(policy, "", "  ")
+		output, err := helpers.MarshalJSONOutput(policy)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -364,6 +425,147 @@ func (s *Service) inspectPolicy(policyID, outputFormat string) error {
 	return nil
 }
 
+// policyFlow is one directed row of a policy's effective access matrix
+type policyFlow struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Protocol    string `json:"protocol"`
+	Ports       string `json:"ports"`
+	Action      string `json:"action"`
+	Rule        string `json:"rule"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// inspectPolicyMatrix implements "policy --inspect <id> --matrix"
+func (s *Service) inspectPolicyMatrix(policyID string, includeDisabled bool, outputFormat string) error {
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
+	}
+
+	flows := buildPolicyFlows(policy.Rules, includeDisabled)
+
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(flows)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Access Matrix: %s", policy.Name)
+	if !policy.Enabled {
+		fmt.Print(" (policy disabled)")
+	}
+	fmt.Println()
+	fmt.Println()
+
+	if len(flows) == 0 {
+		if includeDisabled {
+			fmt.Println("No rules defined")
+		} else {
+			fmt.Println("No enabled rules (use --include-disabled to show disabled rules)")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if includeDisabled {
+		helpers.TableHeader(w, "SOURCE", "DESTINATION", "PROTOCOL/PORTS", "ACTION", "RULE", "ENABLED")
+	} else {
+		helpers.TableHeader(w, "SOURCE", "DESTINATION", "PROTOCOL/PORTS", "ACTION", "RULE")
+	}
+	for _, flow := range flows {
+		protoPorts := flow.Protocol
+		if flow.Ports != "" {
+			protoPorts += "/" + flow.Ports
+		}
+		if includeDisabled {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n", flow.Source, flow.Destination, protoPorts, flow.Action, flow.Rule, flow.Enabled)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", flow.Source, flow.Destination, protoPorts, flow.Action, flow.Rule)
+		}
+	}
+	w.Flush()
+	return nil
+}
+
+// buildPolicyFlows expands rules into one row per source/destination pair.
+// Bidirectional rules produce a row for each direction; duplicate rows are dropped.
+func buildPolicyFlows(rules []models.PolicyRule, includeDisabled bool) []policyFlow {
+	var flows []policyFlow
+	seen := make(map[policyFlow]bool)
+	add := func(flow policyFlow) {
+		if !seen[flow] {
+			seen[flow] = true
+			flows = append(flows, flow)
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.Enabled && !includeDisabled {
+			continue
+		}
+
+		sources := policyFlowEndpoints(rule.Sources, rule.SourceResource)
+		destinations := policyFlowEndpoints(rule.Destinations, rule.DestinationResource)
+		protocol := rule.Protocol
+		if protocol == "" {
+			protocol = "all"
+		}
+		ports := policyRulePorts(rule)
+
+		for _, src := range sources {
+			for _, dst := range destinations {
+				flow := policyFlow{
+					Source:      src,
+					Destination: dst,
+					Protocol:    protocol,
+					Ports:       ports,
+					Action:      rule.Action,
+					Rule:        rule.Name,
+					Enabled:     rule.Enabled,
+				}
+				add(flow)
+				if rule.Bidirectional {
+					flow.Source, flow.Destination = dst, src
+					add(flow)
+				}
+			}
+		}
+	}
+	return flows
+}
+
+// policyFlowEndpoints returns display names for a rule side: its groups, or its resource
+func policyFlowEndpoints(groups []models.PolicyGroup, resource *models.PolicyResource) []string {
+	var names []string
+	for _, g := range groups {
+		name := g.Name
+		if name == "" {
+			name = g.ID
+		}
+		names = append(names, name)
+	}
+	if resource != nil && resource.ID != "" {
+		names = append(names, fmt.Sprintf("%s:%s", resource.Type, resource.ID))
+	}
+	if len(names) == 0 {
+		names = append(names, "[All]")
+	}
+	return names
+}
+
+// policyRulePorts formats a rule's ports and port ranges as one comma-separated list
+func policyRulePorts(rule models.PolicyRule) string {
+	ports := append([]string{}, rule.Ports...)
+	for _, pr := range rule.PortRanges {
+		ports = append(ports, fmt.Sprintf("%d-%d", pr.Start, pr.End))
+	}
+	return strings.Join(ports, ",")
+}
+
 // createPolicy implements the "policy --create" command (deprecated - use createPolicyWithRule)
 func (s *Service) createPolicy(name, description string, enabled bool) error {
 	reqBody := models.PolicyCreateRequest{
@@ -511,15 +713,9 @@ func (s *Service) deletePolicy(policyID string) error {
 // togglePolicy enables or disables a policy
 func (s *Service) togglePolicy(policyID string, enable bool) error {
 	// First, get the current policy
-	resp, err := s.Client.MakeRequest("GET", "/policies/"+policyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var policy models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
-		return fmt.Errorf("failed to decode policy: %v", err)
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
 	}
 
 	// Update the enabled status
@@ -554,17 +750,18 @@ func (s *Service) togglePolicy(policyID string, enable bool) error {
 }
 
 // addRuleToPolicy implements the "policy --add-rule" command
-func (s *Service) addRuleToPolicy(policyID, ruleName string, config *ruleConfig) error {
+func (s *Service) addRuleToPolicy(policyID, ruleName string, allowDuplicateNames bool, config *ruleConfig) error {
 	// First, get the current policy
-	resp, err := s.Client.MakeRequest("GET", "/policies/"+policyID, nil)
-	if err != nil {
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var policy models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
-		return fmt.Errorf("failed to decode policy: %v", err)
+	// Name-based --edit-rule/--remove-rule would be ambiguous with duplicate names
+	if !allowDuplicateNames {
+		if err := checkRuleNameAvailable(policy, ruleName, ""); err != nil {
+			return err
+		}
 	}
 
 	// Build the new rule
@@ -604,18 +801,25 @@ func (s *Service) addRuleToPolicy(policyID, ruleName string, config *ruleConfig)
 	return nil
 }
 
-// editRule implements the "policy --edit-rule" command
-func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig) error {
-	// First, get the current policy
-	resp, err := s.Client.MakeRequest("GET", "/policies/"+policyID, nil)
-	if err != nil {
-		return err
+// checkRuleNameAvailable returns an error if the policy already has a rule with this name.
+// Rules are looked up by exact name, so names differing only in case do not clash.
+// exceptRuleID skips the rule being renamed, so keeping its own name is allowed.
+func checkRuleNameAvailable(policy models.Policy, ruleName, exceptRuleID string) error {
+	for _, rule := range policy.Rules {
+		if rule.Name == ruleName && (exceptRuleID == "" || rule.ID != exceptRuleID) {
+			return fmt.Errorf("policy '%s' already has a rule named '%s' (ID: %s); choose another name or pass --allow-duplicate-names",
+				policy.Name, ruleName, rule.ID)
+		}
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
+// editRule implements the "policy --edit-rule" command
+func (s *Service) editRule(policyID, ruleIdentifier string, allowDuplicateNames bool, config *ruleConfig) error {
+	// First, get the current policy
 	var policy models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
-		return fmt.Errorf("failed to decode policy: %v", err)
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
 	}
 
 	// Find the rule by name or ID
@@ -635,6 +839,11 @@ func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig)
 	existingRule := &policy.Rules[ruleIndex]
 
 	if config.Name != "" {
+		if !allowDuplicateNames {
+			if err := checkRuleNameAvailable(policy, config.Name, existingRule.ID); err != nil {
+				return err
+			}
+		}
 		existingRule.Name = config.Name
 	}
 	if config.Description != "" {
@@ -644,6 +853,9 @@ func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig)
 		existingRule.Action = config.Action
 	}
 	if config.Protocol != "" {
+		if err := validateRuleProtocol(config.Protocol); err != nil {
+			return err
+		}
 		existingRule.Protocol = config.Protocol
 	}
 	if config.Sources != "" {
@@ -661,7 +873,11 @@ func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig)
 		existingRule.Destinations = destGroups
 	}
 	if config.Ports != "" {
-		existingRule.Ports = strings.Split(config.Ports, ",")
+		ports, err := parseRulePorts(config.Ports)
+		if err != nil {
+			return err
+		}
+		existingRule.Ports = ports
 	}
 	if config.PortRange != "" {
 		portRange, err := parsePortRange(config.PortRange)
@@ -673,6 +889,11 @@ func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig)
 	existingRule.Bidirectional = config.Bidirectional
 	existingRule.Enabled = config.Enabled
 
+	// Check the merged rule, so switching to icmp or all is caught when the rule keeps its ports
+	if err := validateRuleProtocolPorts(existingRule); err != nil {
+		return err
+	}
+
 	// Send the update
 	updateReq := models.PolicyUpdateRequest{
 		Name:                policy.Name,
@@ -700,15 +921,9 @@ func (s *Service) editRule(policyID, ruleIdentifier string, config *ruleConfig)
 // removeRuleFromPolicy implements the "policy --remove-rule" command
 func (s *Service) removeRuleFromPolicy(policyID, ruleIdentifier string) error {
 	// First, get the current policy
-	resp, err := s.Client.MakeRequest("GET", "/policies/"+policyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var policy models.Policy
-	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
-		return fmt.Errorf("failed to decode policy: %v", err)
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
 	}
 
 	// Find and remove the rule by name or ID
@@ -753,6 +968,175 @@ func (s *Service) removeRuleFromPolicy(policyID, ruleIdentifier string) error {
 	return nil
 }
 
+// setRuleEnabled changes only the Enabled flag of a single rule, leaving the rest of the policy untouched.
+// A nil enabled value flips the rule's current state.
+func (s *Service) setRuleEnabled(policyID, ruleIdentifier string, enabled *bool) error {
+	// First, get the current policy
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
+	}
+
+	// Find the rule by name or ID
+	ruleIndex := -1
+	for i, rule := range policy.Rules {
+		if rule.ID == ruleIdentifier || rule.Name == ruleIdentifier {
+			ruleIndex = i
+			break
+		}
+	}
+
+	if ruleIndex == -1 {
+		return fmt.Errorf("rule '%s' not found in policy", ruleIdentifier)
+	}
+
+	rule := &policy.Rules[ruleIndex]
+	newState := !rule.Enabled
+	if enabled != nil {
+		newState = *enabled
+	}
+
+	status := "enabled"
+	if !newState {
+		status = "disabled"
+	}
+
+	if rule.Enabled == newState {
+		fmt.Printf("Rule '%s' in policy '%s' is already %s\n", rule.Name, policy.Name, status)
+		return nil
+	}
+	rule.Enabled = newState
+
+	// Send the update
+	updateReq := models.PolicyUpdateRequest{
+		Name:                policy.Name,
+		Description:         policy.Description,
+		Enabled:             policy.Enabled,
+		Rules:               cleanRulesForUpdate(policy.Rules),
+		SourcePostureChecks: policy.SourcePostureChecks,
+	}
+
+	bodyBytes, err := json.Marshal(updateReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp2, err := s.Client.MakeRequest("PUT", "/policies/"+policyID, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp2.Body.Close()
+
+	fmt.Printf("Rule '%s' in policy '%s' %s successfully\n", rule.Name, policy.Name, status)
+	return nil
+}
+
+// moveRule reorders a rule within a policy. The target is a 1-based position (--to)
+// or a position relative to another rule (--before/--after); all rules are otherwise unchanged.
+func (s *Service) moveRule(policyID, ruleIdentifier string, to int, before, after string) error {
+	targets := 0
+	for _, set := range []bool{to != 0, before != "", after != ""} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("--move-rule requires exactly one of --to, --before, or --after")
+	}
+
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
+	}
+
+	from := findPolicyRuleIndex(policy.Rules, ruleIdentifier)
+	if from == -1 {
+		return fmt.Errorf("rule '%s' not found in policy", ruleIdentifier)
+	}
+	rule := policy.Rules[from]
+
+	// Remove the rule, then compute the insert position among the remaining rules
+	remaining := make([]models.PolicyRule, 0, len(policy.Rules)-1)
+	remaining = append(remaining, policy.Rules[:from]...)
+	remaining = append(remaining, policy.Rules[from+1:]...)
+
+	var insertAt int
+	switch {
+	case to != 0:
+		if to < 1 || to > len(policy.Rules) {
+			return fmt.Errorf("invalid --to %d: must be between 1 and %d", to, len(policy.Rules))
+		}
+		insertAt = to - 1
+	default:
+		reference := before
+		if after != "" {
+			reference = after
+		}
+		refIndex := findPolicyRuleIndex(remaining, reference)
+		if refIndex == -1 {
+			if findPolicyRuleIndex(policy.Rules, reference) == from {
+				return fmt.Errorf("cannot move rule '%s' relative to itself", rule.Name)
+			}
+			return fmt.Errorf("rule '%s' not found in policy", reference)
+		}
+		insertAt = refIndex
+		if after != "" {
+			insertAt++
+		}
+	}
+
+	if insertAt == from {
+		fmt.Printf("Rule '%s' is already at position %d in policy '%s'\n", rule.Name, from+1, policy.Name)
+		return nil
+	}
+
+	reordered := make([]models.PolicyRule, 0, len(policy.Rules))
+	reordered = append(reordered, remaining[:insertAt]...)
+	reordered = append(reordered, rule)
+	reordered = append(reordered, remaining[insertAt:]...)
+
+	// Send the update
+	updateReq := models.PolicyUpdateRequest{
+		Name:                policy.Name,
+		Description:         policy.Description,
+		Enabled:             policy.Enabled,
+		Rules:               cleanRulesForUpdate(reordered),
+		SourcePostureChecks: policy.SourcePostureChecks,
+	}
+
+	bodyBytes, err := json.Marshal(updateReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := s.Client.MakeRequest("PUT", "/policies/"+policyID, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	fmt.Printf("Moved rule '%s' from position %d to %d in policy '%s'\n", rule.Name, from+1, insertAt+1, policy.Name)
+	fmt.Println("\nRule order:")
+	for i, r := range reordered {
+		marker := " "
+		if i == insertAt {
+			marker = "*"
+		}
+		fmt.Printf(" %s %d. %s\n", marker, i+1, r.Name)
+	}
+	return nil
+}
+
+// findPolicyRuleIndex returns the index of the rule matching an ID or name, or -1
+func findPolicyRuleIndex(rules []models.PolicyRule, identifier string) int {
+	for i, rule := range rules {
+		if rule.ID == identifier || rule.Name == identifier {
+			return i
+		}
+	}
+	return -1
+}
+
 // buildRuleFromConfig creates a PolicyRule from ruleConfig
 func (s *Service) buildRuleFromConfig(ruleName string, config *ruleConfig) (*models.PolicyRule, error) {
 	// Validate required fields
@@ -760,6 +1144,10 @@ func (s *Service) buildRuleFromConfig(ruleName string, config *ruleConfig) (*mod
 		return nil, fmt.Errorf("invalid action '%s': must be 'accept' or 'drop'", config.Action)
 	}
 
+	if err := validateRuleProtocol(config.Protocol); err != nil {
+		return nil, err
+	}
+
 	// Resolve source and destination groups
 	sourceGroups, err := s.resolveGroupIdentifiers(config.Sources)
 	if err != nil {
@@ -785,7 +1173,11 @@ func (s *Service) buildRuleFromConfig(ruleName string, config *ruleConfig) (*mod
 
 	// Add ports if specified
 	if config.Ports != "" {
-		rule.Ports = strings.Split(config.Ports, ",")
+		ports, err := parseRulePorts(config.Ports)
+		if err != nil {
+			return nil, err
+		}
+		rule.Ports = ports
 	}
 
 	// Add port range if specified
@@ -797,9 +1189,48 @@ func (s *Service) buildRuleFromConfig(ruleName string, config *ruleConfig) (*mod
 		rule.PortRanges = []models.PortRange{*portRange}
 	}
 
+	if err := validateRuleProtocolPorts(rule); err != nil {
+		return nil, err
+	}
+
 	return rule, nil
 }
 
+// parseRulePorts parses a comma-separated --ports value, checking each port is in range
+func parseRulePorts(portsStr string) ([]string, error) {
+	ports := helpers.SplitCommaList(portsStr)
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("--ports is empty: expected comma-separated port numbers (e.g., 80,443)")
+	}
+	for _, port := range ports {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port '%s' in --ports: must be a number between 1 and 65535", port)
+		}
+	}
+	return ports, nil
+}
+
+// validateRuleProtocol checks a --protocol value against the protocols rules accept
+func validateRuleProtocol(protocol string) error {
+	for _, p := range validRuleProtocols {
+		if strings.ToLower(protocol) == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid protocol '%s': must be one of %s", protocol, strings.Join(validRuleProtocols, ", "))
+}
+
+// validateRuleProtocolPorts checks that ports are only set for tcp or udp, since the API
+// rejects ports on icmp and all
+func validateRuleProtocolPorts(rule *models.PolicyRule) error {
+	protocol := strings.ToLower(rule.Protocol)
+	if (len(rule.Ports) > 0 || len(rule.PortRanges) > 0) && protocol != "tcp" && protocol != "udp" {
+		return fmt.Errorf("rule '%s' has ports %s, but protocol '%s' does not allow ports (use tcp or udp)",
+			rule.Name, formatPorts(rule.Ports, rule.PortRanges), rule.Protocol)
+	}
+	return nil
+}
+
 // resolveGroupIdentifiers converts group names or IDs to PolicyGroup objects
 func (s *Service) resolveGroupIdentifiers(identifiers string) ([]models.PolicyGroup, error) {
 	if identifiers == "" {
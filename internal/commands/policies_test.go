@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"testing"
+
+	"netbird-manage/internal/models"
+)
+
+func TestCheckRuleNameAvailable(t *testing.T) {
+	policy := models.Policy{
+		Name: "office",
+		Rules: []models.PolicyRule{
+			{ID: "rule-1", Name: "web-access"},
+			{ID: "rule-2", Name: "ssh-access"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		ruleName     string
+		exceptRuleID string
+		wantErr      bool
+	}{
+		{name: "unused name", ruleName: "db-access"},
+		{name: "duplicate name", ruleName: "web-access", wantErr: true},
+		{name: "name differing only in case", ruleName: "Web-Access"},
+		{name: "rename rule to itself", ruleName: "web-access", exceptRuleID: "rule-1"},
+		{name: "rename rule to another rule's name", ruleName: "ssh-access", exceptRuleID: "rule-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRuleNameAvailable(policy, tt.ruleName, tt.exceptRuleID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRuleNameAvailable(%q, %q) error = %v, wantErr %v", tt.ruleName, tt.exceptRuleID, err, tt.wantErr)
+			}
+		})
+	}
+}
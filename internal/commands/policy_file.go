@@ -0,0 +1,408 @@
+// policy_file.go - Create a single multi-rule policy from a YAML/JSON file, and export one
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"netbird-manage/internal/client"
+	"netbird-manage/internal/models"
+)
+
+// validRuleProtocols lists the protocols accepted in policy rules
+var validRuleProtocols = []string{"all", "tcp", "udp", "icmp"}
+
+// exportPolicyToFile implements "policy --export <id> [file]". It writes the policy in the
+// shape --create-from-file and --import read: a top-level name plus rules keyed by name,
+// with groups and posture checks referenced by name so the file works in other accounts.
+// Without a file the YAML is written to stdout; a .json file is written as JSON.
+func (s *Service) exportPolicyToFile(policyID, path string) error {
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return fmt.Errorf("failed to fetch policy: %v", err)
+	}
+
+	postureCheckNames, err := s.postureCheckNamesByID()
+	if err != nil {
+		return err
+	}
+
+	data := policyToMap(policy, postureCheckNames)
+	data["name"] = policy.Name
+
+	for _, rule := range policy.Rules {
+		if rule.SourceResource != nil || rule.DestinationResource != nil {
+			fmt.Fprintf(os.Stderr, "Warning: rule '%s' references a network resource by ID; resource references are not recreated on import\n", rule.Name)
+		}
+	}
+
+	if path == "" {
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %v", err)
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(strings.TrimSuffix(path, ".gz")), ".json") {
+		format = "json"
+	}
+	if err := writeDataFile(path, data, format); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported policy '%s' (%d rules) to %s\n", policy.Name, len(policy.Rules), path)
+	return nil
+}
+
+// createPolicyFromFile implements "policy --create-from-file" and "policy --import".
+// The file describes one policy in the same shape as an exported policy (rules keyed by
+// name, groups referenced by name) plus a top-level name. Every rule is validated before
+// the policy is created in a single POST, so a bad rule never leaves a half-built policy.
+func (s *Service) createPolicyFromFile(path string) error {
+	data, err := loadYAMLFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read policy file: %v", err)
+	}
+
+	name, policyData, err := policyDataFromFile(data)
+	if err != nil {
+		return err
+	}
+
+	rulesData, err := normalizePolicyFileRules(policyData["rules"])
+	if err != nil {
+		return err
+	}
+
+	groupNames, err := s.getGroupNamesByID()
+	if err != nil {
+		return fmt.Errorf("failed to fetch groups: %v", err)
+	}
+	ctx := &ImportContext{Service: s, GroupNameToID: make(map[string]string, len(groupNames))}
+	for id, groupName := range groupNames {
+		ctx.GroupNameToID[groupName] = id
+	}
+
+	existingPolicies, err := client.GetList[models.Policy](s.Client, "/policies")
+	if err != nil {
+		return fmt.Errorf("failed to fetch policies: %v", err)
+	}
+	for _, existing := range existingPolicies {
+		if existing.Name == name {
+			return fmt.Errorf("a policy named '%s' already exists (ID: %s)", name, existing.ID)
+		}
+	}
+
+	// Files shared between accounts often reference groups the target doesn't have yet
+	if missing := missingPolicyFileGroups(rulesData, ctx.GroupNameToID); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d group(s) referenced by the policy don't exist in this account: %s\n",
+			len(missing), strings.Join(missing, ", "))
+		fmt.Fprintln(os.Stderr, "Create them first, e.g.: netbird-manage group --create <name>")
+	}
+	for _, ruleName := range sortedKeys(rulesData) {
+		ruleData := rulesData[ruleName].(map[string]interface{})
+		if ruleData["source_resource"] != nil || ruleData["destination_resource"] != nil {
+			fmt.Fprintf(os.Stderr, "Warning: rule '%s' references a network resource; resource references are not imported\n", ruleName)
+		}
+	}
+
+	postureCheckIDs, err := s.resolvePolicyFilePostureChecks(policyData["source_posture_checks"])
+	if err != nil {
+		return err
+	}
+
+	// Report every problem at once instead of stopping at the first bad rule
+	if problems := validatePolicyFileRules(rulesData, ctx.GroupNameToID); len(problems) > 0 {
+		return fmt.Errorf("policy file has %d invalid rule setting(s):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+
+	rules, err := ctx.convertPolicyRules(rulesData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to convert rules: %v", err)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	enabled := true
+	if value, ok := policyData["enabled"].(bool); ok {
+		enabled = value
+	}
+
+	reqBody := models.PolicyCreateRequest{
+		Name:                name,
+		Description:         getString(policyData, "description"),
+		Enabled:             enabled,
+		Rules:               rules,
+		SourcePostureChecks: postureCheckIDs,
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := s.Client.MakeRequest("POST", "/policies", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var createdPolicy models.Policy
+	if err := json.NewDecoder(resp.Body).Decode(&createdPolicy); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	fmt.Printf("Policy created successfully:\n")
+	fmt.Printf("  ID:      %s\n", createdPolicy.ID)
+	fmt.Printf("  Name:    %s\n", createdPolicy.Name)
+	fmt.Printf("  Enabled: %t\n", createdPolicy.Enabled)
+	fmt.Printf("  Rules:   %d\n", len(createdPolicy.Rules))
+	return nil
+}
+
+// missingPolicyFileGroups returns the sorted, de-duplicated group names referenced by
+// rule sources or destinations that don't exist in groupNameToID
+func missingPolicyFileGroups(rules map[string]interface{}, groupNameToID map[string]string) []string {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, rule := range rules {
+		ruleData, _ := rule.(map[string]interface{})
+		for _, field := range []string{"sources", "destinations"} {
+			groups, _ := ruleData[field].([]interface{})
+			for _, group := range groups {
+				groupName, ok := group.(string)
+				if !ok || seen[groupName] {
+					continue
+				}
+				if _, exists := groupNameToID[groupName]; !exists {
+					seen[groupName] = true
+					missing = append(missing, groupName)
+				}
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// resolvePolicyFilePostureChecks converts source_posture_checks names (or IDs) to IDs
+func (s *Service) resolvePolicyFilePostureChecks(refsInterface interface{}) ([]string, error) {
+	refs, ok := refsInterface.([]interface{})
+	if !ok || len(refs) == 0 {
+		return nil, nil
+	}
+
+	postureCheckNames, err := s.postureCheckNamesByID()
+	if err != nil {
+		return nil, err
+	}
+	idsByName := make(map[string]string, len(postureCheckNames))
+	for id, checkName := range postureCheckNames {
+		idsByName[checkName] = id
+	}
+
+	var ids, missing []string
+	for _, ref := range refs {
+		checkName := fmt.Sprint(ref)
+		if id, exists := idsByName[checkName]; exists {
+			ids = append(ids, id)
+		} else if _, exists := postureCheckNames[checkName]; exists {
+			ids = append(ids, checkName)
+		} else {
+			missing = append(missing, checkName)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("posture check(s) not found in this account: %s (create them first, e.g. with 'posture-check --create')",
+			strings.Join(missing, ", "))
+	}
+	return ids, nil
+}
+
+// policyDataFromFile extracts the policy name and settings from a policy file.
+// It accepts either a single policy with a top-level "name", or an export-style
+// "policies" section containing exactly one policy.
+func policyDataFromFile(data map[string]interface{}) (string, map[string]interface{}, error) {
+	if policies, ok := data["policies"].(map[string]interface{}); ok {
+		if len(policies) != 1 {
+			return "", nil, fmt.Errorf("policy file contains %d policies; --create-from-file creates exactly one (use 'import' for several)", len(policies))
+		}
+		for name, policy := range policies {
+			policyData, ok := policy.(map[string]interface{})
+			if !ok {
+				return "", nil, fmt.Errorf("invalid format for policy '%s'", name)
+			}
+			return name, policyData, nil
+		}
+	}
+
+	name := getString(data, "name")
+	if name == "" {
+		return "", nil, fmt.Errorf("policy file must set a top-level 'name'")
+	}
+	return name, data, nil
+}
+
+// normalizePolicyFileRules returns rules keyed by name as convertPolicyRules expects.
+// Rules may also be written as a list with a "name" field on each entry. Missing rule
+// settings get the same defaults as 'policy --add-rule' (enabled, accept, all protocols).
+func normalizePolicyFileRules(rulesInterface interface{}) (map[string]interface{}, error) {
+	rules := make(map[string]interface{})
+
+	switch value := rulesInterface.(type) {
+	case map[string]interface{}:
+		for name, rule := range value {
+			ruleData, ok := rule.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid format for rule '%s'", name)
+			}
+			rules[name] = ruleData
+		}
+	case []interface{}:
+		for i, rule := range value {
+			ruleData, ok := rule.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid format for rule #%d", i+1)
+			}
+			name := getString(ruleData, "name")
+			if name == "" {
+				return nil, fmt.Errorf("rule #%d is missing a 'name'", i+1)
+			}
+			if _, exists := rules[name]; exists {
+				return nil, fmt.Errorf("duplicate rule name '%s'", name)
+			}
+			rules[name] = ruleData
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("invalid rules format: expected a map or list of rules")
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("policy file must define at least one rule (NetBird API requires at least one rule)")
+	}
+
+	for _, rule := range rules {
+		ruleData := rule.(map[string]interface{})
+		if _, ok := ruleData["enabled"]; !ok {
+			ruleData["enabled"] = true
+		}
+		if _, ok := ruleData["action"]; !ok {
+			ruleData["action"] = "accept"
+		}
+		if _, ok := ruleData["protocol"]; !ok {
+			ruleData["protocol"] = "all"
+		}
+	}
+
+	return rules, nil
+}
+
+// validatePolicyFileRules checks every rule and returns one message per problem found
+func validatePolicyFileRules(rules map[string]interface{}, groupNameToID map[string]string) []string {
+	var problems []string
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ruleData := rules[name].(map[string]interface{})
+		addProblem := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf("rule '%s': %s", name, fmt.Sprintf(format, args...)))
+		}
+
+		action := getString(ruleData, "action")
+		if action != "accept" && action != "drop" {
+			addProblem("invalid action '%v': must be 'accept' or 'drop'", ruleData["action"])
+		}
+
+		protocol := getString(ruleData, "protocol")
+		validProtocol := false
+		for _, p := range validRuleProtocols {
+			if protocol == p {
+				validProtocol = true
+				break
+			}
+		}
+		if !validProtocol {
+			addProblem("invalid protocol '%v': must be one of %s", ruleData["protocol"], strings.Join(validRuleProtocols, ", "))
+		}
+
+		for _, field := range []string{"sources", "destinations"} {
+			groups, ok := ruleData[field].([]interface{})
+			if !ok || len(groups) == 0 {
+				addProblem("%s must list at least one group name", field)
+				continue
+			}
+			for _, group := range groups {
+				groupName, ok := group.(string)
+				if !ok {
+					addProblem("%s entries must be group names, got %v", field, group)
+					continue
+				}
+				if _, exists := groupNameToID[groupName]; !exists {
+					addProblem("%s group '%s' not found", strings.TrimSuffix(field, "s"), groupName)
+				}
+			}
+		}
+
+		hasPorts := false
+		if raw, exists := ruleData["ports"]; exists {
+			ports, ok := raw.([]interface{})
+			if !ok {
+				addProblem("ports must be a list")
+			}
+			// Accept unquoted YAML numbers, but send them as strings like the API expects
+			for i, port := range ports {
+				portStr := fmt.Sprint(port)
+				if n, err := strconv.Atoi(portStr); err != nil || n < 1 || n > 65535 {
+					addProblem("invalid port '%s': must be between 1 and 65535", portStr)
+				}
+				ports[i] = portStr
+				hasPorts = true
+			}
+		}
+
+		if raw, exists := ruleData["port_ranges"]; exists {
+			portRanges, ok := raw.([]interface{})
+			if !ok {
+				addProblem("port_ranges must be a list of {start, end}")
+			}
+			for _, pr := range portRanges {
+				prMap, ok := pr.(map[string]interface{})
+				if !ok {
+					addProblem("port_ranges entries must have 'start' and 'end'")
+					continue
+				}
+				start, startOK := prMap["start"].(int)
+				end, endOK := prMap["end"].(int)
+				if !startOK || !endOK {
+					addProblem("port range %v must have numeric 'start' and 'end'", pr)
+					continue
+				}
+				if _, err := parsePortRange(fmt.Sprintf("%d-%d", start, end)); err != nil {
+					addProblem("%v", err)
+				}
+				hasPorts = true
+			}
+		}
+
+		if hasPorts && protocol != "tcp" && protocol != "udp" {
+			addProblem("ports and port ranges require protocol 'tcp' or 'udp'")
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,134 @@
+// policy_validate.go - policy --validate: heuristic checks for dead, duplicate, and broken rules
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"netbird-manage/internal/models"
+)
+
+// policyProblem is one finding from 'policy --validate'
+type policyProblem struct {
+	Rule       string
+	Message    string
+	Suggestion string
+}
+
+// validatePolicy implements "policy --validate <id>". It returns an error when any problem
+// is found so the command can gate scripts.
+func (s *Service) validatePolicy(policyID string) error {
+	var policy models.Policy
+	if err := s.Client.GetJSON("/policies/"+policyID, &policy); err != nil {
+		return err
+	}
+
+	fmt.Printf("Validating policy '%s' (%d rules)\n\n", policy.Name, len(policy.Rules))
+
+	problems := findPolicyProblems(policy.Rules)
+	if len(problems) == 0 {
+		fmt.Println("✓ No problems found")
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("  [WARN] Rule '%s': %s\n", problem.Rule, problem.Message)
+		fmt.Printf("         -> %s\n", problem.Suggestion)
+	}
+	fmt.Println()
+	return fmt.Errorf("%d problem(s) found in policy '%s'", len(problems), policy.Name)
+}
+
+// findPolicyProblems flags disabled rules, references to deleted groups, and enabled rules
+// that duplicate, contradict, or are blocked by another rule on the same endpoints
+func findPolicyProblems(rules []models.PolicyRule) []policyProblem {
+	var problems []policyProblem
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			problems = append(problems, policyProblem{rule.Name, "rule is disabled",
+				"Remove it if it is no longer needed, or re-enable it with --enable-rule"})
+		}
+		for _, group := range append(append([]models.PolicyGroup{}, rule.Sources...), rule.Destinations...) {
+			// The API returns group references without a name when the group was deleted
+			if group.Name == "" {
+				problems = append(problems, policyProblem{rule.Name, fmt.Sprintf("references deleted group %s", group.ID),
+					"Update the rule's sources/destinations with --edit-rule"})
+			}
+		}
+	}
+
+	for i := 0; i < len(rules); i++ {
+		if !rules[i].Enabled {
+			continue
+		}
+		for j := i + 1; j < len(rules); j++ {
+			if !rules[j].Enabled || policyRuleEndpoints(rules[i]) != policyRuleEndpoints(rules[j]) {
+				continue
+			}
+			if problem, ok := comparePolicyRules(rules[i], rules[j]); ok {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems
+}
+
+// comparePolicyRules checks two enabled rules with the same endpoints against each other
+func comparePolicyRules(first, second models.PolicyRule) (policyProblem, bool) {
+	sameTraffic := strings.EqualFold(first.Protocol, second.Protocol) && policyRulePortKey(first) == policyRulePortKey(second)
+
+	switch {
+	case sameTraffic && strings.EqualFold(first.Action, second.Action):
+		return policyProblem{second.Name, fmt.Sprintf("duplicates rule '%s'", first.Name),
+			"Remove one of the two rules"}, true
+	case sameTraffic:
+		return policyProblem{second.Name, fmt.Sprintf("conflicts with rule '%s' (%s vs %s for the same traffic)", first.Name, second.Action, first.Action),
+			"Keep only the rule with the intended action"}, true
+	}
+
+	// A drop of all traffic between the same endpoints makes any accept between them dead
+	for _, pair := range [][2]models.PolicyRule{{first, second}, {second, first}} {
+		drop, accept := pair[0], pair[1]
+		if strings.EqualFold(drop.Action, "drop") && strings.EqualFold(drop.Protocol, "all") && policyRulePortKey(drop) == "" &&
+			strings.EqualFold(accept.Action, "accept") {
+			return policyProblem{accept.Name, fmt.Sprintf("never takes effect: rule '%s' drops all traffic between the same groups", drop.Name),
+				"Remove the accept rule, or narrow the drop rule's protocol or groups"}, true
+		}
+	}
+
+	return policyProblem{}, false
+}
+
+// policyRuleEndpoints returns a comparable key for a rule's sources and destinations.
+// Bidirectional rules are direction-independent, so their two sides are ordered.
+func policyRuleEndpoints(rule models.PolicyRule) string {
+	sources := policyRuleSideKey(rule.Sources, rule.SourceResource)
+	destinations := policyRuleSideKey(rule.Destinations, rule.DestinationResource)
+	if rule.Bidirectional && destinations < sources {
+		sources, destinations = destinations, sources
+	}
+	return fmt.Sprintf("%s>%s/%t", sources, destinations, rule.Bidirectional)
+}
+
+// policyRuleSideKey returns a sorted key for one side of a rule
+func policyRuleSideKey(groups []models.PolicyGroup, resource *models.PolicyResource) string {
+	if resource != nil && resource.ID != "" {
+		return "resource:" + resource.ID
+	}
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = group.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// policyRulePortKey returns a rule's ports and port ranges in sorted order
+func policyRulePortKey(rule models.PolicyRule) string {
+	ports := strings.Split(policyRulePorts(rule), ",")
+	sort.Strings(ports)
+	return strings.Trim(strings.Join(ports, ","), ",")
+}
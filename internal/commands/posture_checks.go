@@ -11,6 +11,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -19,6 +20,19 @@ import (
 type PostureCheckFilters struct {
 	NamePattern string
 	CheckType   string
+	ShowUsage   bool // Annotate each check with the number of policies using it
+	UnusedOnly  bool // Keep only checks no policy references
+}
+
+// postureCheckUsage counts the references to one posture check
+type postureCheckUsage struct {
+	Policies int `json:"policies"`
+}
+
+// postureCheckWithUsage is a posture check annotated with its references for --show-usage output
+type postureCheckWithUsage struct {
+	models.PostureCheck
+	Usage postureCheckUsage `json:"usage"`
 }
 
 // HandlePostureChecksCommand routes posture check-related commands
@@ -32,12 +46,15 @@ func (s *Service) HandlePostureChecksCommand(args []string) error {
 	inspectFlag := postureCmd.String("inspect", "", "Inspect a posture check by ID")
 	filterName := postureCmd.String("filter-name", "", "Filter by name pattern")
 	filterType := postureCmd.String("filter-type", "", "Filter by check type")
-	outputFlag := postureCmd.String("output", "table", "Output format: table or json")
+	showUsageFlag := postureCmd.Bool("show-usage", false, "Annotate each posture check with the number of policies using it (use with --list)")
+	unusedOnlyFlag := postureCmd.Bool("unused-only", false, "List only posture checks no policy references (use with --list)")
+	outputFlag := postureCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// Create flags
 	createFlag := postureCmd.String("create", "", "Create a new posture check with the given name")
 	descriptionFlag := postureCmd.String("description", "", "Posture check description")
 	checkTypeFlag := postureCmd.String("type", "", "Check type: nb-version, os-version, geo-location, network-range, process")
+	cloneFlag := postureCmd.String("clone", "", "Clone the posture check with this ID (use with --create <new-name>)")
 
 	// Check-specific flags (defined but accessed via flag lookups in buildCheckDefinition)
 	postureCmd.String("min-version", "", "Minimum NetBird version (for nb-version)")
@@ -70,6 +87,14 @@ func (s *Service) HandlePostureChecksCommand(args []string) error {
 
 	// Handle the flags in priority order
 
+	// Clone posture check
+	if *cloneFlag != "" {
+		if *createFlag == "" {
+			return fmt.Errorf("--create <new-name> is required with --clone")
+		}
+		return s.clonePostureCheck(*cloneFlag, *createFlag, *descriptionFlag)
+	}
+
 	// Create posture check
 	if *createFlag != "" {
 		if *checkTypeFlag == "" {
@@ -101,6 +126,8 @@ func (s *Service) HandlePostureChecksCommand(args []string) error {
 		filters := &PostureCheckFilters{
 			NamePattern: *filterName,
 			CheckType:   *filterType,
+			ShowUsage:   *showUsageFlag,
+			UnusedOnly:  *unusedOnlyFlag,
 		}
 		return s.listPostureChecks(filters, *outputFlag)
 	}
@@ -113,15 +140,18 @@ func (s *Service) HandlePostureChecksCommand(args []string) error {
 
 // listPostureChecks implements the "posture-check --list" command
 func (s *Service) listPostureChecks(filters *PostureCheckFilters, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/posture-checks", nil)
+	checks, err := client.GetList[models.PostureCheck](s.Client, "/posture-checks")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var checks []models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&checks); err != nil {
-		return fmt.Errorf("failed to decode posture checks response: %v", err)
+	// Usage annotations need every policy, so fetch them once up front
+	var usage map[string]postureCheckUsage
+	if filters.ShowUsage || filters.UnusedOnly {
+		usage, err = s.getPostureCheckUsage()
+		if err != nil {
+			return fmt.Errorf("failed to get posture check usage: %v", err)
+		}
 	}
 
 	// Apply filters
@@ -140,17 +170,37 @@ func (s *Service) listPostureChecks(filters *PostureCheckFilters, outputFormat s
 			}
 		}
 
+		if filters.UnusedOnly && usage[check.ID].Policies > 0 {
+			continue
+		}
+
 		filtered = append(filtered, check)
 	}
 
 	if len(filtered) == 0 {
-		fmt.Println("No posture checks found.")
+		if outputFormat == "json" {
+			fmt.Println("[]")
+		} else if filters.UnusedOnly {
+			fmt.Println("No unused posture checks found. Every posture check is used by a policy.")
+		} else {
+			fmt.Println("No posture checks found.")
+		}
 		return nil
 	}
 
+	// With usage, JSON output includes a "usage" object per posture check
+	var items interface{} = filtered
+	if usage != nil {
+		annotated := make([]postureCheckWithUsage, len(filtered))
+		for i, check := range filtered {
+			annotated[i] = postureCheckWithUsage{PostureCheck: check, Usage: usage[check.ID]}
+		}
+		items = annotated
+	}
+
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filtered, "", "  ")
+		output, err := helpers.MarshalJSONOutput(items)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -160,8 +210,11 @@ func (s *Service) listPostureChecks(filters *PostureCheckFilters, outputFormat s
 
 	// Print a formatted table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tTYPE\tDESCRIPTION")
-	fmt.Fprintln(w, "--\t----\t----\t-----------")
+	if usage != nil {
+		helpers.TableHeader(w, "ID", "NAME", "TYPE", "POLICIES", "DESCRIPTION")
+	} else {
+		helpers.TableHeader(w, "ID", "NAME", "TYPE", "DESCRIPTION")
+	}
 
 	for _, check := range filtered {
 		checkType := getCheckType(check.Checks)
@@ -170,6 +223,16 @@ func (s *Service) listPostureChecks(filters *PostureCheckFilters, outputFormat s
 			desc = "-"
 		}
 
+		if usage != nil {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+				check.ID,
+				check.Name,
+				checkType,
+				usage[check.ID].Policies,
+				desc,
+			)
+			continue
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
 			check.ID,
 			check.Name,
@@ -179,26 +242,39 @@ func (s *Service) listPostureChecks(filters *PostureCheckFilters, outputFormat s
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d posture checks\n", len(filtered))
+	helpers.TableFooter("\nTotal: %d posture checks\n", len(filtered))
 	return nil
 }
 
-// inspectPostureCheck implements the "posture-check --inspect" command
-func (s *Service) inspectPostureCheck(checkID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/posture-checks/"+checkID, nil)
+// getPostureCheckUsage fetches all policies once and counts, per posture check ID, the
+// policies listing it in source_posture_checks. A policy is counted once per check.
+func (s *Service) getPostureCheckUsage() (map[string]postureCheckUsage, error) {
+	policies, err := client.GetList[models.Policy](s.Client, "/policies")
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to fetch policies: %v", err)
 	}
-	defer resp.Body.Close()
 
+	usage := make(map[string]postureCheckUsage)
+	for _, policy := range policies {
+		for _, checkID := range uniqueStrings(policy.SourcePostureChecks) {
+			entry := usage[checkID]
+			entry.Policies++
+			usage[checkID] = entry
+		}
+	}
+	return usage, nil
+}
+
+// inspectPostureCheck implements the "posture-check --inspect" command
+func (s *Service) inspectPostureCheck(checkID string, outputFormat string) error {
 	var check models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&check); err != nil {
-		return fmt.Errorf("failed to decode posture check response: %v", err)
+	if err := s.Client.GetJSON("/posture-checks/"+checkID, &check); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(check, "", "  ")
+		output, err := helpers.MarshalJSONOutput(check)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -323,18 +399,64 @@ func (s *Service) createPostureCheck(name, description, checkType string, flags
 	return nil
 }
 
-// updatePostureCheck implements the "posture-check --update" command
-func (s *Service) updatePostureCheck(checkID, description, checkType string, flags *flag.FlagSet) error {
-	// First, get the current check
-	resp, err := s.Client.MakeRequest("GET", "/posture-checks/"+checkID, nil)
+// clonePostureCheck implements "posture-check --clone <id> --create <name>": it copies the
+// source check's definition into a new check. The description is copied unless overridden.
+func (s *Service) clonePostureCheck(sourceID, newName, description string) error {
+	var source models.PostureCheck
+	if err := s.Client.GetJSON("/posture-checks/"+sourceID, &source); err != nil {
+		return fmt.Errorf("failed to fetch source posture check: %v", err)
+	}
+
+	checks, err := client.GetList[models.PostureCheck](s.Client, "/posture-checks")
+	if err != nil {
+		return fmt.Errorf("failed to fetch posture checks: %v", err)
+	}
+	for _, check := range checks {
+		if check.Name == newName {
+			return fmt.Errorf("a posture check named '%s' already exists (ID: %s)", newName, check.ID)
+		}
+	}
+
+	if description == "" {
+		description = source.Description
+	}
+
+	reqBody := models.PostureCheckRequest{
+		Name:        newName,
+		Description: description,
+		Checks:      source.Checks,
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	resp, err := s.Client.MakeRequest("POST", "/posture-checks", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
+	var createdCheck models.PostureCheck
+	if err := json.NewDecoder(resp.Body).Decode(&createdCheck); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	fmt.Printf("Posture check cloned successfully!\n")
+	fmt.Printf("  ID:     %s\n", createdCheck.ID)
+	fmt.Printf("  Name:   %s\n", createdCheck.Name)
+	fmt.Printf("  Type:   %s\n", getCheckType(source.Checks))
+	fmt.Printf("  Source: %s (%s)\n", source.Name, source.ID)
+	return nil
+}
+
+// updatePostureCheck implements the "posture-check --update" command
+func (s *Service) updatePostureCheck(checkID, description, checkType string, flags *flag.FlagSet) error {
+	// First, get the current check
 	var currentCheck models.PostureCheck
-	if err := json.NewDecoder(resp.Body).Decode(&currentCheck); err != nil {
-		return fmt.Errorf("failed to decode current posture check: %v", err)
+	if err := s.Client.GetJSON("/posture-checks/"+checkID, &currentCheck); err != nil {
+		return err
 	}
 
 	// Build check definition based on type
@@ -359,7 +481,7 @@ func (s *Service) updatePostureCheck(checkID, description, checkType string, fla
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/posture-checks/"+checkID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/posture-checks/"+checkID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -38,6 +39,8 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 
 	// Create flags
 	createFlag := routeCmd.String("create", "", "Create a new route with the given network CIDR")
+	createBatchFlag := routeCmd.Bool("create-batch", false, "Create one route per CIDR in --networks with shared settings")
+	networksFlag := routeCmd.String("networks", "", "Network CIDRs for --create-batch (comma-separated)")
 	networkIDFlag := routeCmd.String("network-id", "", "Target network ID (required for create)")
 	descriptionFlag := routeCmd.String("description", "", "Route description")
 	peerFlag := routeCmd.String("peer", "", "Single routing peer ID (use OR --peer-groups)")
@@ -48,19 +51,25 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 	groupsFlag := routeCmd.String("groups", "", "Access group IDs (comma-separated, required for create)")
 	enabledFlag := routeCmd.Bool("enabled", true, "Enable route")
 	disabledFlag := routeCmd.Bool("disabled", false, "Disable route")
+	keepRouteFlag := routeCmd.Bool("keep-route", false, "Keep the route in the peer's routing table after it is removed (update only)")
+	noKeepRouteFlag := routeCmd.Bool("no-keep-route", false, "Stop keeping the route after removal (update only)")
 
 	// Update flags
 	updateFlag := routeCmd.String("update", "", "Update a route by ID")
 
 	// Delete flags
 	deleteFlag := routeCmd.String("delete", "", "Delete a route by ID")
+	deleteBatchFlag := routeCmd.String("delete-batch", "", "Delete multiple routes by ID (comma-separated)")
+	deleteDisabledFlag := routeCmd.Bool("delete-disabled", false, "Delete all disabled routes")
+	dryRunFlag := routeCmd.Bool("dry-run", false, "Show which routes --delete-batch or --delete-disabled would delete")
 
 	// Toggle flags
 	enableFlag := routeCmd.String("enable", "", "Enable a route by ID")
 	disableFlag := routeCmd.String("disable", "", "Disable a route by ID")
 
 	// Output flags
-	outputFlag := routeCmd.String("output", "table", "Output format: table or json")
+	outputFlag := routeCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
+	resolveNamesFlag := routeCmd.Bool("resolve-names", false, "Show group and peer names instead of counts in the --list table")
 
 	// If no flags provided, show usage
 	if len(args) == 1 {
@@ -97,11 +106,43 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 		return s.createRoute(*createFlag, *networkIDFlag, *descriptionFlag, *peerFlag, *peerGroupsFlag, *metricFlag, masquerade, enabled, *groupsFlag)
 	}
 
+	// Create one route per network CIDR
+	if *createBatchFlag {
+		if *networksFlag == "" {
+			return fmt.Errorf("--networks is required when using --create-batch")
+		}
+		if *groupsFlag == "" {
+			return fmt.Errorf("--groups is required when creating routes")
+		}
+
+		masquerade := *masqueradeFlag
+		if *noMasqueradeFlag {
+			masquerade = false
+		}
+
+		enabled := *enabledFlag
+		if *disabledFlag {
+			enabled = false
+		}
+
+		return s.createRoutesBatch(*networksFlag, *networkIDFlag, *descriptionFlag, *peerFlag, *peerGroupsFlag, *metricFlag, masquerade, enabled, *groupsFlag)
+	}
+
 	// Delete route
 	if *deleteFlag != "" {
 		return s.deleteRoute(*deleteFlag)
 	}
 
+	// Delete multiple routes
+	if *deleteBatchFlag != "" {
+		return s.deleteRoutesBatch(*deleteBatchFlag, *dryRunFlag)
+	}
+
+	// Delete all disabled routes
+	if *deleteDisabledFlag {
+		return s.deleteDisabledRoutes(*dryRunFlag)
+	}
+
 	// Enable route
 	if *enableFlag != "" {
 		return s.toggleRoute(*enableFlag, true)
@@ -114,30 +155,31 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 
 	// Update route
 	if *updateFlag != "" {
-		// Determine if masquerade flags were explicitly set
-		var masqueradePtr *bool
-		if *masqueradeFlag {
-			val := true
-			masqueradePtr = &val
-		} else if *noMasqueradeFlag {
-			val := false
-			masqueradePtr = &val
-		}
-
-		// Determine if enabled flags were explicitly set
-		var enabledPtr *bool
-		if *disabledFlag {
-			val := false
-			enabledPtr = &val
-		} else if *enabledFlag && !*disabledFlag {
-			// Only set enabled if explicitly passed (not just default)
-			// This is tricky with boolean flags, so we check if disabled is false
-			// A better approach would be to track if the flag was actually set
-			// For now, we won't auto-set enabled unless explicitly requested
-			enabledPtr = nil
+		set := make(map[string]bool)
+		routeCmd.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+		update := routeUpdate{
+			NetworkID:   *networkIDFlag,
+			Description: *descriptionFlag,
+			Peer:        *peerFlag,
+			PeerGroups:  *peerGroupsFlag,
+			Groups:      *groupsFlag,
+		}
+		if set["metric"] {
+			update.Metric = metricFlag
+		}
+		var err error
+		if update.Masquerade, err = routeBoolPair(set, "masquerade", *masqueradeFlag, "no-masquerade", *noMasqueradeFlag); err != nil {
+			return err
+		}
+		if update.Enabled, err = routeBoolPair(set, "enabled", *enabledFlag, "disabled", *disabledFlag); err != nil {
+			return err
+		}
+		if update.KeepRoute, err = routeBoolPair(set, "keep-route", *keepRouteFlag, "no-keep-route", *noKeepRouteFlag); err != nil {
+			return err
 		}
 
-		return s.updateRoute(*updateFlag, *networkIDFlag, *descriptionFlag, *peerFlag, *peerGroupsFlag, *metricFlag, masqueradePtr, enabledPtr, *groupsFlag)
+		return s.updateRoute(*updateFlag, update)
 	}
 
 	// Inspect route
@@ -153,7 +195,7 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 			EnabledOnly:    *enabledOnlyFlag,
 			DisabledOnly:   *disabledOnlyFlag,
 		}
-		return s.listRoutes(filters, *outputFlag)
+		return s.listRoutes(filters, *resolveNamesFlag, *outputFlag)
 	}
 
 	// If no known flag was used
@@ -163,17 +205,11 @@ func (s *Service) HandleRoutesCommand(args []string) error {
 }
 
 // listRoutes implements the "route --list" command
-func (s *Service) listRoutes(filters *RouteFilters, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/routes", nil)
+func (s *Service) listRoutes(filters *RouteFilters, resolveNames bool, outputFormat string) error {
+	routes, err := client.GetList[models.Route](s.Client, "/routes")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var routes []models.Route
-	if err := json.NewDecoder(resp.Body).Decode(&routes); err != nil {
-		return fmt.Errorf("failed to decode routes response: %v", err)
-	}
 
 	// Apply filters
 	var filtered []models.Route
@@ -206,7 +242,7 @@ func (s *Service) listRoutes(filters *RouteFilters, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filtered, "", "  ")
+		output, err := helpers.MarshalJSONOutput(filtered)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -214,17 +250,38 @@ func (s *Service) listRoutes(filters *RouteFilters, outputFormat string) error {
 		return nil
 	}
 
+	// Template output
+	if helpers.OutputTemplate != "" {
+		return helpers.RenderTemplate(filtered)
+	}
+
+	// Look up names once for the whole table (--resolve-names)
+	var groupNames, peerNames map[string]string
+	if resolveNames {
+		if groupNames, err = s.getGroupNamesByID(); err != nil {
+			return fmt.Errorf("failed to resolve group names: %v", err)
+		}
+		if peerNames, err = s.routePeerNames(filtered); err != nil {
+			return fmt.Errorf("failed to resolve peer names: %v", err)
+		}
+	}
+
 	// Print a formatted table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNETWORK\tTYPE\tMETRIC\tPEER/GROUPS\tMASQ\tENABLED\tGROUPS")
-	fmt.Fprintln(w, "--\t-------\t----\t------\t-----------\t----\t-------\t------")
+	helpers.TableHeader(w, "ID", "NETWORK", "TYPE", "METRIC", "PEER/GROUPS", "MASQ", "ENABLED", "GROUPS")
 
 	for _, route := range filtered {
 		peerInfo := "-"
 		if route.Peer != "" {
-			peerInfo = fmt.Sprintf("peer:%s", route.Peer[:8])
+			peerInfo = "peer:" + shortID(route.Peer)
+			if name, ok := peerNames[route.Peer]; ok {
+				peerInfo = "peer:" + name
+			}
 		} else if len(route.PeerGroups) > 0 {
 			peerInfo = fmt.Sprintf("%d groups", len(route.PeerGroups))
+			if resolveNames {
+				peerInfo = joinGroupNames(route.PeerGroups, groupNames)
+			}
 		}
 
 		masqStr := "No"
@@ -233,6 +290,9 @@ func (s *Service) listRoutes(filters *RouteFilters, outputFormat string) error {
 		}
 
 		groupsStr := fmt.Sprintf("%d groups", len(route.Groups))
+		if resolveNames {
+			groupsStr = joinGroupNames(route.Groups, groupNames)
+		}
 
 		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%t\t%s\n",
 			route.ID,
@@ -247,26 +307,67 @@ func (s *Service) listRoutes(filters *RouteFilters, outputFormat string) error {
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d routes\n", len(filtered))
+	helpers.TableFooter("\nTotal: %d routes\n", len(filtered))
 	return nil
 }
 
-// inspectRoute implements the "route --inspect" command
-func (s *Service) inspectRoute(routeID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/routes/"+routeID, nil)
+// routePeerNames maps routing peer IDs to names, fetching peers only if a route uses one
+func (s *Service) routePeerNames(routes []models.Route) (map[string]string, error) {
+	names := make(map[string]string)
+	needsPeers := false
+	for _, route := range routes {
+		if route.Peer != "" {
+			needsPeers = true
+			break
+		}
+	}
+	if !needsPeers {
+		return names, nil
+	}
+
+	peers, err := client.GetList[models.Peer](s.Client, "/peers")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
+	for _, peer := range peers {
+		names[peer.ID] = peer.Name
+	}
+	return names, nil
+}
+
+// joinGroupNames lists group names for table output, keeping the ID for unknown groups
+func joinGroupNames(groupIDs []string, names map[string]string) string {
+	if len(groupIDs) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(groupIDs))
+	for i, id := range groupIDs {
+		parts[i] = id
+		if name, ok := names[id]; ok {
+			parts[i] = name
+		}
+	}
+	return strings.Join(parts, ", ")
+}
 
+// shortID truncates an ID to 8 characters for compact table columns
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// inspectRoute implements the "route --inspect" command
+func (s *Service) inspectRoute(routeID string, outputFormat string) error {
 	var route models.Route
-	if err := json.NewDecoder(resp.Body).Decode(&route); err != nil {
-		return fmt.Errorf("failed to decode route response: %v", err)
+	if err := s.Client.GetJSON("/routes/"+routeID, &route); err != nil {
+		return err
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(route, "", "  ")
+		output, err := helpers.MarshalJSONOutput(route)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -355,89 +456,222 @@ func (s *Service) createRoute(network, networkID, description, peer, peerGroups
 		Groups:      groupList,
 	}
 
+	createdRoute, err := s.postRoute(reqBody)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Route created successfully!\n")
+	fmt.Printf("  ID:         %s\n", createdRoute.ID)
+	fmt.Printf("  Network:    %s (%s)\n", createdRoute.Network, createdRoute.NetworkType)
+	fmt.Printf("  Metric:     %d\n", createdRoute.Metric)
+	fmt.Printf("  Masquerade: %t\n", createdRoute.Masquerade)
+	fmt.Printf("  Enabled:    %t\n", createdRoute.Enabled)
+	return nil
+}
+
+// postRoute sends a route creation request and returns the created route
+func (s *Service) postRoute(reqBody models.RouteRequest) (*models.Route, error) {
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %v", err)
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
 	}
 
 	resp, err := s.Client.MakeRequest("POST", "/routes", bytes.NewReader(bodyBytes))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var createdRoute models.Route
 	if err := json.NewDecoder(resp.Body).Decode(&createdRoute); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
+	return &createdRoute, nil
+}
 
-	fmt.Printf("Route created successfully!\n")
-	fmt.Printf("  ID:         %s\n", createdRoute.ID)
-	fmt.Printf("  Network:    %s (%s)\n", createdRoute.Network, createdRoute.NetworkType)
-	fmt.Printf("  Metric:     %d\n", createdRoute.Metric)
-	fmt.Printf("  Masquerade: %t\n", createdRoute.Masquerade)
-	fmt.Printf("  Enabled:    %t\n", createdRoute.Enabled)
+// createRoutesBatch implements the "route --create-batch" command.
+// Every CIDR is validated before any route is created; after that, a failure for one
+// network is reported and the remaining networks are still created.
+func (s *Service) createRoutesBatch(networks, networkID, description, peer, peerGroups string, metric int, masquerade, enabled bool, groups string) error {
+	networkList := helpers.SplitCommaList(networks)
+	if len(networkList) == 0 {
+		return fmt.Errorf("no networks provided")
+	}
+
+	var invalid []string
+	seen := make(map[string]bool)
+	for _, network := range networkList {
+		if err := validateCIDR(network); err != nil {
+			invalid = append(invalid, err.Error())
+		}
+		if seen[network] {
+			invalid = append(invalid, fmt.Sprintf("duplicate network '%s'", network))
+		}
+		seen[network] = true
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("no routes created:\n  - %s", strings.Join(invalid, "\n  - "))
+	}
+
+	if metric < 1 || metric > 9999 {
+		return fmt.Errorf("metric must be between 1 and 9999 (got %d)", metric)
+	}
+	if peer != "" && peerGroups != "" {
+		return fmt.Errorf("cannot specify both --peer and --peer-groups (use one or the other)")
+	}
+
+	groupList := helpers.SplitCommaList(groups)
+	if len(groupList) == 0 {
+		return fmt.Errorf("at least one group is required")
+	}
+	var peerGroupList []string
+	if peerGroups != "" {
+		peerGroupList = helpers.SplitCommaList(peerGroups)
+	}
+
+	var succeeded, failed int
+	for i, network := range networkList {
+		fmt.Printf("[%d/%d] Creating route for %s... ", i+1, len(networkList), network)
+
+		// Default the identifier and description per network so routes stay distinguishable
+		routeNetworkID := networkID
+		if routeNetworkID == "" {
+			routeNetworkID = strings.ReplaceAll(network, "/", "-")
+		}
+		routeDescription := "Route to " + network
+		if description != "" {
+			routeDescription = fmt.Sprintf("%s (%s)", description, network)
+		}
+
+		createdRoute, err := s.postRoute(models.RouteRequest{
+			Description: routeDescription,
+			NetworkID:   routeNetworkID,
+			Network:     network,
+			Peer:        peer,
+			PeerGroups:  peerGroupList,
+			Metric:      metric,
+			Masquerade:  masquerade,
+			Enabled:     enabled,
+			Groups:      groupList,
+		})
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("Done (ID: %s)\n", createdRoute.ID)
+		succeeded++
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("completed: %d succeeded, %d failed", succeeded, failed)
+	}
+	fmt.Printf("All %d routes created successfully\n", succeeded)
 	return nil
 }
 
-// updateRoute implements the "route --update" command
-func (s *Service) updateRoute(routeID, networkID, description, peer, peerGroups string, metric int, masquerade, enabled *bool, groups string) error {
-	// First, get the current route
-	resp, err := s.Client.MakeRequest("GET", "/routes/"+routeID, nil)
-	if err != nil {
-		return err
+// routeUpdate holds the fields given to "route --update". Empty strings and nil
+// pointers leave the route's current value in place.
+type routeUpdate struct {
+	NetworkID   string
+	Description string
+	Peer        string
+	PeerGroups  string
+	Groups      string
+	Metric      *int
+	Masquerade  *bool
+	Enabled     *bool
+	KeepRoute   *bool
+}
+
+// routeBoolPair returns the value set by an --on/--off flag pair (so --enabled=false
+// behaves like --disabled), or nil if neither flag was given
+func routeBoolPair(set map[string]bool, on string, onValue bool, off string, offValue bool) (*bool, error) {
+	if set[on] && set[off] {
+		return nil, fmt.Errorf("--%s and --%s cannot be used together", on, off)
 	}
-	defer resp.Body.Close()
+	switch {
+	case set[on]:
+		return &onValue, nil
+	case set[off]:
+		val := !offValue
+		return &val, nil
+	}
+	return nil, nil
+}
 
-	var currentRoute models.Route
-	if err := json.NewDecoder(resp.Body).Decode(&currentRoute); err != nil {
-		return fmt.Errorf("failed to decode current route: %v", err)
+// updateRoute implements the "route --update" command. The current route is fetched and
+// only the provided fields are overridden, so a PUT never resets the rest.
+func (s *Service) updateRoute(routeID string, update routeUpdate) error {
+	if update.Peer != "" && update.PeerGroups != "" {
+		return fmt.Errorf("cannot specify both --peer and --peer-groups (use one or the other)")
+	}
+	if update.Metric != nil && (*update.Metric < 1 || *update.Metric > 9999) {
+		return fmt.Errorf("metric must be between 1 and 9999 (got %d)", *update.Metric)
 	}
 
-	// Build update request (update only provided fields)
-	updateReq := models.RouteRequest{
-		Description: currentRoute.Description,
-		NetworkID:   currentRoute.NetworkID,
-		Network:     currentRoute.Network,
-		Peer:        currentRoute.Peer,
-		PeerGroups:  currentRoute.PeerGroups,
-		Metric:      currentRoute.Metric,
-		Masquerade:  currentRoute.Masquerade,
-		Enabled:     currentRoute.Enabled,
-		Groups:      currentRoute.Groups,
+	var currentRoute models.Route
+	if err := s.Client.GetJSON("/routes/"+routeID, &currentRoute); err != nil {
+		return err
 	}
 
-	// Update fields if provided
-	if networkID != "" {
-		updateReq.NetworkID = networkID
+	updateReq := routeRequestFromRoute(currentRoute)
+	var changed []string
+
+	if update.NetworkID != "" {
+		updateReq.NetworkID = update.NetworkID
+		changed = append(changed, "network-id")
 	}
-	if description != "" {
-		updateReq.Description = description
+	if update.Description != "" {
+		updateReq.Description = update.Description
+		changed = append(changed, "description")
 	}
-	if peer != "" {
-		updateReq.Peer = peer
+	if update.Peer != "" {
+		updateReq.Peer = update.Peer
 		updateReq.PeerGroups = nil
+		changed = append(changed, "peer")
 	}
-	if peerGroups != "" {
-		updateReq.PeerGroups = helpers.SplitCommaList(peerGroups)
+	if update.PeerGroups != "" {
+		peerGroupIDs, err := s.resolveMultipleGroupIdentifiers(helpers.SplitCommaList(update.PeerGroups))
+		if err != nil {
+			return fmt.Errorf("--peer-groups: %v", err)
+		}
+		updateReq.PeerGroups = peerGroupIDs
 		updateReq.Peer = ""
+		changed = append(changed, "peer-groups")
 	}
-	if metric != 100 { // Only update if not default
-		if metric < 1 || metric > 9999 {
-			return fmt.Errorf("metric must be between 1 and 9999 (got %d)", metric)
+	if update.Groups != "" {
+		groupIDs, err := s.resolveMultipleGroupIdentifiers(helpers.SplitCommaList(update.Groups))
+		if err != nil {
+			return fmt.Errorf("--groups: %v", err)
 		}
-		updateReq.Metric = metric
+		if len(groupIDs) == 0 {
+			return fmt.Errorf("at least one group is required")
+		}
+		updateReq.Groups = groupIDs
+		changed = append(changed, "groups")
+	}
+	if update.Metric != nil {
+		updateReq.Metric = *update.Metric
+		changed = append(changed, "metric")
 	}
-	if groups != "" {
-		updateReq.Groups = helpers.SplitCommaList(groups)
+	if update.Masquerade != nil {
+		updateReq.Masquerade = *update.Masquerade
+		changed = append(changed, "masquerade")
 	}
-	// Update masquerade if explicitly provided
-	if masquerade != nil {
-		updateReq.Masquerade = *masquerade
+	if update.Enabled != nil {
+		updateReq.Enabled = *update.Enabled
+		changed = append(changed, "enabled")
 	}
-	// Update enabled if explicitly provided
-	if enabled != nil {
-		updateReq.Enabled = *enabled
+	if update.KeepRoute != nil {
+		updateReq.KeepRoute = *update.KeepRoute
+		changed = append(changed, "keep-route")
+	}
+
+	if len(changed) == 0 {
+		return fmt.Errorf("nothing to update; pass at least one of --description, --network-id, --peer, --peer-groups, --groups, --metric, --masquerade/--no-masquerade, --enabled/--disabled, --keep-route/--no-keep-route")
 	}
 
 	bodyBytes, err := json.Marshal(updateReq)
@@ -445,13 +679,14 @@ func (s *Service) updateRoute(routeID, networkID, description, peer, peerGroups
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/routes/"+routeID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/routes/"+routeID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	fmt.Printf("Route %s updated successfully\n", routeID)
+	fmt.Printf("  Changed: %s\n", strings.Join(changed, ", "))
 	return nil
 }
 
@@ -499,50 +734,179 @@ func (s *Service) deleteRoute(routeID string) error {
 	return nil
 }
 
-// toggleRoute enables or disables a route
-func (s *Service) toggleRoute(routeID string, enable bool) error {
-	// First, get the current route
-	resp, err := s.Client.MakeRequest("GET", "/routes/"+routeID, nil)
+// deleteRoutesBatch implements the "route --delete-batch" command
+func (s *Service) deleteRoutesBatch(idList string, dryRun bool) error {
+	routeIDs := helpers.SplitCommaList(idList)
+	if len(routeIDs) == 0 {
+		return fmt.Errorf("no route IDs provided")
+	}
+
+	fmt.Println("Fetching route details...")
+	routes := make([]models.Route, 0, len(routeIDs))
+	seen := make(map[string]bool, len(routeIDs))
+	for _, id := range routeIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var route models.Route
+		if err := s.Client.GetJSON("/routes/"+id, &route); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", id, err)
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	if len(routes) == 0 {
+		return fmt.Errorf("no valid routes found to delete")
+	}
+
+	return s.deleteRoutes(routes, dryRun)
+}
+
+// deleteDisabledRoutes implements the "route --delete-disabled" command
+func (s *Service) deleteDisabledRoutes(dryRun bool) error {
+	routes, err := client.GetList[models.Route](s.Client, "/routes")
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to fetch routes: %v", err)
+	}
+
+	var disabled []models.Route
+	for _, route := range routes {
+		if !route.Enabled {
+			disabled = append(disabled, route)
+		}
+	}
+
+	if len(disabled) == 0 {
+		fmt.Println("No disabled routes found.")
+		return nil
+	}
+
+	return s.deleteRoutes(disabled, dryRun)
+}
+
+// routeDeletionSummary describes a route in bulk deletion lists
+func routeDeletionSummary(route models.Route) string {
+	summary := fmt.Sprintf("%s (ID: %s, Network ID: %s", routeTarget(route), route.ID, route.NetworkID)
+	if route.Description != "" {
+		summary += ", Description: " + route.Description
+	}
+	return summary + ")"
+}
+
+// deleteRoutes confirms and deletes the given routes, reporting progress and a summary.
+// With dryRun, it only lists the routes that would be deleted.
+func (s *Service) deleteRoutes(routes []models.Route, dryRun bool) error {
+	items := make([]string, 0, len(routes))
+	for _, route := range routes {
+		items = append(items, routeDeletionSummary(route))
 	}
-	defer resp.Body.Close()
 
+	if dryRun {
+		fmt.Printf("Would delete %d routes:\n", len(routes))
+		for _, item := range items {
+			fmt.Printf("  - %s\n", item)
+		}
+		fmt.Println("\nDry run: no routes were deleted")
+		return nil
+	}
+
+	if !helpers.ConfirmBulkDeletion("routes", items, len(routes)) {
+		return nil
+	}
+
+	var succeeded, failed int
+	for i, route := range routes {
+		fmt.Printf("[%d/%d] Deleting route '%s'... ", i+1, len(routes), routeTarget(route))
+
+		resp, err := s.Client.MakeRequest("DELETE", "/routes/"+route.ID, nil)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		resp.Body.Close()
+		fmt.Println("Done")
+		succeeded++
+	}
+
+	fmt.Println()
+	fmt.Printf("Summary: %d deleted, %d failed\n", succeeded, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d route(s)", failed)
+	}
+	return nil
+}
+
+// toggleRoute enables or disables a route, preserving every other field
+func (s *Service) toggleRoute(routeID string, enable bool) error {
+	// First, get the current route
 	var route models.Route
-	if err := json.NewDecoder(resp.Body).Decode(&route); err != nil {
-		return fmt.Errorf("failed to decode route: %v", err)
+	if err := s.Client.GetJSON("/routes/"+routeID, &route); err != nil {
+		return err
 	}
 
-	// Update the enabled status
-	route.Enabled = enable
+	status := "enabled"
+	if !enable {
+		status = "disabled"
+	}
 
-	updateReq := models.RouteRequest{
-		Description: route.Description,
-		NetworkID:   route.NetworkID,
-		Network:     route.Network,
-		Peer:        route.Peer,
-		PeerGroups:  route.PeerGroups,
-		Metric:      route.Metric,
-		Masquerade:  route.Masquerade,
-		Enabled:     enable,
-		Groups:      route.Groups,
+	if route.Enabled == enable {
+		fmt.Printf("Route %s (%s) is already %s\n", routeID, routeTarget(route), status)
+		return nil
 	}
 
+	// Only the enabled status changes
+	updateReq := routeRequestFromRoute(route)
+	updateReq.Enabled = enable
+
 	bodyBytes, err := json.Marshal(updateReq)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/routes/"+routeID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/routes/"+routeID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	status := "enabled"
-	if !enable {
-		status = "disabled"
-	}
-	fmt.Printf("Route %s %s successfully\n", routeID, status)
+	fmt.Printf("Route %s (%s) %s successfully\n", routeID, routeTarget(route), status)
 	return nil
 }
+
+// routeRequestFromRoute builds a full update request from an existing route so PUTs
+// don't reset fields the caller didn't touch
+func routeRequestFromRoute(route models.Route) models.RouteRequest {
+	req := models.RouteRequest{
+		Description:         route.Description,
+		NetworkID:           route.NetworkID,
+		Network:             route.Network,
+		Domains:             route.Domains,
+		Peer:                route.Peer,
+		PeerGroups:          route.PeerGroups,
+		Metric:              route.Metric,
+		Masquerade:          route.Masquerade,
+		Enabled:             route.Enabled,
+		Groups:              route.Groups,
+		AccessControlGroups: route.AccessControlGroups,
+		KeepRoute:           route.KeepRoute,
+	}
+
+	// Network and Domains are mutually exclusive in requests
+	if len(route.Domains) > 0 {
+		req.Network = ""
+	}
+
+	return req
+}
+
+// routeTarget returns the network CIDR or domains a route points at
+func routeTarget(route models.Route) string {
+	if len(route.Domains) > 0 {
+		return strings.Join(route.Domains, ", ")
+	}
+	return route.Network
+}
@@ -0,0 +1,69 @@
+// server_features.go - management server version guards for features newer servers add
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"netbird-manage/internal/client"
+)
+
+// serverFeature describes a command that only exists on some management servers
+type serverFeature struct {
+	Name       string // Shown in messages, e.g. "Networks"
+	MinVersion string // Oldest management server release with the API, if known
+	CloudOnly  bool   // Only NetBird Cloud serves the API, regardless of version
+}
+
+// serverFeatures maps top-level commands (and their aliases) to the server feature they need
+var serverFeatures = map[string]serverFeature{
+	"network":       {Name: "Networks", MinVersion: "0.35.0"},
+	"posture-check": {Name: "Posture checks", MinVersion: "0.26.0"},
+	"posture":       {Name: "Posture checks", MinVersion: "0.26.0"},
+	"geo":           {Name: "Geo locations", MinVersion: "0.26.0"},
+	"geo-location":  {Name: "Geo locations", MinVersion: "0.26.0"},
+	"location":      {Name: "Geo locations", MinVersion: "0.26.0"},
+	"ingress-port":  {Name: "Ingress ports", CloudOnly: true},
+	"ingress":       {Name: "Ingress ports", CloudOnly: true},
+	"ingress-peer":  {Name: "Ingress peers", CloudOnly: true},
+}
+
+// CommandNeedsServerVersion reports whether a command is guarded, so the server version is
+// only probed when it matters
+func CommandNeedsServerVersion(command string) bool {
+	feature, ok := serverFeatures[command]
+	return ok && feature.MinVersion != ""
+}
+
+// CheckServerFeature refuses a command the management server is known to be too old for.
+// An unknown or non-release server version (e.g. a development build) is always allowed.
+func (s *Service) CheckServerFeature(command string) error {
+	feature, ok := serverFeatures[command]
+	if !ok || feature.MinVersion == "" || s.Client.ServerVersion == "" {
+		return nil
+	}
+	cmp, ok := client.CompareVersions(s.Client.ServerVersion, feature.MinVersion)
+	if ok && cmp < 0 {
+		return fmt.Errorf("%s require management server >= %s (this server reports %s); upgrade the server or pass --api-version to override",
+			feature.Name, feature.MinVersion, s.Client.ServerVersion)
+	}
+	return nil
+}
+
+// ExplainMissingFeature adds the version or Cloud requirement to a 404 from a guarded
+// command, which otherwise reads like a wrong ID
+func ExplainMissingFeature(command string, err error) error {
+	feature, ok := serverFeatures[command]
+	if !ok {
+		return err
+	}
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return err
+	}
+	if feature.CloudOnly {
+		return fmt.Errorf("%v (%s are only available on NetBird Cloud; self-hosted servers return 404)", err, feature.Name)
+	}
+	return fmt.Errorf("%v (if the ID is correct, the server may be too old: %s require management server >= %s)", err, feature.Name, feature.MinVersion)
+}
@@ -9,9 +9,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -29,12 +29,13 @@ func (s *Service) HandleSetupKeysCommand(args []string) error {
 	filterNameFlag := setupKeyCmd.String("filter-name", "", "Filter by name pattern (use with --list)")
 	filterTypeFlag := setupKeyCmd.String("filter-type", "", "Filter by type: one-off or reusable (use with --list)")
 	validOnlyFlag := setupKeyCmd.Bool("valid-only", false, "Show only valid keys (use with --list)")
-	outputFlag := setupKeyCmd.String("output", "table", "Output format: table or json")
+	outputFlag := setupKeyCmd.String("output", helpers.DefaultOutputFormat, "Output format: table, wide (list only), or json")
 
 	// Create flags
 	createFlag := setupKeyCmd.String("create", "", "Create a new setup key with the given name")
 	keyTypeFlag := setupKeyCmd.String("type", "one-off", "Key type: one-off or reusable (default: one-off)")
 	expiresInFlag := setupKeyCmd.String("expires-in", "7d", "Expiration duration: 1d, 7d, 30d, 90d, 1y (default: 7d)")
+	expiresAtFlag := setupKeyCmd.String("expires-at", "", "Expiration date: YYYY-MM-DD (end of day, local time) or RFC3339 (alternative to --expires-in)")
 	autoGroupsFlag := setupKeyCmd.String("auto-groups", "", "Comma-separated group IDs for auto-assignment")
 	usageLimitFlag := setupKeyCmd.Int("usage-limit", 0, "Usage limit (0 = unlimited, default: 0)")
 	ephemeralFlag := setupKeyCmd.Bool("ephemeral", false, "Mark peers as ephemeral")
@@ -53,6 +54,10 @@ func (s *Service) HandleSetupKeysCommand(args []string) error {
 	deleteFlag := setupKeyCmd.String("delete", "", "Delete a setup key by its ID")
 	deleteBatchFlag := setupKeyCmd.String("delete-batch", "", "Delete multiple setup keys (comma-separated IDs)")
 	deleteAllFlag := setupKeyCmd.Bool("delete-all", false, "Delete all setup keys")
+	deleteExpiredFlag := setupKeyCmd.Bool("delete-expired", false, "Delete revoked, expired, and used one-off setup keys")
+	includeUsedReusableFlag := setupKeyCmd.Bool("include-used-reusable", false, "Also delete reusable keys that reached their usage limit (use with --delete-expired)")
+	dryRunFlag := setupKeyCmd.Bool("dry-run", false, "Show which keys would be deleted without deleting (use with --delete-expired)")
+	concurrencyFlag := setupKeyCmd.Int("concurrency", defaultSetupKeyDeleteConcurrency, "Parallel deletions for --delete-batch, --delete-all, and --delete-expired")
 
 	// If no flags provided, show usage
 	if len(args) == 1 {
@@ -75,12 +80,36 @@ func (s *Service) HandleSetupKeysCommand(args []string) error {
 	}
 
 	if *createFlag != "" {
-		expiresInSec, err := helpers.ParseDuration(*expiresInFlag, helpers.SetupKeyDurationBounds())
-		if err != nil {
-			return fmt.Errorf("invalid expiration duration: %v", err)
+		var expiresInSec int
+		if *expiresAtFlag != "" {
+			expiresInSet := false
+			setupKeyCmd.Visit(func(f *flag.Flag) {
+				if f.Name == "expires-in" {
+					expiresInSet = true
+				}
+			})
+			if expiresInSet {
+				return fmt.Errorf("--expires-at and --expires-in cannot be used together")
+			}
+
+			expiresAt, seconds, err := expiresInUntil(*expiresAtFlag, time.Now())
+			if err != nil {
+				return err
+			}
+			expiresInSec = seconds
+			fmt.Printf("Expiration:   %s (in %s)\n\n", expiresAt.Format("2006-01-02 15:04:05 MST"), formatDuration(seconds))
+		} else {
+			seconds, err := helpers.ParseDuration(*expiresInFlag, helpers.SetupKeyDurationBounds())
+			if err != nil {
+				return fmt.Errorf("invalid expiration duration: %v", err)
+			}
+			expiresInSec = seconds
 		}
 		// Resolve group names/IDs to IDs
 		groupIdentifiers := helpers.SplitCommaList(*autoGroupsFlag)
+		if strings.TrimSpace(*autoGroupsFlag) != "" && len(groupIdentifiers) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: --auto-groups '%s' contains no group names; the key will have no auto-groups\n", *autoGroupsFlag)
+		}
 		autoGroupIDs, err := s.resolveMultipleGroupIdentifiers(groupIdentifiers)
 		if err != nil {
 			return fmt.Errorf("failed to resolve auto-groups: %v", err)
@@ -118,12 +147,22 @@ func (s *Service) HandleSetupKeysCommand(args []string) error {
 		return s.deleteSetupKey(*deleteFlag)
 	}
 
+	if *deleteBatchFlag != "" || *deleteAllFlag || *deleteExpiredFlag {
+		if *concurrencyFlag < 1 || *concurrencyFlag > maxSetupKeyDeleteConcurrency {
+			return fmt.Errorf("--concurrency must be between 1 and %d", maxSetupKeyDeleteConcurrency)
+		}
+	}
+
 	if *deleteBatchFlag != "" {
-		return s.deleteSetupKeysBatch(*deleteBatchFlag)
+		return s.deleteSetupKeysBatch(*deleteBatchFlag, *concurrencyFlag)
 	}
 
 	if *deleteAllFlag {
-		return s.deleteAllSetupKeys()
+		return s.deleteAllSetupKeys(*concurrencyFlag)
+	}
+
+	if *deleteExpiredFlag {
+		return s.deleteExpiredSetupKeys(*dryRunFlag, *includeUsedReusableFlag, *concurrencyFlag)
 	}
 
 	// If no known flag was used
@@ -188,6 +227,9 @@ func formatExpiration(expiresStr string) string {
 	if err != nil {
 		return expiresStr
 	}
+	if expires.IsZero() || expires.Year() <= 1 {
+		return "Never"
+	}
 
 	now := time.Now()
 	if expires.Before(now) {
@@ -225,16 +267,10 @@ func formatState(state string, valid, revoked bool) string {
 
 // listSetupKeys lists all setup keys with optional filters
 func (s *Service) listSetupKeys(filterName, filterType string, validOnly bool, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys", nil)
+	keys, err := client.GetList[models.SetupKey](s.Client, "/setup-keys")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var keys []models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
-	}
 
 	// Apply filters
 	var filtered []models.SetupKey
@@ -264,7 +300,7 @@ func (s *Service) listSetupKeys(filterName, filterType string, validOnly bool, o
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(filtered, "", "  ")
+		output, err := helpers.MarshalJSONOutput(filtered)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -272,10 +308,19 @@ func (s *Service) listSetupKeys(filterName, filterType string, validOnly bool, o
 		return nil
 	}
 
-	// Display in table format
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSTATE\tUSED/LIMIT\tEXPIRES\tGROUPS")
-	fmt.Fprintln(w, "--\t----\t----\t-----\t----------\t-------\t------")
+	// Display in table format; --output wide adds key options
+	table := helpers.NewTable(outputFormat,
+		helpers.TableColumn{Header: "ID"},
+		helpers.TableColumn{Header: "NAME"},
+		helpers.TableColumn{Header: "TYPE"},
+		helpers.TableColumn{Header: "STATE"},
+		helpers.TableColumn{Header: "USED/LIMIT"},
+		helpers.TableColumn{Header: "EXPIRES"},
+		helpers.TableColumn{Header: "GROUPS"},
+		helpers.TableColumn{Header: "EPHEMERAL", Wide: true},
+		helpers.TableColumn{Header: "EXTRA DNS LABELS", Wide: true},
+		helpers.TableColumn{Header: "LAST USED", Wide: true},
+	)
 
 	for _, key := range filtered {
 		usageLimit := "∞"
@@ -289,39 +334,55 @@ func (s *Service) listSetupKeys(filterName, filterType string, validOnly bool, o
 			groupsStr = fmt.Sprintf("%d groups", groupCount)
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%s\t%s\t%s\n",
+		lastUsed := "never"
+		if key.UsedTimes > 0 {
+			lastUsed = helpers.FormatLastSeen(key.LastUsed, time.Now())
+		}
+
+		table.Row(
 			key.ID,
 			key.Name,
 			key.Type,
 			formatState(key.State, key.Valid, key.Revoked),
-			key.UsedTimes,
-			usageLimit,
+			fmt.Sprintf("%d/%s", key.UsedTimes, usageLimit),
 			formatExpiration(key.Expires),
 			groupsStr,
+			strconv.FormatBool(key.Ephemeral),
+			strconv.FormatBool(key.AllowExtraDNSLabels),
+			lastUsed,
 		)
 	}
 
-	w.Flush()
-	fmt.Printf("\nTotal: %d setup keys\n", len(filtered))
+	table.Flush()
+	helpers.TableFooter("\nTotal: %d setup keys\n", len(filtered))
 	return nil
 }
 
+// setupKeyInspectOutput is the JSON shape of 'setup-key --inspect', adding resolved group names
+type setupKeyInspectOutput struct {
+	models.SetupKey
+	AutoGroupsResolved []models.ResolvedGroupRef `json:"auto_groups_resolved"`
+}
+
 // inspectSetupKey shows detailed information about a setup key
 func (s *Service) inspectSetupKey(keyID string, outputFormat string) error {
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys/"+keyID, nil)
-	if err != nil {
+	var key models.SetupKey
+	if err := s.Client.GetJSON("/setup-keys/"+keyID, &key); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var key models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	// Resolve auto-group IDs to names in a single groups lookup
+	autoGroups, err := s.resolveGroupRefs(key.AutoGroups)
+	if err != nil {
+		return fmt.Errorf("failed to resolve auto-groups: %v", err)
 	}
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(key, "", "  ")
+		output, err := helpers.MarshalJSONOutput(setupKeyInspectOutput{
+			SetupKey:           key,
+			AutoGroupsResolved: autoGroups,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -379,11 +440,11 @@ func (s *Service) inspectSetupKey(keyID string, outputFormat string) error {
 
 	fmt.Printf("Auto-Groups\n")
 	fmt.Printf("-----------\n")
-	if len(key.AutoGroups) == 0 {
+	if len(autoGroups) == 0 {
 		fmt.Printf("None\n")
 	} else {
-		for _, groupID := range key.AutoGroups {
-			fmt.Printf("  - %s\n", groupID)
+		for _, ref := range autoGroups {
+			fmt.Printf("  - %s\n", formatGroupRef(ref))
 		}
 	}
 	fmt.Printf("\n")
@@ -405,6 +466,34 @@ func (s *Service) inspectSetupKey(keyID string, outputFormat string) error {
 	return nil
 }
 
+// expiresInUntil converts an --expires-at date into expires_in seconds from now.
+// A bare date means the end of that day in local time. The result must fall within
+// the API's setup key expiration bounds.
+func expiresInUntil(value string, now time.Time) (time.Time, int, error) {
+	var expiresAt time.Time
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		expiresAt = date.AddDate(0, 0, 1).Add(-time.Second)
+	} else if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		expiresAt = ts
+	} else {
+		return time.Time{}, 0, fmt.Errorf("invalid --expires-at '%s': use YYYY-MM-DD or RFC3339 (e.g. 2025-12-31T17:00:00Z)", value)
+	}
+
+	if !expiresAt.After(now) {
+		return time.Time{}, 0, fmt.Errorf("--expires-at %s is in the past", expiresAt.Format("2006-01-02 15:04:05 MST"))
+	}
+
+	seconds := int(expiresAt.Sub(now).Seconds())
+	bounds := helpers.SetupKeyDurationBounds()
+	if seconds < bounds.Min {
+		return time.Time{}, 0, fmt.Errorf("--expires-at must be at least %s from now", formatDuration(bounds.Min))
+	}
+	if seconds > bounds.Max {
+		return time.Time{}, 0, fmt.Errorf("--expires-at must be at most %s from now", formatDuration(bounds.Max))
+	}
+	return expiresAt, seconds, nil
+}
+
 // createSetupKey creates a new setup key
 func (s *Service) createSetupKey(name, keyType string, expiresIn int, autoGroups []string, usageLimit int, ephemeral, allowExtraDNSLabels bool) error {
 	// Validate key type
@@ -452,9 +541,7 @@ func (s *Service) createSetupKey(name, keyType string, expiresIn int, autoGroups
 		fmt.Printf("Usage Limit:  Unlimited\n")
 	}
 
-	if len(autoGroups) > 0 {
-		fmt.Printf("Auto-Groups:  %s\n", strings.Join(autoGroups, ", "))
-	}
+	s.printCreatedKeyAutoGroups(autoGroups, key.AutoGroups)
 
 	// Display the key value - CRITICAL: Only shown once!
 	if key.Key != "" {
@@ -470,18 +557,41 @@ func (s *Service) createSetupKey(name, keyType string, expiresIn int, autoGroups
 	return nil
 }
 
+// printCreatedKeyAutoGroups shows the auto-groups attached to a new key by name, and warns
+// when the server did not attach every requested group
+func (s *Service) printCreatedKeyAutoGroups(requested, attached []string) {
+	if len(attached) == 0 {
+		fmt.Printf("Auto-Groups:  None (enrolled peers join only the All group)\n")
+	} else {
+		refs, err := s.resolveGroupRefs(attached)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not resolve auto-group names: %v\n", err)
+			fmt.Printf("Auto-Groups:  %s\n", strings.Join(attached, ", "))
+		} else {
+			fmt.Printf("Auto-Groups:\n")
+			for _, ref := range refs {
+				fmt.Printf("  - %s\n", formatGroupRef(ref))
+			}
+		}
+	}
+
+	attachedSet := make(map[string]bool, len(attached))
+	for _, id := range attached {
+		attachedSet[id] = true
+	}
+	for _, id := range requested {
+		if !attachedSet[id] {
+			fmt.Fprintf(os.Stderr, "Warning: requested auto-group %s was not attached to the key\n", id)
+		}
+	}
+}
+
 // updateSetupKeyRevocation updates the revocation status of a setup key
 func (s *Service) updateSetupKeyRevocation(keyID string, revoked bool) error {
 	// First get the current key to retrieve auto-groups
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys/"+keyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var currentKey models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&currentKey); err != nil {
-		return fmt.Errorf("failed to decode current key: %v", err)
+	if err := s.Client.GetJSON("/setup-keys/"+keyID, &currentKey); err != nil {
+		return err
 	}
 
 	// Create update request
@@ -495,7 +605,7 @@ func (s *Service) updateSetupKeyRevocation(keyID string, revoked bool) error {
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/setup-keys/"+keyID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/setup-keys/"+keyID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -513,15 +623,9 @@ func (s *Service) updateSetupKeyRevocation(keyID string, revoked bool) error {
 // updateSetupKeyGroups updates the auto-groups for a setup key
 func (s *Service) updateSetupKeyGroups(keyID string, newGroups []string) error {
 	// First get the current key to retrieve revoked status
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys/"+keyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var currentKey models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&currentKey); err != nil {
-		return fmt.Errorf("failed to decode current key: %v", err)
+	if err := s.Client.GetJSON("/setup-keys/"+keyID, &currentKey); err != nil {
+		return err
 	}
 
 	// Create update request
@@ -535,7 +639,7 @@ func (s *Service) updateSetupKeyGroups(keyID string, newGroups []string) error {
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err = s.Client.MakeRequest("PUT", "/setup-keys/"+keyID, bytes.NewReader(bodyBytes))
+	resp, err := s.Client.MakeRequest("PUT", "/setup-keys/"+keyID, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -554,15 +658,9 @@ func (s *Service) updateSetupKeyGroups(keyID string, newGroups []string) error {
 // deleteSetupKey deletes a setup key
 func (s *Service) deleteSetupKey(keyID string) error {
 	// First get the key details to show confirmation info
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys/"+keyID, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
 	var key models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
-		return fmt.Errorf("failed to decode setup key: %v", err)
+	if err := s.Client.GetJSON("/setup-keys/"+keyID, &key); err != nil {
+		return err
 	}
 
 	// Show confirmation prompt with key details
@@ -576,7 +674,7 @@ func (s *Service) deleteSetupKey(keyID string) error {
 	}
 
 	// Perform deletion
-	resp, err = s.Client.MakeRequest("DELETE", "/setup-keys/"+keyID, nil)
+	resp, err := s.Client.MakeRequest("DELETE", "/setup-keys/"+keyID, nil)
 	if err != nil {
 		return err
 	}
@@ -587,7 +685,7 @@ func (s *Service) deleteSetupKey(keyID string) error {
 }
 
 // deleteSetupKeysBatch deletes multiple setup keys
-func (s *Service) deleteSetupKeysBatch(idList string) error {
+func (s *Service) deleteSetupKeysBatch(idList string, concurrency int) error {
 	keyIDs := helpers.SplitCommaList(idList)
 	if len(keyIDs) == 0 {
 		return fmt.Errorf("no setup key IDs provided")
@@ -628,19 +726,56 @@ func (s *Service) deleteSetupKeysBatch(idList string) error {
 		return nil
 	}
 
-	// Process deletions with progress
+	if failCount := s.deleteSetupKeysWithProgress(keys, concurrency); failCount > 0 {
+		return fmt.Errorf("failed to delete %d setup key(s)", failCount)
+	}
+	return nil
+}
+
+const (
+	// defaultSetupKeyDeleteConcurrency is the default number of parallel bulk deletions
+	defaultSetupKeyDeleteConcurrency = 4
+	// maxSetupKeyDeleteConcurrency caps --concurrency to stay within API rate limits
+	maxSetupKeyDeleteConcurrency = 16
+)
+
+// deleteSetupKeysWithProgress deletes the given keys using up to concurrency parallel
+// requests. Status lines are printed in input order as results arrive, followed by a
+// summary; it returns the number of failed deletions.
+func (s *Service) deleteSetupKeysWithProgress(keys []models.SetupKey, concurrency int) int {
+	// One buffered channel per key lets workers finish out of order while output stays ordered
+	results := make([]chan error, len(keys))
+	for i := range results {
+		results[i] = make(chan error, 1)
+	}
+
+	jobs := make(chan int)
+	for w := 0; w < concurrency && w < len(keys); w++ {
+		go func() {
+			for i := range jobs {
+				resp, err := s.Client.MakeRequest("DELETE", "/setup-keys/"+keys[i].ID, nil)
+				if err == nil {
+					resp.Body.Close()
+				}
+				results[i] <- err
+			}
+		}()
+	}
+	go func() {
+		for i := range keys {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
 	var succeeded, failed int
 	for i, key := range keys {
-		fmt.Printf("[%d/%d] Deleting setup key '%s'... ", i+1, len(keys), key.Name)
-
-		resp, err := s.Client.MakeRequest("DELETE", "/setup-keys/"+key.ID, nil)
-		if err != nil {
-			fmt.Printf("Failed: %v\n", err)
+		if err := <-results[i]; err != nil {
+			fmt.Printf("[%d/%d] Deleting setup key '%s'... Failed: %v\n", i+1, len(keys), key.Name, err)
 			failed++
 			continue
 		}
-		resp.Body.Close()
-		fmt.Println("Done")
+		fmt.Printf("[%d/%d] Deleting setup key '%s'... Done\n", i+1, len(keys), key.Name)
 		succeeded++
 	}
 
@@ -651,22 +786,81 @@ func (s *Service) deleteSetupKeysBatch(idList string) error {
 	} else {
 		fmt.Printf("All %d setup keys deleted successfully\n", succeeded)
 	}
+	return failed
+}
 
-	return nil
+// setupKeyCleanupReason returns why a key is eligible for --delete-expired, or "" to keep it.
+// Reusable keys that reached their usage limit are only selected with includeUsedReusable.
+func setupKeyCleanupReason(key models.SetupKey, includeUsedReusable bool, now time.Time) string {
+	if key.Revoked {
+		return "revoked"
+	}
+	// Keys without an expiry come back as "0001-01-01T00:00:00Z"; never treat those as expired
+	expires, err := time.Parse(time.RFC3339, key.Expires)
+	hasExpiry := err == nil && !expires.IsZero() && expires.Year() > 1
+	if key.State == "expired" || (hasExpiry && expires.Before(now)) {
+		if hasExpiry {
+			return "expired " + expires.Format("2006-01-02")
+		}
+		return "expired"
+	}
+	if key.Type == "one-off" && key.UsedTimes >= 1 {
+		return "used (one-off)"
+	}
+	if includeUsedReusable && key.Type == "reusable" && key.UsageLimit > 0 && key.UsedTimes >= key.UsageLimit {
+		return fmt.Sprintf("usage limit reached (%d/%d)", key.UsedTimes, key.UsageLimit)
+	}
+	return ""
 }
 
-// deleteAllSetupKeys deletes all setup keys with confirmation
-func (s *Service) deleteAllSetupKeys() error {
-	// First, get all setup keys
-	resp, err := s.Client.MakeRequest("GET", "/setup-keys", nil)
+// deleteExpiredSetupKeys deletes revoked, expired, and used-up setup keys
+func (s *Service) deleteExpiredSetupKeys(dryRun, includeUsedReusable bool, concurrency int) error {
+	allKeys, err := client.GetList[models.SetupKey](s.Client, "/setup-keys")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
+	now := time.Now()
 	var keys []models.SetupKey
-	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+	var itemList []string
+	for _, key := range allKeys {
+		reason := setupKeyCleanupReason(key, includeUsedReusable, now)
+		if reason == "" {
+			continue
+		}
+		keys = append(keys, key)
+		itemList = append(itemList, fmt.Sprintf("%s (ID: %s, Type: %s, Reason: %s)", key.Name, key.ID, key.Type, reason))
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("No expired, revoked, or used setup keys found.")
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: %d setup key(s) would be deleted:\n", len(keys))
+		for _, item := range itemList {
+			fmt.Printf("  - %s\n", item)
+		}
+		return nil
+	}
+
+	if !helpers.ConfirmBulkDeletion("setup keys", itemList, len(keys)) {
+		return nil
+	}
+
+	if failCount := s.deleteSetupKeysWithProgress(keys, concurrency); failCount > 0 {
+		return fmt.Errorf("failed to delete %d setup key(s)", failCount)
+	}
+	return nil
+}
+
+// deleteAllSetupKeys deletes all setup keys with confirmation
+func (s *Service) deleteAllSetupKeys(concurrency int) error {
+	// First, get all setup keys
+	keys, err := client.GetList[models.SetupKey](s.Client, "/setup-keys")
+	if err != nil {
+		return err
 	}
 
 	if len(keys) == 0 {
@@ -687,26 +881,7 @@ func (s *Service) deleteAllSetupKeys() error {
 
 	// Delete all keys
 	fmt.Printf("\nDeleting %d setup key(s)...\n", len(keys))
-	successCount := 0
-	failCount := 0
-
-	for _, key := range keys {
-		resp, err := s.Client.MakeRequest("DELETE", "/setup-keys/"+key.ID, nil)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "✗ Failed to delete %s (%s): %v\n", key.Name, key.ID, err)
-			failCount++
-			continue
-		}
-		resp.Body.Close()
-
-		fmt.Printf("✓ Deleted %s (%s)\n", key.Name, key.ID)
-		successCount++
-	}
-
-	// Summary
-	fmt.Printf("\nDeletion complete: %d successful, %d failed\n", successCount, failCount)
-
-	if failCount > 0 {
+	if failCount := s.deleteSetupKeysWithProgress(keys, concurrency); failCount > 0 {
 		return fmt.Errorf("failed to delete %d setup key(s)", failCount)
 	}
 
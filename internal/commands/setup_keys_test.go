@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"netbird-manage/internal/models"
+)
+
+func TestSetupKeyCleanupReason(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		key                 models.SetupKey
+		includeUsedReusable bool
+		want                string
+	}{
+		{
+			name: "revoked",
+			key:  models.SetupKey{Type: "reusable", Revoked: true, State: "revoked", Expires: "2027-01-01T00:00:00Z"},
+			want: "revoked",
+		},
+		{
+			name: "expired",
+			key:  models.SetupKey{Type: "reusable", State: "expired", Expires: "2026-06-01T00:00:00Z"},
+			want: "expired 2026-06-01",
+		},
+		{
+			name: "expired by date while state still valid",
+			key:  models.SetupKey{Type: "reusable", Valid: true, State: "valid", Expires: "2026-06-14T00:00:00Z"},
+			want: "expired 2026-06-14",
+		},
+		{
+			name: "never expiring",
+			key:  models.SetupKey{Type: "reusable", Valid: true, State: "valid", Expires: "0001-01-01T00:00:00Z"},
+			want: "",
+		},
+		{
+			name: "valid until later",
+			key:  models.SetupKey{Type: "reusable", Valid: true, State: "valid", Expires: "2026-07-01T00:00:00Z"},
+			want: "",
+		},
+		{
+			name: "used one-off",
+			key:  models.SetupKey{Type: "one-off", State: "overused", UsedTimes: 1, Expires: "0001-01-01T00:00:00Z"},
+			want: "used (one-off)",
+		},
+		{
+			name: "usage limit reached, not selected",
+			key:  models.SetupKey{Type: "reusable", State: "overused", UsedTimes: 5, UsageLimit: 5, Expires: "2027-01-01T00:00:00Z"},
+			want: "",
+		},
+		{
+			name:                "usage limit reached, selected",
+			key:                 models.SetupKey{Type: "reusable", State: "overused", UsedTimes: 5, UsageLimit: 5, Expires: "2027-01-01T00:00:00Z"},
+			includeUsedReusable: true,
+			want:                "usage limit reached (5/5)",
+		},
+		{
+			name:                "unlimited reusable key",
+			key:                 models.SetupKey{Type: "reusable", Valid: true, State: "valid", UsedTimes: 40, Expires: "0001-01-01T00:00:00Z"},
+			includeUsedReusable: true,
+			want:                "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setupKeyCleanupReason(tt.key, tt.includeUsedReusable, now); got != tt.want {
+				t.Errorf("setupKeyCleanupReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiresInUntilEndOfDayAcrossDST(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	defer func(saved *time.Location) { time.Local = saved }(time.Local)
+	time.Local = location
+
+	// 2026-03-08 is 23 hours long in New York; the key must still expire at 23:59:59 local time
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, location)
+	expiresAt, _, err := expiresInUntil("2026-03-08", now)
+	if err != nil {
+		t.Fatalf("expiresInUntil: %v", err)
+	}
+	if got := expiresAt.Format("2006-01-02 15:04:05"); got != "2026-03-08 23:59:59" {
+		t.Errorf("expiresAt = %s, want 2026-03-08 23:59:59", got)
+	}
+}
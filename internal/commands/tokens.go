@@ -4,11 +4,15 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -21,18 +25,23 @@ func (s *Service) HandleTokensCommand(args []string) error {
 	// Query flags
 	listFlag := tokenCmd.Bool("list", false, "List all personal access tokens")
 	inspectFlag := tokenCmd.String("inspect", "", "Inspect token by ID")
-	outputFlag := tokenCmd.String("output", "table", "Output format: table or json")
+	outputFlag := tokenCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// Create flags
 	createFlag := tokenCmd.Bool("create", false, "Create a new personal access token")
 	name := tokenCmd.String("name", "", "Token name/description")
 	expiresIn := tokenCmd.Int("expires-in", 90, "Expiration in days (1-365)")
+	printEnvFlag := tokenCmd.Bool("print-env", false, "Print a shell line that exports the new token (for eval)")
+	shellFlag := tokenCmd.String("shell", "sh", "Shell syntax for --print-env: sh, fish, or powershell")
 
 	// Delete flags
 	revokeFlag := tokenCmd.String("revoke", "", "Revoke/delete token by ID")
+	revokeAllExpiredFlag := tokenCmd.Bool("revoke-all-expired", false, "Revoke every token past its expiration date")
+	dryRunFlag := tokenCmd.Bool("dry-run", false, "List expired tokens without revoking them (use with --revoke-all-expired)")
 
 	// User ID flag (optional - defaults to current user)
 	userID := tokenCmd.String("user-id", "", "User ID (defaults to current user)")
+	tokenCmd.StringVar(userID, "user", "", "Alias for --user-id")
 
 	if err := tokenCmd.Parse(args[1:]); err != nil {
 		return err
@@ -66,13 +75,22 @@ func (s *Service) HandleTokensCommand(args []string) error {
 		if *expiresIn < 1 || *expiresIn > 365 {
 			return fmt.Errorf("--expires-in must be between 1 and 365 days")
 		}
-		return s.createToken(targetUserID, *name, *expiresIn)
+		if *printEnvFlag {
+			if _, err := tokenEnvLines(*shellFlag, "", ""); err != nil {
+				return err
+			}
+		}
+		return s.createToken(targetUserID, *name, *expiresIn, *printEnvFlag, *shellFlag)
 	}
 
 	if *revokeFlag != "" {
 		return s.revokeToken(targetUserID, *revokeFlag)
 	}
 
+	if *revokeAllExpiredFlag {
+		return s.revokeExpiredTokens(targetUserID, *dryRunFlag)
+	}
+
 	tokenCmd.Usage()
 	return nil
 }
@@ -82,7 +100,8 @@ func (s *Service) getCurrentUserID() (string, error) {
 	resp, err := s.Client.MakeRequest("GET", "/users/current", nil)
 	if err != nil {
 		// Check if it's a 403 error (service token)
-		if resp != nil && resp.StatusCode == 403 {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.IsForbidden() {
 			return "", fmt.Errorf("unable to get current user: service user tokens cannot access /users/current. Please provide --user-id flag with your user ID. Use 'user --list' to find your user ID")
 		}
 		return "", err
@@ -119,7 +138,7 @@ func (s *Service) listTokens(userID string, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(tokens, "", "  ")
+		output, err := helpers.MarshalJSONOutput(tokens)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -128,8 +147,7 @@ func (s *Service) listTokens(userID string, outputFormat string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tCREATED AT\tEXPIRES\tLAST USED\tCREATED BY")
-	fmt.Fprintln(w, "--\t----\t----------\t-------\t---------\t----------")
+	helpers.TableHeader(w, "ID", "NAME", "CREATED AT", "EXPIRES", "LAST USED", "CREATED BY")
 
 	for _, token := range tokens {
 		lastUsed := token.LastUsed
@@ -168,7 +186,7 @@ func (s *Service) inspectToken(userID, tokenID string, outputFormat string) erro
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(token, "", "  ")
+		output, err := helpers.MarshalJSONOutput(token)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -192,7 +210,7 @@ func (s *Service) inspectToken(userID, tokenID string, outputFormat string) erro
 }
 
 // createToken creates a new personal access token
-func (s *Service) createToken(userID, name string, expiresIn int) error {
+func (s *Service) createToken(userID, name string, expiresIn int, printEnv bool, shell string) error {
 	req := models.TokenCreateRequest{
 		Name:      name,
 		ExpiresIn: expiresIn,
@@ -215,22 +233,73 @@ func (s *Service) createToken(userID, name string, expiresIn int) error {
 		return fmt.Errorf("failed to decode response: %v", err)
 	}
 
+	// With --print-env, stdout carries only the export lines so the output can be eval'd;
+	// the details and warning go to stderr
+	out := os.Stdout
+	if printEnv {
+		out = os.Stderr
+	}
+
 	// Display the token prominently with warning
-	fmt.Println("Token created successfully!")
-	fmt.Println()
-	fmt.Println("IMPORTANT: Save this token now - it won't be shown again!")
-	fmt.Println("============================================================")
-	fmt.Printf("Token: %s\n", tokenResp.PlainToken)
-	fmt.Println("============================================================")
-	fmt.Println()
-	fmt.Printf("Token ID:     %s\n", tokenResp.PersonalAccessToken.ID)
-	fmt.Printf("Name:         %s\n", tokenResp.PersonalAccessToken.Name)
-	fmt.Printf("Expires:      %s\n", tokenResp.PersonalAccessToken.ExpirationDate)
-	fmt.Printf("Created By:   %s\n", tokenResp.PersonalAccessToken.CreatedBy)
+	fmt.Fprintln(out, "Token created successfully!")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "IMPORTANT: Save this token now - it won't be shown again!")
+	fmt.Fprintln(out, "============================================================")
+	fmt.Fprintf(out, "Token: %s\n", tokenResp.PlainToken)
+	fmt.Fprintln(out, "============================================================")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Token ID:     %s\n", tokenResp.PersonalAccessToken.ID)
+	fmt.Fprintf(out, "Name:         %s\n", tokenResp.PersonalAccessToken.Name)
+	fmt.Fprintf(out, "Expires:      %s\n", tokenResp.PersonalAccessToken.ExpirationDate)
+	fmt.Fprintf(out, "Created By:   %s\n", tokenResp.PersonalAccessToken.CreatedBy)
+
+	if printEnv {
+		lines, err := tokenEnvLines(shell, tokenResp.PlainToken, s.Client.ManagementURL)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	}
 
 	return nil
 }
 
+// tokenEnvLines returns the lines that set NETBIRD_API_TOKEN and NETBIRD_MANAGEMENT_URL in
+// the given shell, with values quoted so they are safe to eval
+func tokenEnvLines(shell, token, managementURL string) ([]string, error) {
+	vars := [][2]string{{"NETBIRD_API_TOKEN", token}}
+	if managementURL != "" {
+		vars = append(vars, [2]string{"NETBIRD_MANAGEMENT_URL", managementURL})
+	}
+
+	var format func(name, value string) string
+	switch strings.ToLower(shell) {
+	case "sh", "bash", "zsh":
+		format = func(name, value string) string {
+			return fmt.Sprintf("export %s='%s'", name, strings.ReplaceAll(value, "'", `'\''`))
+		}
+	case "fish":
+		format = func(name, value string) string {
+			value = strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), "'", `\'`)
+			return fmt.Sprintf("set -gx %s '%s'", name, value)
+		}
+	case "powershell", "pwsh":
+		format = func(name, value string) string {
+			return fmt.Sprintf("$env:%s = '%s'", name, strings.ReplaceAll(value, "'", "''"))
+		}
+	default:
+		return nil, fmt.Errorf("invalid --shell: %s (must be sh, fish, or powershell)", shell)
+	}
+
+	lines := make([]string, len(vars))
+	for i, v := range vars {
+		lines[i] = format(v[0], v[1])
+	}
+	return lines, nil
+}
+
 // revokeToken deletes/revokes a personal access token
 func (s *Service) revokeToken(userID, tokenID string) error {
 	endpoint := fmt.Sprintf("/users/%s/tokens/%s", userID, tokenID)
@@ -270,3 +339,67 @@ func (s *Service) revokeToken(userID, tokenID string) error {
 	fmt.Printf("Token revoked successfully: %s\n", tokenID)
 	return nil
 }
+
+// revokeExpiredTokens deletes every token of a user whose expiration date has passed
+func (s *Service) revokeExpiredTokens(userID string, dryRun bool) error {
+	endpoint := fmt.Sprintf("/users/%s/tokens", userID)
+	tokens, err := client.GetList[models.PersonalAccessToken](s.Client, endpoint)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var expired []models.PersonalAccessToken
+	var itemList []string
+	for _, token := range tokens {
+		expiresAt, err := time.Parse(time.RFC3339, token.ExpirationDate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Skipping token %s: cannot parse expiration date '%s'\n", token.ID, token.ExpirationDate)
+			continue
+		}
+		if expiresAt.After(now) {
+			continue
+		}
+		expired = append(expired, token)
+		itemList = append(itemList, fmt.Sprintf("%s (ID: %s, Expired: %s)", token.Name, token.ID, token.ExpirationDate))
+	}
+
+	if len(expired) == 0 {
+		fmt.Printf("No expired tokens found (%d token(s) checked).\n", len(tokens))
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: %d expired token(s) would be revoked:\n", len(expired))
+		for _, item := range itemList {
+			fmt.Printf("  - %s\n", item)
+		}
+		return nil
+	}
+
+	if !helpers.ConfirmBulkDeletion("tokens", itemList, len(expired)) {
+		return nil
+	}
+
+	var succeeded, failed int
+	for i, token := range expired {
+		fmt.Printf("[%d/%d] Revoking token '%s'... ", i+1, len(expired), token.Name)
+
+		resp, err := s.Client.MakeRequest("DELETE", fmt.Sprintf("/users/%s/tokens/%s", userID, token.ID), nil)
+		if err != nil {
+			fmt.Printf("Failed: %v\n", err)
+			failed++
+			continue
+		}
+		resp.Body.Close()
+		fmt.Println("Done")
+		succeeded++
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("revoked %d expired token(s), %d failed", succeeded, failed)
+	}
+	fmt.Printf("All %d expired tokens revoked successfully\n", succeeded)
+	return nil
+}
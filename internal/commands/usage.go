@@ -8,15 +8,37 @@ func PrintUsage() {
 	fmt.Println("----------------------")
 	fmt.Println("A simple tool to manage your NetBird network via the API.")
 	fmt.Println("\nUsage:")
-	fmt.Println("  netbird-manage [--yes] [--debug] <command> [arguments]")
+	fmt.Println("  netbird-manage [--yes] [--confirm-phrase <text>] [--debug] [--timings] [--env-file <path>] [--template <tmpl>] [--fields <a,b>] [--extra-header <h>] [--request-id <id>] [--max-in-flight <n>] [--api-version <v>] [--no-headers] <command> [arguments]")
 	fmt.Println("\nGlobal Flags:")
 	fmt.Println("  --yes, -y                     Skip confirmation prompts (for automation)")
+	fmt.Println("  --confirm-phrase <text>       Confirm bulk deletions non-interactively (e.g. \"delete 3 groups\")")
 	fmt.Println("  --debug, -d                   Enable verbose debug output (HTTP requests/responses)")
+	fmt.Println("  --timings                     Print per-endpoint API call counts and durations at exit")
+	fmt.Println("  --env-file <path>             Load NETBIRD_API_TOKEN / NETBIRD_MANAGEMENT_URL from a KEY=VALUE file")
+	fmt.Println("  --no-headers                  Omit table header and separator rows (for awk/cut pipelines)")
+	fmt.Println("  --template <tmpl>             Render list output (peers, groups, policies, routes) with a Go template")
+	fmt.Println("  --fields <a,b,c>              With --output json, keep only these top-level fields of each object")
+	fmt.Println("  --extra-header 'Name: Value'  Send an extra header with every request (repeatable; saved by connect)")
+	fmt.Println("  --request-id <id>             Send this X-Request-Id on every request instead of a generated one")
+	fmt.Println("  --max-in-flight <n>           Cap concurrent API requests, including --concurrency workers (default: 8)")
+	fmt.Println("  --api-version <version>       Assume this management server version instead of probing it (e.g. 0.35.0)")
+	fmt.Println("\nEnvironment:")
+	fmt.Println("  NETBIRD_OUTPUT                Default --output format (table, wide, or json); overrides connect --default-output")
+	fmt.Println("  NETBIRD_CONFIG_PASSPHRASE     Passphrase for a token stored with connect --encrypt (otherwise prompted)")
 	fmt.Println("\nAvailable Commands:")
 	fmt.Println("  connect                       Check current connection status")
 	fmt.Println("  connect [flags]               Connect and save your API token")
 	fmt.Println("    --token <key>               (Required) Your NetBird API token")
+	fmt.Println("    --token-from-stdin          Read the token from stdin instead (keeps it out of shell history)")
+	fmt.Println("    --token-from-file <path>    Read the token from a file instead")
 	fmt.Println("    --management-url <url>      (Optional) Your self-hosted management URL")
+	fmt.Println("    --default-output <format>   (Optional) Store default --output: table, wide, or json")
+	fmt.Println("    --skip-validation           (Optional) Save without testing the connection (offline setups)")
+	fmt.Println("    --encrypt                   (Optional) Encrypt the stored token with a passphrase")
+	fmt.Println()
+	fmt.Println("  doctor [--timeout <dur>]      Diagnose config, network, TLS, and token problems")
+	fmt.Println()
+	fmt.Println("  completion <bash|zsh|fish>    Print a shell completion script")
 	fmt.Println()
 	fmt.Println("  peer ...                      Manage peers (run 'netbird-manage peer' for options)")
 	fmt.Println()
@@ -65,8 +87,21 @@ func PrintPeerUsage() {
 	fmt.Println("  --list                            List all peers")
 	fmt.Println("    --filter-name <pattern>         Filter by name (supports wildcards: ubuntu*)")
 	fmt.Println("    --filter-ip <pattern>           Filter by IP address pattern")
+	fmt.Println("    --filter-version <expr>         Filter by client version: '<0.28.0', '>=0.30', '==0.29.1'")
+	fmt.Println("    --group <id-or-name,...>        Only peers in these groups")
+	fmt.Println("    --group-match <any|all>         Member of any group (default) or of all of them")
+	fmt.Println("    --filter-connected <true|false> Only online (true) or offline (false) peers")
+	fmt.Println("    --last-seen-before <time>       Last seen before: RFC3339, YYYY-MM-DD, or ago (90d)")
+	fmt.Println("    --last-seen-after <time>        Last seen after: RFC3339, YYYY-MM-DD, or ago (24h)")
+	fmt.Println("    --sort <keys>                   Sort by name, ip, last-seen, connected, version (-key = descending)")
+	fmt.Println("    --output wide                   Add SSH, expiration, group count, and last-seen columns")
 	fmt.Println("  --inspect <peer-id>               Inspect a single peer")
+	fmt.Println("    --output <table|json>           JSON includes the raw last_seen timestamp")
+	fmt.Println("    --with-routing                  Also list routes and networks the peer routes for")
+	fmt.Println("    --show-policies                 Also list policies whose rules reference the peer's groups")
 	fmt.Println("  --accessible-peers <peer-id>      List peers accessible from the specified peer")
+	fmt.Println("  --export-inventory <file.csv>     Write a CSV asset report of all peers")
+	fmt.Println("    --group <group-id-or-name>      Only include peers in this group")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --remove <peer-id>                Remove a peer from your network")
@@ -76,6 +111,9 @@ func PrintPeerUsage() {
 	fmt.Println("    --add-group <group-id>          Add peer to a group (requires --edit)")
 	fmt.Println("    --remove-group <group-id>       Remove peer from a group (requires --edit)")
 	fmt.Println()
+	fmt.Println("  --rename <peer-id-or-name>        Rename a peer (keeps all other settings)")
+	fmt.Println("    --new-name <new-name>           New name for the peer (required)")
+	fmt.Println()
 	fmt.Println("  --update <peer-id>                Update peer settings")
 	fmt.Println("    --rename <new-name>             Change peer name")
 	fmt.Println("    --ssh-enabled <true|false>      Enable/disable SSH access")
@@ -83,6 +121,16 @@ func PrintPeerUsage() {
 	fmt.Println("    --inactivity-expiration <true|false> Enable/disable inactivity expiration")
 	fmt.Println("    --approval-required <true|false> Require approval (cloud-only)")
 	fmt.Println("    --ip <ip-address>               Set IP (must be in 100.64.0.0/10 range)")
+	fmt.Println()
+	fmt.Println("  --set-ssh <true|false>            Enable/disable SSH on several peers at once")
+	fmt.Println("    --peer <peer-id-or-name>        Target a single peer")
+	fmt.Println("    --group <id-or-name,...>        Target peers in these groups (--group-match any|all)")
+	fmt.Println("    --all                           Target every peer")
+	fmt.Println()
+	fmt.Println("  --bulk-update-from-csv <file>     Update peers from a CSV keyed by a 'peer' column (ID or name)")
+	fmt.Println("                                    Columns: name, ssh_enabled, login_expiration,")
+	fmt.Println("                                    inactivity_expiration, approval (empty cell = unchanged)")
+	fmt.Println("    --dry-run                       Show the per-row changes without updating")
 }
 
 // PrintGroupUsage provides specific help for the 'group' command
@@ -92,11 +140,16 @@ func PrintGroupUsage() {
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all groups")
 	fmt.Println("    --filter-name <pattern>        Filter by name (supports wildcards: prod-*)")
+	fmt.Println("    --show-usage                   Show references per group (pol:2 route:1 dns:0 key:0 user:1)")
+	fmt.Println("    --unused-only                  List only groups with no peers, resources, or references")
+	fmt.Println("    --empty-only                   List only groups with no peers or resources (references ignored)")
 	fmt.Println("  --inspect <group-id>             Inspect a specific group")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --create <group-name>            Create a new group")
 	fmt.Println("    --peers <id1,id2,...>          (Optional) Add peers on creation")
+	fmt.Println("    --from-peers-filter <expr>     (Optional) Add peers matching os=, version<, connected=, name-pattern=")
+	fmt.Println("    --dry-run                      Show matching peers without creating the group")
 	fmt.Println()
 	fmt.Println("  --delete <group-id>              Delete a group")
 	fmt.Println("  --delete-batch <id1,id2,...>     Delete multiple groups (comma-separated IDs)")
@@ -104,9 +157,12 @@ func PrintGroupUsage() {
 	fmt.Println()
 	fmt.Println("  --rename <group-id>              Rename a group")
 	fmt.Println("    --new-name <new-name>          New name for the group (required)")
+	fmt.Println("  --rename-batch <file>            Rename groups from 'old-name: new-name' pairs (YAML or .csv)")
+	fmt.Println("    --dry-run                      Show the renames without applying them")
 	fmt.Println()
-	fmt.Println("  --add-peers <group-id>           Add peers to a group (bulk)")
+	fmt.Println("  --add-peers <group-id>           Add peers to a group (bulk); existing members are skipped")
 	fmt.Println("    --peers <id1,id2,...>          Comma-separated peer IDs (required)")
+	fmt.Println("    --verbose                      List each peer as added or already present (implied by --debug)")
 	fmt.Println()
 	fmt.Println("  --remove-peers <group-id>        Remove peers from a group (bulk)")
 	fmt.Println("    --peers <id1,id2,...>          Comma-separated peer IDs (required)")
@@ -120,6 +176,9 @@ func PrintNetworkUsage() {
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                              List all networks")
 	fmt.Println("    --filter-name <pattern>           Filter by name (supports wildcards: prod-*)")
+	fmt.Println("    --sort <key>                      Sort by name, routers, or resources (-key = descending)")
+	fmt.Println("    --output <table|json>             JSON includes IDs and router/resource/policy lists")
+	fmt.Println("    --output wide                     Add routing peer and routing group columns")
 	fmt.Println("  --inspect <network-id>              Inspect a specific network")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
@@ -137,6 +196,8 @@ func PrintNetworkUsage() {
 	fmt.Println("\n=== Resource Operations ===")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list-resources <network-id>       List all resources in a network")
+	fmt.Println("    --filter-enabled <true|false>     Filter by enabled state")
+	fmt.Println("    --filter-type <type>              Filter by type: host, subnet, domain")
 	fmt.Println("  --inspect-resource                  Inspect a specific resource")
 	fmt.Println("    --network-id <id>                 Network ID (required)")
 	fmt.Println("    --resource-id <id>                Resource ID (required)")
@@ -163,10 +224,17 @@ func PrintNetworkUsage() {
 	fmt.Println("    --network-id <id>                 Network ID (required)")
 	fmt.Println("    --resource-id <id>                Resource ID (required)")
 	fmt.Println()
+	fmt.Println("  --move-resource                     Recreate a resource in another network, then delete the original")
+	fmt.Println("    --from <network>                  Source network ID or name (required)")
+	fmt.Println("    --to <network>                    Target network ID or name (required)")
+	fmt.Println("    --resource-id <id>                Resource ID in the source network (required)")
+	fmt.Println("    --dry-run                         Show the move without changing anything")
+	fmt.Println()
 	fmt.Println("\n=== Router Operations ===")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list-routers <network-id>         List all routers in a network")
-	fmt.Println("  --list-all-routers                  List all routers across all networks")
+	fmt.Println("  --list-all-routers                  List all routers across all networks, grouped by network")
+	fmt.Println("    --filter-enabled <true|false>     Only show enabled or disabled routers")
 	fmt.Println("  --inspect-router                    Inspect a specific router")
 	fmt.Println("    --network-id <id>                 Network ID (required)")
 	fmt.Println("    --router-id <id>                  Router ID (required)")
@@ -174,6 +242,7 @@ func PrintNetworkUsage() {
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --add-router <network-id>           Add a router to a network")
 	fmt.Println("    --peer <peer-id>                  Use single peer as router (use this OR --peer-groups)")
+	fmt.Println("    --peer-name <name>                Use single peer by name or hostname (instead of --peer)")
 	fmt.Println("    --peer-groups <id1,id2,...>       Use peer groups as routers (use this OR --peer)")
 	fmt.Println("    --metric <1-9999>                 Route metric, lower = higher priority (default: 100)")
 	fmt.Println("    --masquerade                      Enable masquerading (NAT)")
@@ -185,6 +254,7 @@ func PrintNetworkUsage() {
 	fmt.Println("    --network-id <id>                 Network ID (required)")
 	fmt.Println("    --router-id <id>                  Router ID (required)")
 	fmt.Println("    --peer <peer-id>                  Change to single peer (optional)")
+	fmt.Println("    --peer-name <name>                Change to single peer by name or hostname (optional)")
 	fmt.Println("    --peer-groups <id1,id2,...>       Change to peer groups (optional)")
 	fmt.Println("    --metric <1-9999>                 Update metric (optional)")
 	fmt.Println("    --masquerade/--no-masquerade      Toggle masquerading")
@@ -201,7 +271,11 @@ func PrintPolicyUsage() {
 	fmt.Println("\nManage access control policies.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all policies")
+	fmt.Println("    --output wide                  Add the posture check count column")
 	fmt.Println("  --inspect <policy-id>            Inspect a specific policy")
+	fmt.Println("    --matrix                       Show effective source -> destination access per rule")
+	fmt.Println("    --include-disabled             Include disabled rules in the matrix")
+	fmt.Println("  --validate <policy-id>           Flag disabled, duplicate, conflicting, or broken rules (exits non-zero)")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --create <name>                  Create a new policy")
@@ -209,11 +283,28 @@ func PrintPolicyUsage() {
 	fmt.Println("    --enabled                      Enable policy (default)")
 	fmt.Println("    --disabled                     Create disabled policy")
 	fmt.Println()
+	fmt.Println("  --create-from-file <file>        Create one policy with all its rules from YAML/JSON")
+	fmt.Println("                                   (groups by name; all rules validated before creating)")
+	fmt.Println()
+	fmt.Println("  --export <policy-id> [file]      Write one policy to a YAML/JSON file (stdout if no file)")
+	fmt.Println("                                   (groups and posture checks by name, for other accounts)")
+	fmt.Println("  --import <file>                  Create a policy from an --export file (warns about missing groups)")
+	fmt.Println()
 	fmt.Println("  --delete <policy-id>             Delete a policy")
 	fmt.Println()
 	fmt.Println("  --enable <policy-id>             Enable a policy")
 	fmt.Println("  --disable <policy-id>            Disable a policy")
 	fmt.Println()
+	fmt.Println("  --enable-rule <rule>             Enable a single rule (name or ID)")
+	fmt.Println("  --disable-rule <rule>            Disable a single rule (name or ID)")
+	fmt.Println("  --toggle-rule <rule>             Flip a single rule's enabled state (name or ID)")
+	fmt.Println("    --policy-id <id>               Policy containing the rule (required)")
+	fmt.Println()
+	fmt.Println("  --move-rule <rule>               Reorder a rule within a policy (name or ID)")
+	fmt.Println("    --policy-id <id>               Policy containing the rule (required)")
+	fmt.Println("    --to <n>                       New 1-based position, or")
+	fmt.Println("    --before <rule> / --after <rule>  Position relative to another rule")
+	fmt.Println()
 	fmt.Println("  --add-rule <policy-id>           Add a rule to a policy")
 	fmt.Println("    --rule-name <name>             Rule name (required)")
 	fmt.Println("    --sources <groups>             Source group IDs/names (comma-separated)")
@@ -223,6 +314,7 @@ func PrintPolicyUsage() {
 	fmt.Println("    --action <action>              Action: accept or drop (default: accept)")
 	fmt.Println("    --bidirectional                Enable bidirectional traffic (default)")
 	fmt.Println("    --unidirectional               Disable bidirectional traffic")
+	fmt.Println("    --allow-duplicate-names        Allow a rule name already used in the policy")
 }
 
 // PrintSetupKeyUsage provides specific help for the 'setup-key' command
@@ -231,12 +323,15 @@ func PrintSetupKeyUsage() {
 	fmt.Println("\nManage device registration/setup keys.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all setup keys")
-	fmt.Println("  --inspect <key-id>               Inspect a specific setup key")
+	fmt.Println("    --output wide                  Add ephemeral, extra DNS labels, and last-used columns")
+	fmt.Println("  --inspect <key-id>               Inspect a specific setup key (resolves auto-group names)")
+	fmt.Println("    --output <table|json>          Output format (default: table)")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --create <name>                  Create a new setup key")
 	fmt.Println("    --type <type>                  Key type: one-off or reusable (default: one-off)")
 	fmt.Println("    --expires-in <days>            Expiration in days (default: 30)")
+	fmt.Println("    --expires-at <date>            Expire on a date: YYYY-MM-DD or RFC3339 (instead of --expires-in)")
 	fmt.Println("    --usage-limit <limit>          Max uses for reusable keys (default: 0 = unlimited)")
 	fmt.Println("    --auto-groups <groups>         Comma-separated group IDs/names for auto-assignment")
 	fmt.Println("    --ephemeral                    Create ephemeral peers (auto-removed when offline)")
@@ -247,6 +342,10 @@ func PrintSetupKeyUsage() {
 	fmt.Println("  --delete <key-id>                Delete a setup key")
 	fmt.Println("  --delete-batch <id1,id2,...>     Delete multiple keys (comma-separated IDs)")
 	fmt.Println("  --delete-all                     Delete ALL setup keys (requires confirmation)")
+	fmt.Println("  --delete-expired                 Delete revoked, expired, and used one-off keys")
+	fmt.Println("    --include-used-reusable        Also delete reusable keys that reached their usage limit")
+	fmt.Println("    --dry-run                      Show which keys would be deleted without deleting")
+	fmt.Println("  --concurrency <n>                Parallel deletions for bulk deletes (default: 4, max: 16; capped by --max-in-flight)")
 	fmt.Println()
 	fmt.Println("  --revoke <key-id>                Revoke a setup key (disable without deleting)")
 }
@@ -257,24 +356,23 @@ func PrintUserUsage() {
 	fmt.Println("\nManage users and access.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all users")
-	fmt.Println("  --inspect <user-id>              Inspect a specific user")
+	fmt.Println("  --inspect <user-id-or-email>     Inspect a user, with auto-group names resolved")
 	fmt.Println("  --me                             Show current user info")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
-	fmt.Println("  --invite <email>                 Invite a new user")
-	fmt.Println("    --role <role>                  Role: admin, user, owner (default: user)")
-	fmt.Println("    --auto-groups <groups>         Comma-separated group IDs for auto-assignment")
-	fmt.Println()
-	fmt.Println("  --create-service <name>          Create a service user")
-	fmt.Println("    --role <role>                  Role: admin, user (default: user)")
-	fmt.Println("    --auto-groups <groups>         Comma-separated group IDs for auto-assignment")
+	fmt.Println("  --invite, --create               Invite/create a new user")
+	fmt.Println("    --email <email>                User email (required unless --service-user)")
+	fmt.Println("    --name <name>                  User full name (required with --service-user)")
+	fmt.Println("    --role <role>                  admin, user, owner, billing_admin, auditor, network_admin (default: user)")
+	fmt.Println("    --auto-groups <groups>         Comma-separated group names or IDs for auto-assignment")
+	fmt.Println("    --service-user                 Create as a service user")
 	fmt.Println()
 	fmt.Println("  --update <user-id>               Update a user")
 	fmt.Println("    --role <role>                  New role")
-	fmt.Println("    --auto-groups <groups>         New auto-groups")
+	fmt.Println("    --auto-groups <groups>         New auto-groups (names or IDs)")
 	fmt.Println()
-	fmt.Println("  --block <user-id>                Block a user")
-	fmt.Println("  --unblock <user-id>              Unblock a user")
+	fmt.Println("  --block <user-id-or-email>       Block a user (asks for confirmation)")
+	fmt.Println("  --unblock <user-id-or-email>     Unblock a user")
 	fmt.Println()
 	fmt.Println("  --remove <user-id>               Remove a user")
 	fmt.Println("  --resend-invite <user-id>        Resend invitation email")
@@ -289,10 +387,17 @@ func PrintTokenUsage() {
 	fmt.Println("  --inspect <token-id>             Inspect a specific token")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
-	fmt.Println("  --create <name>                  Create a new token")
-	fmt.Println("    --expires-in <days>            Expiration in days (1-365, default: 30)")
+	fmt.Println("  --create --name <name>           Create a new token")
+	fmt.Println("    --expires-in <days>            Expiration in days (1-365, default: 90)")
+	fmt.Println("    --print-env                    Also print a line exporting the token (details go to stderr)")
+	fmt.Println("    --shell <sh|fish|powershell>   Syntax for --print-env (default: sh)")
 	fmt.Println()
 	fmt.Println("  --revoke <token-id>              Revoke/delete a token")
+	fmt.Println("  --revoke-all-expired             Revoke every token past its expiration date")
+	fmt.Println("    --dry-run                      List expired tokens without revoking them")
+	fmt.Println()
+	fmt.Println("Common Flags:")
+	fmt.Println("  --user-id, --user <user-id>      Act on another user's tokens (default: current user)")
 }
 
 // PrintRouteUsage provides specific help for the 'route' command
@@ -301,6 +406,8 @@ func PrintRouteUsage() {
 	fmt.Println("\nManage network routes.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all routes")
+	fmt.Println("    --resolve-names                Show group and routing peer names instead of counts")
+	fmt.Println("    --output <table|json>          Output format (JSON keeps API IDs)")
 	fmt.Println("  --inspect <route-id>             Inspect a specific route")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
@@ -314,7 +421,21 @@ func PrintRouteUsage() {
 	fmt.Println("    --masquerade                   Enable masquerading")
 	fmt.Println("    --description <desc>           Route description")
 	fmt.Println()
+	fmt.Println("  --create-batch                   Create one route per CIDR with shared settings")
+	fmt.Println("    --networks <cidrs>             Network CIDRs (comma-separated, required)")
+	fmt.Println("    --groups, --peer, --peer-groups, --metric, --masquerade, --description as above")
+	fmt.Println("    --network-id <id>              (Optional) Defaults to an identifier derived from each CIDR")
+	fmt.Println()
+	fmt.Println("  --update <route-id>              Update a route; only the flags given are changed")
+	fmt.Println("    --description, --network-id, --peer, --peer-groups, --groups, --metric as above")
+	fmt.Println("    --masquerade | --no-masquerade Turn masquerading on or off")
+	fmt.Println("    --enabled | --disabled         Enable or disable the route")
+	fmt.Println("    --keep-route | --no-keep-route Keep the route on peers after it is removed")
+	fmt.Println()
 	fmt.Println("  --delete <route-id>              Delete a route")
+	fmt.Println("  --delete-batch <id1,id2,...>     Delete multiple routes (with confirmation)")
+	fmt.Println("  --delete-disabled                Delete all disabled routes (with confirmation)")
+	fmt.Println("    --dry-run                      List the routes that would be deleted without deleting")
 	fmt.Println()
 	fmt.Println("  --enable <route-id>              Enable a route")
 	fmt.Println("  --disable <route-id>             Disable a route")
@@ -326,6 +447,9 @@ func PrintDNSUsage() {
 	fmt.Println("\nManage DNS nameserver groups.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all DNS nameserver groups")
+	fmt.Println("    --filter-name <pattern>        Filter by name (supports wildcards)")
+	fmt.Println("    --primary-only                 Show only primary groups")
+	fmt.Println("    --enabled-only                 Show only enabled groups")
 	fmt.Println("  --inspect <group-id>             Inspect a specific DNS group")
 	fmt.Println("  --settings                       Show DNS settings")
 	fmt.Println()
@@ -341,6 +465,7 @@ func PrintDNSUsage() {
 	fmt.Println()
 	fmt.Println("  --enable <group-id>              Enable a DNS group")
 	fmt.Println("  --disable <group-id>             Disable a DNS group")
+	fmt.Println("  --toggle <group-id>              Flip a DNS group between enabled and disabled")
 }
 
 // PrintPostureCheckUsage provides specific help for the 'posture-check' command
@@ -349,7 +474,13 @@ func PrintPostureCheckUsage() {
 	fmt.Println("\nManage device posture checks.")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all posture checks")
+	fmt.Println("    --filter-name <pattern>        Filter by name pattern (supports wildcards)")
+	fmt.Println("    --filter-type <type>           Filter by check type (nb-version, os-version, geo-location, ...)")
+	fmt.Println("    --show-usage                   Add a POLICIES column with the number of policies using each check")
+	fmt.Println("    --unused-only                  List only posture checks no policy references")
+	fmt.Println("    --output <table|json>          JSON includes each check's full definition")
 	fmt.Println("  --inspect <check-id>             Inspect a specific posture check")
+	fmt.Println("    --output <table|json>          Output format (default: table)")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --create <name>                  Create a posture check")
@@ -360,6 +491,10 @@ func PrintPostureCheckUsage() {
 	fmt.Println("    --network-allow <cidrs>        Allow network ranges")
 	fmt.Println("    --network-deny <cidrs>         Deny network ranges")
 	fmt.Println()
+	fmt.Println("  --clone <check-id> --create <name>")
+	fmt.Println("                                   Copy a posture check's definition to a new check")
+	fmt.Println("    --description <desc>           Description (default: copied from the source)")
+	fmt.Println()
 	fmt.Println("  --delete <check-id>              Delete a posture check")
 }
 
@@ -374,11 +509,24 @@ func PrintEventUsage() {
 	fmt.Println("    --start-date <date>            Start date (YYYY-MM-DD)")
 	fmt.Println("    --end-date <date>              End date (YYYY-MM-DD)")
 	fmt.Println()
+	fmt.Println("  --activity-codes                 List activity codes seen in recent audit events, with counts")
+	fmt.Println("    --since <duration>             Window to scan (default: 30d)")
+	fmt.Println()
 	fmt.Println("  --traffic                        List network traffic events (Cloud-only)")
 	fmt.Println("    --page <n>                     Page number")
 	fmt.Println("    --page-size <n>                Results per page")
+	fmt.Println("    --summary                      Aggregate totals across all pages")
+	fmt.Println("      --group-by <key>             peer, policy, protocol, direction (default: peer)")
+	fmt.Println("      --max-pages <n>              Safety cap on pages fetched (default: 50)")
+	fmt.Println()
+	fmt.Println("  --export <dir-or-zip>            Write audit and traffic events plus a manifest (.zip target: archive)")
+	fmt.Println("    --since <time>                 Window start: duration ago, YYYY-MM-DD, or RFC 3339 (default: 30d)")
+	fmt.Println("    --until <time>                 Window end (default: now)")
+	fmt.Println("    --format <json|csv>            File format (default: json)")
+	fmt.Println("    --max-events <n>               Safety cap on events per type (default: 100000)")
 	fmt.Println()
 	fmt.Println("  --json                           Output in JSON format")
+	fmt.Println("  --json-lines                     One JSON object per event per line (ndjson, for log shippers)")
 }
 
 // PrintGeoLocationUsage provides specific help for the 'geo' command
@@ -388,6 +536,7 @@ func PrintGeoLocationUsage() {
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --countries                      List all available country codes")
 	fmt.Println("  --cities <country-code>          List cities for a country (e.g., --cities US)")
+	fmt.Println("  --validate <locations>           Check posture-check locations (e.g., \"US:NewYork,GB:London\")")
 	fmt.Println()
 	fmt.Println("  --json                           Output in JSON format")
 }
@@ -399,12 +548,17 @@ func PrintAccountUsage() {
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all accounts")
 	fmt.Println("  --inspect <account-id>           Inspect account settings")
+	fmt.Println("  --export-settings <file>         Save current settings as a YAML/JSON baseline")
+	fmt.Println("  --settings-diff <file>           Compare current settings to a baseline (exits non-zero on drift)")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
 	fmt.Println("  --update <account-id>            Update account settings")
 	fmt.Println("    --peer-login-expiration <s>    Peer login expiration in seconds")
 	fmt.Println("    --peer-inactivity-expiration <s> Peer inactivity expiration in seconds")
 	fmt.Println()
+	fmt.Println("  --enable-traffic-logging         Enable traffic event logging (Cloud-only; asks for confirmation)")
+	fmt.Println("  --disable-traffic-logging        Disable traffic event logging (Cloud-only)")
+	fmt.Println()
 	fmt.Println("  --delete <account-id>            Delete an account (dangerous!)")
 }
 
@@ -413,20 +567,27 @@ func PrintIngressPortUsage() {
 	fmt.Println("Usage: netbird-manage ingress-port <flag> [arguments]")
 	fmt.Println("\nManage port forwarding (Cloud-only).")
 	fmt.Println("\nQuery Flags:")
-	fmt.Println("  --list <peer-id>                 List ingress ports for a peer")
-	fmt.Println("  --inspect                        Inspect a specific port allocation")
-	fmt.Println("    --peer-id <id>                 Peer ID (required)")
-	fmt.Println("    --port-id <id>                 Port allocation ID (required)")
+	fmt.Println("  --list                           List port allocations for a peer")
+	fmt.Println("    --peer <id>                    Peer ID (required)")
+	fmt.Println("    --output <table|json>          Output format (default: table)")
+	fmt.Println("  --inspect <allocation-id>        Inspect a specific port allocation")
+	fmt.Println("    --peer <id>                    Peer ID (required)")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
-	fmt.Println("  --create <peer-id>               Create a port forwarding rule")
+	fmt.Println("  --create                         Create a port forwarding rule")
+	fmt.Println("    --peer <id>                    Peer ID (required)")
 	fmt.Println("    --target-port <port>           Target port on the peer (required)")
 	fmt.Println("    --protocol <tcp|udp>           Protocol (default: tcp)")
 	fmt.Println("    --description <desc>           Description")
 	fmt.Println()
-	fmt.Println("  --delete                         Delete a port allocation")
-	fmt.Println("    --peer-id <id>                 Peer ID (required)")
-	fmt.Println("    --port-id <id>                 Port allocation ID (required)")
+	fmt.Println("  --update <allocation-id>         Update a port allocation")
+	fmt.Println("    --peer <id>                    Peer ID (required)")
+	fmt.Println("    --target-port <port>           Target port on the peer (required)")
+	fmt.Println()
+	fmt.Println("  --delete <allocation-id>         Delete a port allocation")
+	fmt.Println("    --peer <id>                    Peer ID (required)")
+	fmt.Println()
+	fmt.Println("Allocations without an assigned public port are shown as (pending).")
 }
 
 // PrintIngressPeerUsage provides specific help for the 'ingress-peer' command
@@ -435,6 +596,8 @@ func PrintIngressPeerUsage() {
 	fmt.Println("\nManage ingress peers (Cloud-only).")
 	fmt.Println("\nQuery Flags:")
 	fmt.Println("  --list                           List all ingress peers")
+	fmt.Println("    --filter-enabled <true|false>  Only show enabled or disabled peers")
+	fmt.Println("    --output <table|json>          Output format (default: table)")
 	fmt.Println("  --inspect <peer-id>              Inspect a specific ingress peer")
 	fmt.Println()
 	fmt.Println("Modification Flags:")
@@ -456,6 +619,17 @@ func PrintExportUsage() {
 	fmt.Println("  --full                           Export to a single file (default)")
 	fmt.Println("  --split                          Export to multiple files in a directory")
 	fmt.Println("  --format <yaml|json>             Output format (default: yaml)")
+	fmt.Println("  --progress                       Show a compact progress indicator (TTY only)")
+	fmt.Println("  --gzip                           Compress output files (.yml.gz / .json.gz)")
+	fmt.Println()
+	fmt.Println("Selective Export (can be combined):")
+	fmt.Println("  --groups-only                    Export only groups")
+	fmt.Println("  --policies-only                  Export only policies")
+	fmt.Println("  --networks-only                  Export only networks")
+	fmt.Println("  --routes-only                    Export only routes")
+	fmt.Println("  --dns-only                       Export only DNS nameserver groups")
+	fmt.Println("  --posture-only                   Export only posture checks")
+	fmt.Println("  --setup-keys-only                Export only setup keys")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  netbird-manage export                           # Export to single YAML file")
@@ -463,8 +637,9 @@ func PrintExportUsage() {
 	fmt.Println("  netbird-manage export --split                   # Export to multiple YAML files")
 	fmt.Println("  netbird-manage export --split --format json     # Export to multiple JSON files")
 	fmt.Println("  netbird-manage export /path/to/dir              # Export to specific directory")
+	fmt.Println("  netbird-manage export --policies-only           # Export only policies")
 	fmt.Println()
-	fmt.Println("Output files are named: netbird-manage-export-YYMMDD.{yml,json}")
+	fmt.Println("Output files are named: netbird-manage-export-YYMMDD.{yml,json}[.gz]")
 }
 
 // PrintImportUsage provides specific help for the 'import' command
@@ -478,10 +653,17 @@ func PrintImportUsage() {
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --apply                          Actually apply changes (default is dry-run)")
-	fmt.Println("  --update                         Update existing resources")
-	fmt.Println("  --skip-existing                  Skip resources that already exist")
-	fmt.Println("  --force                          Create or update all resources (upsert)")
+	fmt.Println("  --on-conflict <mode>             Existing resources: fail (default), update, skip, or upsert")
+	fmt.Println("  --update, --skip-existing, --force")
+	fmt.Println("                                   Deprecated aliases for --on-conflict update / skip / upsert")
 	fmt.Println("  --verbose                        Show detailed output")
+	fmt.Println("  --diff                           Show field-level differences against live state")
+	fmt.Println("                                   (never applies; exits non-zero if drift is found)")
+	fmt.Println("  --progress                       Show a compact progress indicator instead of per-resource")
+	fmt.Println("                                   lines (TTY only; the summary is still printed)")
+	fmt.Println("  --summary-only                   Hide per-resource lines and print only the summary and errors")
+	fmt.Println("  --prune                          Delete groups, policies, and networks not in the file")
+	fmt.Println("                                   (requires --apply; asks for confirmation; never setup keys)")
 	fmt.Println()
 	fmt.Println("Resource Filters:")
 	fmt.Println("  --groups-only                    Import only groups")
@@ -491,12 +673,14 @@ func PrintImportUsage() {
 	fmt.Println("  --dns-only                       Import only DNS nameserver groups")
 	fmt.Println("  --posture-only                   Import only posture checks")
 	fmt.Println("  --setup-keys-only                Import only setup keys")
+	fmt.Println("  --peers-only                     Import only peer settings (existing peers)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  netbird-manage import config.yml                       # Dry-run preview")
 	fmt.Println("  netbird-manage import config.yml --apply               # Apply changes")
-	fmt.Println("  netbird-manage import config.yml --apply --skip-existing")
+	fmt.Println("  netbird-manage import config.yml --apply --on-conflict skip")
 	fmt.Println("                                                         # Apply, skip existing resources")
+	fmt.Println("  netbird-manage import config.yml --diff                # Check for drift")
 	fmt.Println()
 	fmt.Println("Peer Migration:")
 	fmt.Println("  Peers must be migrated using the migrate command:")
@@ -505,3 +689,30 @@ func PrintImportUsage() {
 	fmt.Println()
 	fmt.Println("The input file should be a YAML file previously exported with 'netbird-manage export'.")
 }
+
+// PrintDoctorUsage provides specific help for the 'doctor' command
+func PrintDoctorUsage() {
+	fmt.Println("Usage: netbird-manage doctor [--timeout <duration>]")
+	fmt.Println("\nCheck that the CLI can reach and authenticate to the NetBird API.")
+	fmt.Println("\nChecks:")
+	fmt.Println("  Configuration      A token is configured (env file, environment, or config file)")
+	fmt.Println("  Management URL     The URL is a valid http(s) API URL")
+	fmt.Println("  Network            The management host accepts TCP connections")
+	fmt.Println("  TLS                The TLS handshake succeeds and the certificate is trusted")
+	fmt.Println("  API token          GET /peers succeeds with the token")
+	fmt.Println("  Token scope        Users, policies, groups, and setup keys can be read (warning only)")
+	fmt.Println("  API version        Server version, if the server exposes it")
+	fmt.Println("\nFlags:")
+	fmt.Println("  --timeout <dur>    Timeout for each network check (default: 10s)")
+	fmt.Println("\nExits non-zero if any check fails.")
+}
+
+// PrintCompletionUsage provides specific help for the 'completion' command
+func PrintCompletionUsage() {
+	fmt.Println("Usage: netbird-manage completion <bash|zsh|fish>")
+	fmt.Println("\nPrint a shell completion script for commands and their flags.")
+	fmt.Println("\nInstall:")
+	fmt.Println("  bash   netbird-manage completion bash > /etc/bash_completion.d/netbird-manage")
+	fmt.Println("  zsh    netbird-manage completion zsh > \"${fpath[1]}/_netbird-manage\"")
+	fmt.Println("  fish   netbird-manage completion fish > ~/.config/fish/completions/netbird-manage.fish")
+}
@@ -4,12 +4,15 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"netbird-manage/internal/client"
 	"netbird-manage/internal/helpers"
 	"netbird-manage/internal/models"
 )
@@ -22,22 +25,26 @@ func (s *Service) HandleUsersCommand(args []string) error {
 	// Query flags
 	listFlag := userCmd.Bool("list", false, "List all users")
 	meFlag := userCmd.Bool("me", false, "Get current user information")
+	inspectFlag := userCmd.String("inspect", "", "Inspect a user by ID or email")
 	serviceUserFilter := userCmd.Bool("service-users", false, "List only service users")
 	regularUserFilter := userCmd.Bool("regular-users", false, "List only regular users")
-	outputFlag := userCmd.String("output", "table", "Output format: table or json")
+	outputFlag := userCmd.String("output", helpers.DefaultOutputFormat, "Output format: table or json")
 
 	// Create/Invite flags
 	inviteFlag := userCmd.Bool("invite", false, "Invite a new user")
+	createFlag := userCmd.Bool("create", false, "Create a new user (same as --invite)")
 	email := userCmd.String("email", "", "User email address")
 	name := userCmd.String("name", "", "User full name")
-	role := userCmd.String("role", "user", "User role (admin, user, owner)")
-	autoGroups := userCmd.String("auto-groups", "", "Comma-separated group IDs for auto-assignment")
+	role := userCmd.String("role", "user", "User role ("+strings.Join(validUserRoles, ", ")+")")
+	autoGroups := userCmd.String("auto-groups", "", "Comma-separated group names or IDs for auto-assignment")
 	serviceUser := userCmd.Bool("service-user", false, "Create as service user")
 
 	// Update flags
 	updateFlag := userCmd.String("update", "", "Update user by ID")
 	blocked := userCmd.Bool("blocked", false, "Block user access (use with --update)")
 	unblocked := userCmd.Bool("unblocked", false, "Unblock user access (use with --update)")
+	blockFlag := userCmd.String("block", "", "Block a user by ID or email, revoking their access")
+	unblockFlag := userCmd.String("unblock", "", "Unblock a user by ID or email")
 
 	// Delete flags
 	removeFlag := userCmd.String("remove", "", "Remove user by ID")
@@ -54,6 +61,10 @@ func (s *Service) HandleUsersCommand(args []string) error {
 		return s.getCurrentUser(*outputFlag)
 	}
 
+	if *inspectFlag != "" {
+		return s.inspectUser(*inspectFlag, *outputFlag)
+	}
+
 	if *listFlag || *serviceUserFilter || *regularUserFilter {
 		filterType := ""
 		if *serviceUserFilter {
@@ -64,17 +75,21 @@ func (s *Service) HandleUsersCommand(args []string) error {
 		return s.listUsers(filterType, *outputFlag)
 	}
 
-	if *inviteFlag {
-		if *email == "" {
+	if *inviteFlag || *createFlag {
+		if *email == "" && !*serviceUser {
 			return fmt.Errorf("--email is required when inviting a user")
 		}
+		if *serviceUser && *name == "" {
+			return fmt.Errorf("--name is required when creating a service user")
+		}
+		if err := validateUserRole(*role); err != nil {
+			return err
+		}
 
-		var groups []string
-		if *autoGroups != "" {
-			groups = strings.Split(*autoGroups, ",")
-			for i := range groups {
-				groups[i] = strings.TrimSpace(groups[i])
-			}
+		// Resolve group names/IDs to IDs
+		groups, err := s.resolveMultipleGroupIdentifiers(helpers.SplitCommaList(*autoGroups))
+		if err != nil {
+			return fmt.Errorf("failed to resolve auto-groups: %v", err)
 		}
 
 		return s.inviteUser(*email, *name, *role, groups, *serviceUser)
@@ -84,13 +99,14 @@ func (s *Service) HandleUsersCommand(args []string) error {
 		if *blocked && *unblocked {
 			return fmt.Errorf("cannot use both --blocked and --unblocked")
 		}
+		if err := validateUserRole(*role); err != nil {
+			return err
+		}
 
-		var groups []string
-		if *autoGroups != "" {
-			groups = strings.Split(*autoGroups, ",")
-			for i := range groups {
-				groups[i] = strings.TrimSpace(groups[i])
-			}
+		// Resolve group names/IDs to IDs
+		groups, err := s.resolveMultipleGroupIdentifiers(helpers.SplitCommaList(*autoGroups))
+		if err != nil {
+			return fmt.Errorf("failed to resolve auto-groups: %v", err)
 		}
 
 		isBlocked := false
@@ -101,6 +117,14 @@ func (s *Service) HandleUsersCommand(args []string) error {
 		return s.updateUser(*updateFlag, *role, groups, isBlocked)
 	}
 
+	if *blockFlag != "" {
+		return s.setUserBlocked(*blockFlag, true)
+	}
+
+	if *unblockFlag != "" {
+		return s.setUserBlocked(*unblockFlag, false)
+	}
+
 	if *removeFlag != "" {
 		return s.removeUser(*removeFlag)
 	}
@@ -113,6 +137,19 @@ func (s *Service) HandleUsersCommand(args []string) error {
 	return nil
 }
 
+// validUserRoles are the roles accepted by the NetBird API
+var validUserRoles = []string{"admin", "user", "owner", "billing_admin", "auditor", "network_admin"}
+
+// validateUserRole checks a --role value against the allowed roles
+func validateUserRole(role string) error {
+	for _, valid := range validUserRoles {
+		if role == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid role '%s' (valid: %s)", role, strings.Join(validUserRoles, ", "))
+}
+
 // listUsers lists all users in the account
 func (s *Service) listUsers(filterType string, outputFormat string) error {
 	endpoint := "/users"
@@ -122,16 +159,10 @@ func (s *Service) listUsers(filterType string, outputFormat string) error {
 		endpoint += "?service_user=false"
 	}
 
-	resp, err := s.Client.MakeRequest("GET", endpoint, nil)
+	users, err := client.GetList[models.User](s.Client, endpoint)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	var users []models.User
-	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
-	}
 
 	if len(users) == 0 {
 		fmt.Println("No users found")
@@ -140,7 +171,7 @@ func (s *Service) listUsers(filterType string, outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(users, "", "  ")
+		output, err := helpers.MarshalJSONOutput(users)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -149,8 +180,7 @@ func (s *Service) listUsers(filterType string, outputFormat string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tEMAIL\tNAME\tROLE\tSTATUS\tSERVICE\tBLOCKED\tLAST LOGIN")
-	fmt.Fprintln(w, "--\t-----\t----\t----\t------\t-------\t-------\t----------")
+	helpers.TableHeader(w, "ID", "EMAIL", "NAME", "ROLE", "STATUS", "SERVICE", "BLOCKED", "LAST LOGIN")
 
 	for _, user := range users {
 		serviceUserStr := "No"
@@ -187,7 +217,8 @@ func (s *Service) getCurrentUser(outputFormat string) error {
 	resp, err := s.Client.MakeRequest("GET", "/users/current", nil)
 	if err != nil {
 		// Check if it's a 403 error (service token)
-		if resp != nil && resp.StatusCode == 403 {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.IsForbidden() {
 			return fmt.Errorf("unable to get current user: this endpoint is not available for service user tokens. Use 'user --list' to see all users instead")
 		}
 		return err
@@ -201,7 +232,7 @@ func (s *Service) getCurrentUser(outputFormat string) error {
 
 	// JSON output
 	if outputFormat == "json" {
-		output, err := json.MarshalIndent(user, "", "  ")
+		output, err := helpers.MarshalJSONOutput(user)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %v", err)
 		}
@@ -308,6 +339,124 @@ func (s *Service) updateUser(userID, role string, autoGroups []string, isBlocked
 	return nil
 }
 
+// findUser resolves a user by ID or email (case-insensitive)
+func (s *Service) findUser(identifier string) (*models.User, error) {
+	users, err := client.GetList[models.User](s.Client, "/users")
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].ID == identifier || (users[i].Email != "" && strings.EqualFold(users[i].Email, identifier)) {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user '%s' not found (use a user ID or email)", identifier)
+}
+
+// userInspection is the "user --inspect" JSON output: the user plus resolved auto-group names
+type userInspection struct {
+	models.User
+	AutoGroupNames []string `json:"auto_group_names"`
+}
+
+// inspectUser implements the "user --inspect" command
+func (s *Service) inspectUser(identifier, outputFormat string) error {
+	user, err := s.findUser(identifier)
+	if err != nil {
+		return err
+	}
+
+	groupNames, err := s.getGroupNamesByID()
+	if err != nil {
+		return fmt.Errorf("failed to fetch groups: %v", err)
+	}
+	autoGroupNames := make([]string, len(user.AutoGroups))
+	for i, id := range user.AutoGroups {
+		autoGroupNames[i] = id
+		if name, ok := groupNames[id]; ok {
+			autoGroupNames[i] = name
+		}
+	}
+
+	// JSON output
+	if outputFormat == "json" {
+		output, err := helpers.MarshalJSONOutput(userInspection{User: *user, AutoGroupNames: autoGroupNames})
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("User: %s\n", user.ID)
+	fmt.Println("---------------------------------")
+	fmt.Printf("  Email:          %s\n", user.Email)
+	fmt.Printf("  Name:           %s\n", user.Name)
+	fmt.Printf("  Role:           %s\n", user.Role)
+	fmt.Printf("  Status:         %s\n", user.Status)
+	fmt.Printf("  Service User:   %t\n", user.IsServiceUser)
+	fmt.Printf("  Blocked:        %t\n", user.IsBlocked)
+	if lastLogin := helpers.FormatLastSeen(user.LastLogin, time.Now()); lastLogin == "never" || lastLogin == user.LastLogin {
+		fmt.Printf("  Last Login:     %s\n", lastLogin)
+	} else {
+		fmt.Printf("  Last Login:     %s (%s)\n", lastLogin, user.LastLogin)
+	}
+	dashboardView := user.Permissions.DashboardView
+	if dashboardView == "" {
+		dashboardView = "-"
+	}
+	fmt.Printf("  Dashboard View: %s\n", dashboardView)
+
+	if len(autoGroupNames) == 0 {
+		fmt.Println("  Auto Groups:    -")
+		return nil
+	}
+	fmt.Println("  Auto Groups:")
+	for _, id := range user.AutoGroups {
+		if name, ok := groupNames[id]; ok {
+			fmt.Printf("    - %s (%s)\n", name, id)
+		} else {
+			fmt.Printf("    - %s (unknown group)\n", id)
+		}
+	}
+	return nil
+}
+
+// setUserBlocked blocks or unblocks a user, keeping their role and auto-groups.
+// Blocking asks for confirmation because it revokes the user's access immediately.
+func (s *Service) setUserBlocked(identifier string, block bool) error {
+	user, err := s.findUser(identifier)
+	if err != nil {
+		return err
+	}
+
+	label := user.Email
+	if label == "" {
+		label = user.Name
+	}
+	if user.IsBlocked == block {
+		state := "blocked"
+		if !block {
+			state = "not blocked"
+		}
+		fmt.Printf("User %s (%s) is already %s\n", label, user.ID, state)
+		return nil
+	}
+
+	if block {
+		fmt.Printf("About to block user:\n")
+		fmt.Printf("  Email:   %s\n", user.Email)
+		fmt.Printf("  Name:    %s\n", user.Name)
+		fmt.Printf("  ID:      %s\n", user.ID)
+		fmt.Printf("  Role:    %s\n", user.Role)
+		if !helpers.ConfirmAction("Blocking revokes this user's access immediately. Continue?") {
+			return nil // User cancelled
+		}
+	}
+
+	return s.updateUser(user.ID, user.Role, user.AutoGroups, block)
+}
+
 // removeUser deletes a user from the account
 func (s *Service) removeUser(userID string) error {
 	// Fetch user details first
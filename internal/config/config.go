@@ -2,10 +2,16 @@
 package config
 
 import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"netbird-manage/internal/client"
 	"netbird-manage/internal/models"
@@ -17,6 +23,24 @@ const configFileName = ".netbird-manage.json"
 // DefaultCloudURL is the default NetBird cloud API URL
 const DefaultCloudURL = "https://api.netbird.io/api"
 
+// Environment variable names recognized in the process environment and in --env-file files
+const (
+	EnvToken         = "NETBIRD_API_TOKEN"
+	EnvManagementURL = "NETBIRD_MANAGEMENT_URL"
+	EnvOutput        = "NETBIRD_OUTPUT"
+)
+
+// ValidOutputFormats are the accepted values for the default output format
+var ValidOutputFormats = []string{"table", "wide", "json"}
+
+// ExtraHeaderFlags holds headers from global --extra-header flags. They apply to this run's
+// management URL only, overriding stored headers for it, and replace them when 'connect' saves
+// the config.
+var ExtraHeaderFlags = map[string]string{}
+
+// envFileValues holds values loaded by LoadEnvFile; they take precedence over the process environment
+var envFileValues = map[string]string{}
+
 // GetConfigPath returns the full path to the configuration file
 func GetConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -26,8 +50,12 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(homeDir, configFileName), nil
 }
 
-// TestAndSave validates a token by making an API call and saves it if successful
-func TestAndSave(token, managementURL string) error {
+// ErrNoToken is returned by Load when no API token is configured anywhere
+var ErrNoToken = errors.New("no token found")
+
+// TestAndSave validates a token by making an API call and saves it if successful.
+// With encrypt, the token is stored encrypted with a passphrase.
+func TestAndSave(token, managementURL string, encrypt bool) error {
 	fmt.Println("Testing connection to NetBird API at", managementURL)
 
 	// Create a temporary client to test the new credentials
@@ -36,21 +64,57 @@ func TestAndSave(token, managementURL string) error {
 	// Use "GET /api/peers" as the test endpoint
 	resp, err := testClient.MakeRequest("GET", "/peers", nil)
 	if err != nil {
-		return err
+		return classifyConnectError(err, managementURL)
 	}
 	defer resp.Body.Close()
 
 	fmt.Println("Connection successful. Saving configuration...")
+	return saveCredentials(token, managementURL, encrypt)
+}
+
+// SaveWithoutValidation saves credentials without contacting the API, for offline or
+// pre-provisioned environments
+func SaveWithoutValidation(token, managementURL string, encrypt bool) error {
+	fmt.Fprintln(os.Stderr, "Warning: skipping connection validation; the token and management URL were not checked.")
+	fmt.Fprintln(os.Stderr, "         Run 'netbird-manage doctor' once the API is reachable to verify them.")
+	return saveCredentials(token, managementURL, encrypt)
+}
+
+// saveCredentials writes the token and management URL, keeping any stored preferences.
+// With encrypt, only the encrypted token is written.
+func saveCredentials(token, managementURL string, encrypt bool) error {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return err
 	}
 
-	// Create the config struct
+	// Create the config struct, keeping any stored preferences
 	cfg := models.Config{
 		Token:         token,
 		ManagementURL: managementURL,
 	}
+	if stored, err := readStoredConfig(); err == nil {
+		cfg.OutputFormat = stored.OutputFormat
+		cfg.ExtraHeadersByURL = storedExtraHeaders(stored)
+	}
+	if len(ExtraHeaderFlags) > 0 {
+		if cfg.ExtraHeadersByURL == nil {
+			cfg.ExtraHeadersByURL = make(map[string]map[string]string)
+		}
+		cfg.ExtraHeadersByURL[client.NormalizeBaseURL(managementURL)] = ExtraHeaderFlags
+	}
+
+	if encrypt {
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		cfg.EncryptedToken, err = encryptToken(token, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt token: %v", err)
+		}
+		cfg.Token = ""
+	}
 
 	// Marshal to JSON
 	configData, err := json.MarshalIndent(cfg, "", "  ")
@@ -64,40 +128,323 @@ func TestAndSave(token, managementURL string) error {
 	}
 
 	fmt.Printf("Configuration saved successfully to %s\n", configPath)
+	if encrypt {
+		fmt.Printf("The token is encrypted; set %s or enter the passphrase when prompted\n", EnvConfigPassphrase)
+	}
+	return nil
+}
+
+// classifyConnectError turns a failed validation request into a specific message with
+// a suggestion, distinguishing network, TLS, authentication, and permission failures
+func classifyConnectError(err error, managementURL string) error {
+	var reason, suggestion string
+
+	var apiErr *client.APIError
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCertErr x509.CertificateInvalidError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 401:
+		reason = "authentication failed (401 Unauthorized): the token is invalid or expired"
+		suggestion = "Create a new Personal Access Token in the NetBird dashboard and try again"
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 403:
+		reason = "the token is valid but not allowed to list peers (403 Forbidden)"
+		suggestion = "Use a token from an admin or network admin user, or a service user with admin role"
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 404:
+		reason = "API endpoint not found (404 Not Found)"
+		suggestion = "Check the management URL; it usually ends in /api (e.g. https://netbird.example.com/api)"
+	case errors.As(err, &apiErr):
+		reason = apiErr.Error()
+		suggestion = "Re-run with --debug to see the full response"
+	case errors.As(err, &dnsErr):
+		reason = fmt.Sprintf("cannot resolve host '%s'", dnsErr.Name)
+		suggestion = "Check the management URL spelling and your DNS settings"
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthErr), errors.As(err, &hostnameErr), errors.As(err, &invalidCertErr):
+		reason = fmt.Sprintf("TLS certificate verification failed (%v)", err)
+		suggestion = "The server certificate is invalid or untrusted; check the certificate or add its CA to your system store"
+	case errors.As(err, &netErr):
+		reason = fmt.Sprintf("cannot reach %s (%v)", managementURL, err)
+		suggestion = "Check your network connection, firewall, or proxy; use --skip-validation to save the config anyway"
+	default:
+		return err
+	}
+
+	return fmt.Errorf("%s\n  Suggestion: %s", reason, suggestion)
+}
+
+// LoadEnvFile reads KEY=VALUE pairs from a dotenv-style file for use during config resolution.
+// Blank lines and lines starting with '#' are ignored, an optional "export " prefix is allowed,
+// and values may be wrapped in single or double quotes. The process environment is not modified.
+func LoadEnvFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read env file: %v", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("invalid env file line %d: expected KEY=VALUE", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read env file: %v", err)
+	}
+
+	envFileValues = values
 	return nil
 }
 
-// Load loads the API token and URL from the config file or environment variable
+// lookupEnv returns a value from the loaded env file, falling back to the process environment
+func lookupEnv(key string) string {
+	if value := envFileValues[key]; value != "" {
+		return value
+	}
+	return os.Getenv(key)
+}
+
+// Load resolves the API token and URL. Precedence (highest first):
+//  1. Values from --env-file
+//  2. Process environment (NETBIRD_API_TOKEN, NETBIRD_MANAGEMENT_URL)
+//  3. Stored config file
+//
+// The URL falls back to the stored config only when the token also came from it,
+// so an env token is never sent to a URL saved for a different account.
 func Load() (*models.Config, error) {
+	token := lookupEnv(EnvToken)
+	managementURL := lookupEnv(EnvManagementURL)
+
+	if token == "" {
+		if cfg, err := readStoredConfig(); err == nil {
+			token = cfg.Token
+			if token == "" && cfg.EncryptedToken != nil {
+				passphrase, err := readPassphrase(false)
+				if err != nil {
+					return nil, err
+				}
+				if token, err = decryptToken(cfg.EncryptedToken, passphrase); err != nil {
+					return nil, err
+				}
+			}
+			if managementURL == "" {
+				managementURL = cfg.ManagementURL
+			}
+		}
+	}
+
+	if token == "" {
+		return nil, ErrNoToken
+	}
+
+	// If URL is somehow empty, use the default
+	if managementURL == "" {
+		managementURL = DefaultCloudURL
+	}
+
+	return &models.Config{
+		Token:         token,
+		ManagementURL: managementURL,
+	}, nil
+}
+
+// TokenSource describes where Load would take the API token from, or "" if no token is configured.
+// An error is returned when the stored config file exists but cannot be read or parsed.
+func TokenSource() (string, error) {
+	if envFileValues[EnvToken] != "" {
+		return "--env-file (" + EnvToken + ")", nil
+	}
+	if os.Getenv(EnvToken) != "" {
+		return "environment (" + EnvToken + ")", nil
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := readStoredConfig()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %v", configPath, err)
+	}
+	if cfg.Token == "" && cfg.EncryptedToken != nil {
+		return "config file (" + configPath + ", encrypted)", nil
+	}
+	if cfg.Token == "" {
+		return "", nil
+	}
+	return "config file (" + configPath + ")", nil
+}
+
+// readStoredConfig reads the config file written by 'connect'
+func readStoredConfig() (*models.Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return nil, err
 	}
 
-	// Try loading from config file first
 	configData, err := os.ReadFile(configPath)
-	if err == nil {
-		var cfg models.Config
-		if err := json.Unmarshal(configData, &cfg); err == nil {
-			// If URL is somehow empty in file, set default
-			if cfg.ManagementURL == "" {
-				cfg.ManagementURL = DefaultCloudURL
-			}
-			if cfg.Token != "" {
-				return &cfg, nil
-			}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg models.Config
+	if err := json.Unmarshal(configData, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	return &cfg, nil
+}
+
+// isValidOutputFormat reports whether format is a supported output format
+func isValidOutputFormat(format string) bool {
+	for _, valid := range ValidOutputFormats {
+		if format == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultOutputFormat resolves the output format used when --output isn't given.
+// Precedence: NETBIRD_OUTPUT (env file or environment), then the stored config, then "table".
+// An invalid value produces a warning and falls back to "table".
+func DefaultOutputFormat() (string, string) {
+	format, source := lookupEnv(EnvOutput), EnvOutput
+	if format == "" {
+		if cfg, err := readStoredConfig(); err == nil {
+			format, source = cfg.OutputFormat, "config file"
+		}
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return "table", ""
+	}
+	if !isValidOutputFormat(format) {
+		return "table", fmt.Sprintf("ignoring invalid output format '%s' from %s (valid: %s)",
+			format, source, strings.Join(ValidOutputFormats, ", "))
+	}
+	return format, ""
+}
+
+// ParseExtraHeader parses an --extra-header value of the form "Name: Value"
+func ParseExtraHeader(header string) (string, string, error) {
+	name, value, ok := strings.Cut(header, ":")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("invalid extra header '%s': expected 'Name: Value'", header)
+	}
+	if strings.EqualFold(name, "Authorization") {
+		return "", "", fmt.Errorf("extra headers cannot set Authorization; use the API token instead")
+	}
+	return name, value, nil
+}
+
+// ResolveExtraHeaders returns the stored extra headers per management URL (see
+// client.ExtraHeadersByURL), with --extra-header flags overlaid on the headers of managementURL,
+// the URL this run talks to
+func ResolveExtraHeaders(managementURL string) map[string]map[string]string {
+	byURL := make(map[string]map[string]string)
+	if stored, err := readStoredConfig(); err == nil {
+		byURL = storedExtraHeaders(stored)
+	}
+	if len(ExtraHeaderFlags) > 0 {
+		key := client.NormalizeBaseURL(managementURL)
+		headers := make(map[string]string)
+		for name, value := range byURL[key] {
+			headers[name] = value
+		}
+		for name, value := range ExtraHeaderFlags {
+			headers[name] = value
+		}
+		byURL[key] = headers
+	}
+	return byURL
+}
+
+// storedExtraHeaders returns the config's headers keyed by normalized management URL. Headers
+// from older configs (extra_headers) belong to the stored management URL.
+func storedExtraHeaders(cfg *models.Config) map[string]map[string]string {
+	byURL := make(map[string]map[string]string)
+	if len(cfg.ExtraHeaders) > 0 {
+		legacyURL := cfg.ManagementURL
+		if legacyURL == "" {
+			legacyURL = DefaultCloudURL
+		}
+		byURL[client.NormalizeBaseURL(legacyURL)] = cfg.ExtraHeaders
+	}
+	for managementURL, headers := range cfg.ExtraHeadersByURL {
+		byURL[client.NormalizeBaseURL(managementURL)] = headers
+	}
+	return byURL
+}
+
+// ManagementURL returns the management URL Load would use, without reading the token:
+// NETBIRD_MANAGEMENT_URL, else the stored URL when the token also comes from the config
+// file, else NetBird Cloud
+func ManagementURL() string {
+	if managementURL := lookupEnv(EnvManagementURL); managementURL != "" {
+		return managementURL
+	}
+	if lookupEnv(EnvToken) == "" {
+		if cfg, err := readStoredConfig(); err == nil && cfg.ManagementURL != "" {
+			return cfg.ManagementURL
 		}
 	}
+	return DefaultCloudURL
+}
 
-	// If file doesn't exist or is empty, try environment variable
-	token := os.Getenv("NETBIRD_API_TOKEN")
-	if token != "" {
-		// If using env var, assume default cloud URL
-		return &models.Config{
-			Token:         token,
-			ManagementURL: DefaultCloudURL,
-		}, nil
+// SaveDefaultOutput stores the default output format in the config file
+func SaveDefaultOutput(format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if !isValidOutputFormat(format) {
+		return fmt.Errorf("invalid output format '%s' (valid: %s)", format, strings.Join(ValidOutputFormats, ", "))
 	}
 
-	return nil, fmt.Errorf("no token found")
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := readStoredConfig()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		cfg = &models.Config{}
+	}
+	cfg.OutputFormat = format
+
+	configData, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize config: %v", err)
+	}
+	if err := os.WriteFile(configPath, configData, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+
+	fmt.Printf("Default output format set to '%s' in %s\n", format, configPath)
+	return nil
 }
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"netbird-manage/internal/models"
+)
+
+// EnvConfigPassphrase supplies the passphrase for an encrypted config without prompting
+const EnvConfigPassphrase = "NETBIRD_CONFIG_PASSPHRASE"
+
+// Token encryption parameters: PBKDF2-HMAC-SHA256 derives an AES-256-GCM key from the passphrase
+const (
+	tokenKDF           = "pbkdf2-sha256"
+	tokenCipher        = "aes-256-gcm"
+	tokenKDFIterations = 600000
+	tokenSaltSize      = 16
+	tokenKeySize       = 32
+)
+
+// encryptToken seals the API token with a key derived from passphrase
+func encryptToken(token, passphrase string) (*models.EncryptedToken, error) {
+	salt := make([]byte, tokenSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	gcm, err := tokenAEAD(passphrase, salt, tokenKDFIterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return &models.EncryptedToken{
+		KDF:        tokenKDF,
+		Iterations: tokenKDFIterations,
+		Cipher:     tokenCipher,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, []byte(token), nil)),
+	}, nil
+}
+
+// decryptToken opens an encrypted token; a wrong passphrase fails GCM authentication
+func decryptToken(enc *models.EncryptedToken, passphrase string) (string, error) {
+	if enc.KDF != tokenKDF || enc.Cipher != tokenCipher {
+		return "", fmt.Errorf("unsupported token encryption (kdf %s, cipher %s)", enc.KDF, enc.Cipher)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(enc.Salt)
+	if err != nil {
+		return "", fmt.Errorf("corrupt encrypted token: invalid salt")
+	}
+	nonce, err := base64.StdEncoding.DecodeString(enc.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("corrupt encrypted token: invalid nonce")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(enc.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("corrupt encrypted token: invalid ciphertext")
+	}
+
+	gcm, err := tokenAEAD(passphrase, salt, enc.Iterations)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("corrupt encrypted token: invalid nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the stored token: wrong passphrase or corrupted config")
+	}
+	return string(plaintext), nil
+}
+
+// tokenAEAD derives the key and returns the AES-GCM cipher
+func tokenAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("corrupt encrypted token: invalid iteration count")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, tokenKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPassphrase returns NETBIRD_CONFIG_PASSPHRASE if set, otherwise prompts on the terminal.
+// With confirm, the passphrase must be typed twice.
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := lookupEnv(EnvConfigPassphrase); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("cannot prompt for the config passphrase without a terminal; set %s", EnvConfigPassphrase)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	passphrase, err := promptHidden(reader, "Config passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	if confirm {
+		again, err := promptHidden(reader, "Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptHidden reads a line from the terminal with echo turned off where stty is available
+func promptHidden(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	echoOff := exec.Command("stty", "-echo")
+	echoOff.Stdin = os.Stdin
+	if echoOff.Run() == nil {
+		defer func() {
+			echoOn := exec.Command("stty", "echo")
+			echoOn.Stdin = os.Stdin
+			echoOn.Run()
+			fmt.Fprintln(os.Stderr)
+		}()
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase (%v); set %s to supply it non-interactively", err, EnvConfigPassphrase)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
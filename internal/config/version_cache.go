@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// versionCacheFileName holds probed management server versions, keyed by management URL
+const versionCacheFileName = ".netbird-manage-versions.json"
+
+// VersionCacheTTL is how long a probed server version is reused before probing again
+const VersionCacheTTL = 24 * time.Hour
+
+// cachedServerVersion is one entry of the version cache; an empty Version records
+// that the server does not report one, so it is not re-probed on every run
+type cachedServerVersion struct {
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// versionCachePath returns the full path to the version cache file
+func versionCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, versionCacheFileName), nil
+}
+
+// readVersionCache loads the version cache; a missing or unreadable file is an empty cache
+func readVersionCache() map[string]cachedServerVersion {
+	cache := make(map[string]cachedServerVersion)
+	path, err := versionCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[string]cachedServerVersion)
+	}
+	return cache
+}
+
+// CachedServerVersion returns the version last probed for a management URL, if the entry
+// is younger than VersionCacheTTL. An empty version with ok=true means "probed, not reported".
+func CachedServerVersion(managementURL string) (version string, ok bool) {
+	entry, found := readVersionCache()[managementURL]
+	if !found || time.Since(entry.CheckedAt) > VersionCacheTTL {
+		return "", false
+	}
+	return entry.Version, true
+}
+
+// SaveServerVersion records the probed version for a management URL. The cache is only an
+// optimization, so write failures are ignored.
+func SaveServerVersion(managementURL, version string) {
+	path, err := versionCachePath()
+	if err != nil {
+		return
+	}
+	cache := readVersionCache()
+	cache[managementURL] = cachedServerVersion{Version: version, CheckedAt: time.Now()}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,197 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MarshalJSONOutput marshals v for --output json. When --fields is given, each object (or
+// each element of a list of objects) is reduced to those top-level fields, in the order given.
+func MarshalJSONOutput(v interface{}) ([]byte, error) {
+	// A filtered list that matched nothing is often a nil slice; print it as [] rather than null
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+
+	if len(OutputFields) == 0 {
+		return json.MarshalIndent(v, "", "  ")
+	}
+
+	known, err := jsonFieldNamesOf(v)
+	if err != nil {
+		return nil, err
+	}
+	if known != nil {
+		for _, field := range OutputFields {
+			if !known[field] {
+				return nil, fmt.Errorf("unknown field '%s' for --fields (available: %s)", field, strings.Join(sortedFieldNames(known), ", "))
+			}
+		}
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	var compact bytes.Buffer
+	switch value := data.(type) {
+	case []interface{}:
+		// Map outputs have no struct to validate against, so check the fields are present somewhere
+		if known == nil {
+			if err := checkFieldsPresent(value); err != nil {
+				return nil, err
+			}
+		}
+		compact.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				compact.WriteByte(',')
+			}
+			if err := writeProjected(&compact, item); err != nil {
+				return nil, err
+			}
+		}
+		compact.WriteByte(']')
+	case map[string]interface{}:
+		if known == nil {
+			if err := checkFieldsPresent([]interface{}{value}); err != nil {
+				return nil, err
+			}
+		}
+		if err := writeProjected(&compact, value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("--fields applies to JSON objects; this output is not an object or a list of objects")
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// writeProjected writes item with only OutputFields; fields the object omits are written as null
+func writeProjected(buf *bytes.Buffer, item interface{}) error {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("--fields applies to JSON objects; this output is not a list of objects")
+	}
+	buf.WriteByte('{')
+	for i, field := range OutputFields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		value, err := json.Marshal(object[field])
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// checkFieldsPresent validates OutputFields against the keys of map-based outputs
+func checkFieldsPresent(items []interface{}) error {
+	present := make(map[string]bool)
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			for key := range object {
+				present[key] = true
+			}
+		}
+	}
+	if len(present) == 0 {
+		return nil
+	}
+	for _, field := range OutputFields {
+		if !present[field] {
+			return fmt.Errorf("unknown field '%s' for --fields (available: %s)", field, strings.Join(sortedFieldNames(present), ", "))
+		}
+	}
+	return nil
+}
+
+// jsonFieldNamesOf returns the JSON field names of v's object type (or its element type for
+// slices). It returns nil for maps, whose keys are only known at runtime.
+func jsonFieldNamesOf(v interface{}) (map[string]bool, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	if t == nil {
+		return nil, fmt.Errorf("--fields applies to JSON objects; this output is empty")
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		names := make(map[string]bool)
+		collectJSONFieldNames(t, names)
+		return names, nil
+	case reflect.Map, reflect.Interface:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("--fields applies to JSON objects; this output is a list of %s values", t.Kind())
+	}
+}
+
+// collectJSONFieldNames adds the JSON names of t's exported fields, following untagged
+// embedded structs the way encoding/json flattens them
+func collectJSONFieldNames(t reflect.Type, names map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJSONFieldNames(embedded, names)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+}
+
+// sortedFieldNames lists field names alphabetically for error messages
+func sortedFieldNames(names map[string]bool) []string {
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
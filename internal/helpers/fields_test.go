@@ -0,0 +1,56 @@
+package helpers
+
+import (
+	"testing"
+)
+
+type fieldsTestItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	IP   string `json:"ip"`
+}
+
+func TestMarshalJSONOutputFields(t *testing.T) {
+	defer func(saved []string) { OutputFields = saved }(OutputFields)
+
+	var none []fieldsTestItem
+	tests := []struct {
+		name   string
+		fields []string
+		value  interface{}
+		want   string
+	}{
+		{name: "nil list without fields", value: none, want: "[]"},
+		{name: "nil list with fields", fields: []string{"id", "name"}, value: none, want: "[]"},
+		{name: "empty list with fields", fields: []string{"id"}, value: []fieldsTestItem{}, want: "[]"},
+		{
+			name:   "list projected in field order",
+			fields: []string{"name", "id"},
+			value:  []fieldsTestItem{{ID: "p1", Name: "web", IP: "100.64.0.1"}},
+			want:   "[\n  {\n    \"name\": \"web\",\n    \"id\": \"p1\"\n  }\n]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			OutputFields = tt.fields
+			got, err := MarshalJSONOutput(tt.value)
+			if err != nil {
+				t.Fatalf("MarshalJSONOutput: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSONOutput = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONOutputUnknownField(t *testing.T) {
+	defer func(saved []string) { OutputFields = saved }(OutputFields)
+
+	OutputFields = []string{"bogus"}
+	var none []fieldsTestItem
+	if _, err := MarshalJSONOutput(none); err == nil {
+		t.Error("expected an error for an unknown field on an empty list")
+	}
+}
@@ -3,11 +3,16 @@ package helpers
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 )
 
 var (
@@ -17,6 +22,28 @@ var (
 
 	// SkipConfirmation is set to true when --yes flag is provided
 	SkipConfirmation = false
+
+	// ConfirmPhrase is set when --confirm-phrase is provided; bulk deletions compare it
+	// to their expected phrase instead of prompting
+	ConfirmPhrase = ""
+
+	// OutputTemplate is set when --template flag is provided; list commands render
+	// each item through it instead of printing a table
+	OutputTemplate = ""
+
+	// OutputFields is set when --fields is provided; JSON output keeps only these
+	// top-level fields of each object
+	OutputFields []string
+
+	// NoHeaders is set when --no-headers is provided; list tables omit their header and
+	// separator rows so only data rows are printed
+	NoHeaders = false
+
+	// DefaultOutputFormat is the --output default, resolved from NETBIRD_OUTPUT or the config file
+	DefaultOutputFormat = "table"
+
+	// tableFallbackWarned ensures the JSON fallback warning is printed at most once
+	tableFallbackWarned = false
 )
 
 func init() {
@@ -37,6 +64,45 @@ func FormatOS(osStr string) string {
 	return osStr
 }
 
+// FormatLastSeen renders an RFC3339 timestamp as "X ago" relative to now. Peers that have
+// never connected (empty or zero timestamp) show "never"; unparseable values are returned as-is.
+func FormatLastSeen(timestamp string, now time.Time) string {
+	if timestamp == "" {
+		return "never"
+	}
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	if t.IsZero() || t.Year() <= 1 {
+		return "never"
+	}
+
+	elapsed := now.Sub(t)
+	if elapsed < time.Minute {
+		return "just now"
+	}
+
+	var n int
+	var unit string
+	switch {
+	case elapsed < time.Hour:
+		n, unit = int(elapsed/time.Minute), "minute"
+	case elapsed < 24*time.Hour:
+		n, unit = int(elapsed/time.Hour), "hour"
+	case elapsed < 30*24*time.Hour:
+		n, unit = int(elapsed/(24*time.Hour)), "day"
+	case elapsed < 365*24*time.Hour:
+		n, unit = int(elapsed/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(elapsed/(365*24*time.Hour)), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
 // ValidateNetBirdIP validates that an IP address is within the NetBird CGNAT range
 // NetBird uses 100.64.0.0/10 (100.64.0.0 to 100.127.255.255)
 func ValidateNetBirdIP(ipStr string) error {
@@ -153,18 +219,29 @@ func SplitCommaList(input string) []string {
 // ReorderArgsForFlags reorders command arguments to put flags before positional arguments.
 // This allows users to write: command file.yml --flag
 // instead of requiring: command --flag file.yml
-// Go's flag package requires flags before positional arguments.
-func ReorderArgsForFlags(args []string) []string {
+// Go's flag package requires flags before positional arguments. valueFlags names the flags
+// that take a separate value (e.g. "on-conflict"), so that value moves with its flag.
+func ReorderArgsForFlags(args []string, valueFlags ...string) []string {
 	if len(args) == 0 {
 		return args
 	}
 
+	takesValue := make(map[string]bool, len(valueFlags))
+	for _, name := range valueFlags {
+		takesValue[name] = true
+	}
+
 	var flags []string
 	var positional []string
 
-	for _, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		if strings.HasPrefix(arg, "-") {
 			flags = append(flags, arg)
+			if takesValue[strings.TrimLeft(arg, "-")] && i+1 < len(args) {
+				flags = append(flags, args[i+1])
+				i++
+			}
 		} else {
 			positional = append(positional, arg)
 		}
@@ -225,6 +302,17 @@ func ConfirmBulkDeletion(resourceType string, items []string, count int) bool {
 	// Generate confirmation text
 	confirmText := fmt.Sprintf("delete %d %s", count, resourceType)
 
+	// A phrase supplied with --confirm-phrase must match exactly; never fall back to a prompt
+	if ConfirmPhrase != "" {
+		if strings.TrimSpace(ConfirmPhrase) == confirmText {
+			fmt.Fprintf(os.Stderr, "\nConfirmed with --confirm-phrase '%s'\n", confirmText)
+			return true
+		}
+		fmt.Fprintf(os.Stderr, "\n--confirm-phrase '%s' does not match '%s'\n", ConfirmPhrase, confirmText)
+		fmt.Fprintln(os.Stderr, "Operation cancelled")
+		return false
+	}
+
 	fmt.Fprintf(os.Stderr, "\nType '%s' to confirm:\n> ", confirmText)
 
 	reader := bufio.NewReader(os.Stdin)
@@ -372,3 +460,215 @@ func MigrationKeyDurationBounds() *DurationBounds {
 		ClampToBounds: true,     // Clamp instead of error
 	}
 }
+
+// FieldDiff describes a single field-level difference between two values
+type FieldDiff struct {
+	Path string      // Dotted path to the field (e.g. "rules.ssh.ports")
+	Kind string      // "added", "removed", or "changed"
+	Old  interface{} // Value in the current state (nil when added)
+	New  interface{} // Value in the desired state (nil when removed)
+}
+
+// NormalizeValue converts a value into its generic JSON form (maps, slices, float64, string, bool)
+// so values decoded from YAML and values built from API models can be compared directly.
+func NormalizeValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// DiffValues compares two normalized values and returns their field-level differences.
+// Map keys starting with "_" are treated as annotations and ignored.
+// Slices are compared as a whole and reported as a single change.
+func DiffValues(path string, current, desired interface{}) []FieldDiff {
+	currentMap, currentIsMap := current.(map[string]interface{})
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+
+	if !currentIsMap || !desiredIsMap {
+		if reflect.DeepEqual(current, desired) {
+			return nil
+		}
+		return []FieldDiff{{Path: path, Kind: "changed", Old: current, New: desired}}
+	}
+
+	// Collect keys from both sides in a stable order
+	keySet := make(map[string]bool)
+	for key := range currentMap {
+		keySet[key] = true
+	}
+	for key := range desiredMap {
+		keySet[key] = true
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		if !strings.HasPrefix(key, "_") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []FieldDiff
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		currentValue, inCurrent := currentMap[key]
+		desiredValue, inDesired := desiredMap[key]
+
+		switch {
+		case !inCurrent:
+			diffs = append(diffs, FieldDiff{Path: fieldPath, Kind: "added", New: desiredValue})
+		case !inDesired:
+			diffs = append(diffs, FieldDiff{Path: fieldPath, Kind: "removed", Old: currentValue})
+		default:
+			diffs = append(diffs, DiffValues(fieldPath, currentValue, desiredValue)...)
+		}
+	}
+
+	return diffs
+}
+
+// ClosestMatches returns up to max candidates that are close to input, best match first.
+// Comparison ignores case, spaces, and hyphens so "NewYork" matches "New York".
+func ClosestMatches(input string, candidates []string, max int) []string {
+	normalize := func(s string) string {
+		s = strings.ToLower(s)
+		s = strings.ReplaceAll(s, " ", "")
+		return strings.ReplaceAll(s, "-", "")
+	}
+
+	target := normalize(input)
+	// Allow roughly one edit per three characters, but at least two
+	threshold := len(target) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	type scored struct {
+		value    string
+		distance int
+	}
+	var matches []scored
+	for _, candidate := range candidates {
+		normalized := normalize(candidate)
+		distance := levenshtein(target, normalized)
+		if strings.HasPrefix(normalized, target) && len(target) >= 3 {
+			distance = 1 // Treat prefixes as close matches
+		}
+		if distance <= threshold {
+			matches = append(matches, scored{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	result := make([]string, 0, max)
+	for i := 0; i < len(matches) && i < max; i++ {
+		result = append(result, matches[i].value)
+	}
+	return result
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// templateFuncs are the helper functions available to --template
+var templateFuncs = template.FuncMap{
+	// join renders a list separated by sep; items with a Name field use it
+	"join": func(list interface{}, sep string) string {
+		v := reflect.ValueOf(list)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return fmt.Sprint(list)
+		}
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := reflect.Indirect(v.Index(i))
+			if item.Kind() == reflect.Struct {
+				if name := item.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+					parts = append(parts, name.String())
+					continue
+				}
+			}
+			parts = append(parts, fmt.Sprint(item.Interface()))
+		}
+		return strings.Join(parts, sep)
+	},
+	// default returns def when value is empty, e.g. {{.Hostname | default "n/a"}}
+	"default": func(def, value interface{}) interface{} {
+		if value == nil {
+			return def
+		}
+		v := reflect.ValueOf(value)
+		if v.IsZero() || ((v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0) {
+			return def
+		}
+		return value
+	},
+}
+
+// RenderTemplate renders each element of items (a slice) through OutputTemplate,
+// one item per line
+func RenderTemplate(items interface{}) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(OutputTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %v", err)
+	}
+
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("--template requires a list of items")
+	}
+
+	addNewline := !strings.HasSuffix(OutputTemplate, "\n")
+	for i := 0; i < v.Len(); i++ {
+		if err := tmpl.Execute(os.Stdout, v.Index(i).Interface()); err != nil {
+			return fmt.Errorf("failed to render --template: %v", err)
+		}
+		if addNewline {
+			fmt.Println()
+		}
+	}
+
+	return nil
+}
+
+// WarnTableOnly notes on stderr (once per run) that a view has no JSON output
+// and will be printed as a table instead
+func WarnTableOnly(outputFormat, what string) {
+	if outputFormat != "json" || tableFallbackWarned {
+		return
+	}
+	tableFallbackWarned = true
+	fmt.Fprintf(os.Stderr, "Note: JSON output is not supported for %s; showing table\n", what)
+}
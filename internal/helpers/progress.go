@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// progressBarWidth is the number of cells in the rendered progress bar
+const progressBarWidth = 30
+
+// Progress renders a compact "X/Y processed" line that is redrawn in place.
+// While active, regular stdout output is discarded so the per-resource lines don't
+// scroll past the indicator; call Finish before printing a final summary.
+// A nil or inactive Progress is a no-op, so callers can use it unconditionally.
+type Progress struct {
+	out      *os.File // The real stdout the indicator is drawn on
+	discard  *os.File
+	total    int
+	done     int
+	label    string
+	finished bool
+	silent   bool // Discard output without drawing an indicator (--summary-only)
+}
+
+// StartProgress returns an active Progress when enabled and stdout is a terminal.
+// Otherwise it returns nil and output stays line-by-line.
+func StartProgress(enabled bool, total int) *Progress {
+	if !enabled || total <= 0 || !IsTerminal(os.Stdout) {
+		return nil
+	}
+
+	discard, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil
+	}
+
+	p := &Progress{out: os.Stdout, discard: discard, total: total}
+	os.Stdout = discard
+	p.render()
+	return p
+}
+
+// SuppressOutput returns a Progress that discards regular stdout output without drawing an
+// indicator, so only what is printed after Finish (the summary) is shown. Unlike
+// StartProgress it also works when stdout is not a terminal. It returns nil when not enabled.
+func SuppressOutput(enabled bool) *Progress {
+	if !enabled {
+		return nil
+	}
+
+	discard, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil
+	}
+
+	p := &Progress{out: os.Stdout, discard: discard, silent: true}
+	os.Stdout = discard
+	return p
+}
+
+// IsTerminal reports whether f is an interactive terminal rather than a pipe or file
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Stage sets the resource type currently being processed
+func (p *Progress) Stage(label string) {
+	if p == nil || p.finished {
+		return
+	}
+	p.label = label
+	p.render()
+}
+
+// Step marks one item as processed
+func (p *Progress) Step() {
+	if p == nil || p.finished {
+		return
+	}
+	if p.done < p.total {
+		p.done++
+	}
+	p.render()
+}
+
+// Finish draws the final state and restores stdout. It is safe to call more than once.
+func (p *Progress) Finish() {
+	if p == nil || p.finished {
+		return
+	}
+	if !p.silent {
+		p.label = "done"
+		p.render()
+		fmt.Fprintln(p.out)
+		fmt.Fprintln(p.out)
+	}
+
+	os.Stdout = p.out
+	p.discard.Close()
+	p.finished = true
+}
+
+// render redraws the indicator on the current terminal line
+func (p *Progress) render() {
+	if p.silent {
+		return
+	}
+	filled := p.done * progressBarWidth / p.total
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	fmt.Fprintf(p.out, "\r\033[K[%s] %d/%d processed  %s", bar, p.done, p.total, p.label)
+}
@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// OutputWide is the --output value that adds secondary columns to list tables
+const OutputWide = "wide"
+
+// TableColumn describes one table column; Wide columns are only shown with --output wide
+type TableColumn struct {
+	Header string
+	Wide   bool
+}
+
+// Table renders aligned list output with a header and dashed separator (see TableHeader). Commands declare
+// every column once and pass a value for each in Row; wide-only columns are dropped unless
+// the table was created for wide output.
+type Table struct {
+	w       *tabwriter.Writer
+	columns []TableColumn
+	wide    bool
+}
+
+// NewTable starts a table on stdout and prints its header for the given output format
+func NewTable(outputFormat string, columns ...TableColumn) *Table {
+	t := &Table{
+		w:       tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0),
+		columns: columns,
+		wide:    outputFormat == OutputWide,
+	}
+
+	var headers []string
+	for _, column := range t.visible() {
+		headers = append(headers, column.Header)
+	}
+	TableHeader(t.w, headers...)
+	return t
+}
+
+// TableHeader writes a tab-separated header row and its dashed separator, unless
+// --no-headers was given
+func TableHeader(w io.Writer, headers ...string) {
+	if NoHeaders {
+		return
+	}
+	separators := make([]string, len(headers))
+	for i, header := range headers {
+		separators[i] = strings.Repeat("-", len(header))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
+}
+
+// visible returns the columns shown for this table's output format
+func (t *Table) visible() []TableColumn {
+	var columns []TableColumn
+	for _, column := range t.columns {
+		if !column.Wide || t.wide {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Row writes one row; cells correspond to the columns passed to NewTable
+func (t *Table) Row(cells ...string) {
+	var shown []string
+	for i, column := range t.columns {
+		if column.Wide && !t.wide {
+			continue
+		}
+		if i < len(cells) {
+			shown = append(shown, cells[i])
+		} else {
+			shown = append(shown, "")
+		}
+	}
+	fmt.Fprintln(t.w, strings.Join(shown, "\t"))
+}
+
+// Line writes tab-separated cells as-is, for detail lines (such as policy rules) that
+// align with the table but do not follow its columns
+func (t *Table) Line(cells ...string) {
+	fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+// TableFooter prints a summary line after a table (such as "Total: 3 routes"), unless
+// --no-headers was given
+func TableFooter(format string, args ...interface{}) {
+	if NoHeaders {
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// Flush writes the buffered table to stdout
+func (t *Table) Flush() {
+	t.w.Flush()
+}
@@ -5,6 +5,22 @@ package models
 type Config struct {
 	Token         string `json:"token"`
 	ManagementURL string `json:"management_url"`
+	OutputFormat  string `json:"output_format,omitempty"` // Default --output for list/inspect commands
+
+	ExtraHeaders      map[string]string            `json:"extra_headers,omitempty"`        // Legacy: headers for ManagementURL; read, no longer written
+	ExtraHeadersByURL map[string]map[string]string `json:"extra_headers_by_url,omitempty"` // Static headers per management URL (auth proxies)
+
+	EncryptedToken *EncryptedToken `json:"encrypted_token,omitempty"` // Set instead of Token by 'connect --encrypt'
+}
+
+// EncryptedToken is the API token sealed with a passphrase-derived key (connect --encrypt)
+type EncryptedToken struct {
+	KDF        string `json:"kdf"`        // Key derivation function, "pbkdf2-sha256"
+	Iterations int    `json:"iterations"` // KDF iteration count
+	Cipher     string `json:"cipher"`     // AEAD cipher, "aes-256-gcm"
+	Salt       string `json:"salt"`       // Base64 KDF salt
+	Nonce      string `json:"nonce"`      // Base64 AEAD nonce
+	Ciphertext string `json:"ciphertext"` // Base64 sealed token
 }
 
 // Peer represents a single NetBird peer (from peers.mdx)
@@ -42,6 +58,13 @@ type PolicyGroup struct {
 	ResourcesCount int    `json:"resources_count,omitempty"`
 }
 
+// ResolvedGroupRef pairs a group ID with its resolved name for display output
+type ResolvedGroupRef struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Missing bool   `json:"missing,omitempty"` // True if the ID no longer matches any group
+}
+
 // GroupDetail represents the full group object (from groups.mdx)
 type GroupDetail struct {
 	ID             string          `json:"id"`